@@ -20,6 +20,10 @@ type Config struct {
 	Password  string
 	Name      string
 	CORS      CORSConfig
+	Alerts    AlertsConfig
+	Storage   StorageConfig
+
+	RebatchIntervalMinutes int
 }
 
 type CORSConfig struct {
@@ -31,6 +35,28 @@ type CORSConfig struct {
 	MaxAge           int
 }
 
+type AlertsConfig struct {
+	IntervalMinutes int
+	SMTPHost        string
+	SMTPPort        string
+	SMTPUser        string
+	SMTPPassword    string
+	SMTPFrom        string
+	WebhookURL      string
+	WebhookSecret   string
+}
+
+type StorageConfig struct {
+	Driver      string
+	LocalPath   string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PathStyle bool
+}
+
 func Load() (*Config, error) {
 	if err := godotenv.Load(); err != nil {
 		fmt.Printf("WARNING: Não foi possível carregar .env: %v\n", err)
@@ -81,6 +107,10 @@ func Load() (*Config, error) {
 		Password:  dbPassword,
 		Name:      dbName,
 		CORS:      loadCORSConfig(),
+		Alerts:    loadAlertsConfig(),
+		Storage:   loadStorageConfig(),
+
+		RebatchIntervalMinutes: parseInt(getEnvWithDefault("REBATCH_INTERVAL_MINUTES", "0")),
 	}, nil
 }
 
@@ -104,6 +134,32 @@ func loadCORSConfig() CORSConfig {
 	return corsConfig
 }
 
+func loadAlertsConfig() AlertsConfig {
+	return AlertsConfig{
+		IntervalMinutes: parseInt(getEnvWithDefault("ALERTS_INTERVAL_MINUTES", "0")),
+		SMTPHost:        getEnvWithDefault("SMTP_HOST", ""),
+		SMTPPort:        getEnvWithDefault("SMTP_PORT", "587"),
+		SMTPUser:        getEnvWithDefault("SMTP_USER", ""),
+		SMTPPassword:    getEnvWithDefault("SMTP_PASSWORD", ""),
+		SMTPFrom:        getEnvWithDefault("SMTP_FROM", "alertas@fazendapro.com"),
+		WebhookURL:      getEnvWithDefault("ALERTS_WEBHOOK_URL", ""),
+		WebhookSecret:   getEnvWithDefault("ALERTS_WEBHOOK_SECRET", ""),
+	}
+}
+
+func loadStorageConfig() StorageConfig {
+	return StorageConfig{
+		Driver:      getEnvWithDefault("STORAGE_DRIVER", "local"),
+		LocalPath:   getEnvWithDefault("STORAGE_LOCAL_PATH", "./data/storage"),
+		S3Endpoint:  getEnvWithDefault("S3_ENDPOINT", ""),
+		S3Region:    getEnvWithDefault("S3_REGION", "us-east-1"),
+		S3Bucket:    getEnvWithDefault("S3_BUCKET", ""),
+		S3AccessKey: getEnvWithDefault("S3_ACCESS_KEY", ""),
+		S3SecretKey: getEnvWithDefault("S3_SECRET_KEY", ""),
+		S3PathStyle: getEnvWithDefault("S3_PATH_STYLE", "true") == "true",
+	}
+}
+
 func splitEnvVar(value string) []string {
 	if value == "" {
 		return []string{}
@@ -1,23 +1,30 @@
 package handlers
 
 import (
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"github.com/fazendapro/FazendaPro-api/internal/service"
+	"github.com/fazendapro/FazendaPro-api/internal/utils"
 )
 
 type AnimalHandler struct {
-	service *service.AnimalService
+	service           *service.AnimalService
+	photoService      service.AnimalPhotoService
+	attachmentService service.AnimalAttachmentService
 }
 
-func NewAnimalHandler(service *service.AnimalService) *AnimalHandler {
-	return &AnimalHandler{service: service}
+func NewAnimalHandler(service *service.AnimalService, photoService service.AnimalPhotoService, attachmentService service.AnimalAttachmentService) *AnimalHandler {
+	return &AnimalHandler{service: service, photoService: photoService, attachmentService: attachmentService}
 }
 
 type AnimalData struct {
@@ -48,10 +55,14 @@ type CreateAnimalRequest struct {
 
 type AnimalResponse struct {
 	AnimalData
-	Father    *AnimalParent `json:"father,omitempty"`
-	Mother    *AnimalParent `json:"mother,omitempty"`
-	CreatedAt string        `json:"createdAt"`
-	UpdatedAt string        `json:"updatedAt"`
+	PhotoThumbnail   string        `json:"photo_thumbnail,omitempty"`
+	LatestWeight     *float64      `json:"latest_weight,omitempty"`
+	LatestWeightDate string        `json:"latest_weight_date,omitempty"`
+	AverageDailyGain *float64      `json:"average_daily_gain,omitempty"`
+	Father           *AnimalParent `json:"father,omitempty"`
+	Mother           *AnimalParent `json:"mother,omitempty"`
+	CreatedAt        string        `json:"createdAt"`
+	UpdatedAt        string        `json:"updatedAt"`
 }
 
 type AnimalParent struct {
@@ -80,7 +91,6 @@ func animalDataToModel(data AnimalData) models.Animal {
 		Breed:                data.Breed,
 		Type:                 data.Type,
 		BirthDate:            birthDate,
-		Photo:                data.Photo,
 		FatherID:             data.FatherID,
 		MotherID:             data.MotherID,
 		Confinement:          data.Confinement,
@@ -95,6 +105,16 @@ func animalDataToModel(data AnimalData) models.Animal {
 	return animal
 }
 
+func (response *AnimalResponse) setWeightSummary(summary *service.AnimalWeightSummary) {
+	if summary == nil || summary.LatestWeight == nil {
+		return
+	}
+	latest := summary.LatestWeight.AnimalWeight
+	response.LatestWeight = &latest
+	response.LatestWeightDate = summary.LatestWeight.Date.Format(DateFormatISO)
+	response.AverageDailyGain = summary.AverageDailyGain
+}
+
 func modelToAnimalResponse(animal *models.Animal) AnimalResponse {
 	var birthDate string
 	if animal.BirthDate != nil {
@@ -130,7 +150,7 @@ func modelToAnimalResponse(animal *models.Animal) AnimalResponse {
 			Breed:                animal.Breed,
 			Type:                 animal.Type,
 			BirthDate:            birthDate,
-			Photo:                animal.Photo,
+			Photo:                animalPhotoURL(animal),
 			FatherID:             animal.FatherID,
 			MotherID:             animal.MotherID,
 			Confinement:          animal.Confinement,
@@ -141,10 +161,11 @@ func modelToAnimalResponse(animal *models.Animal) AnimalResponse {
 			Purpose:              animal.Purpose,
 			CurrentBatch:         animal.CurrentBatch,
 		},
-		Father:    father,
-		Mother:    mother,
-		CreatedAt: animal.CreatedAt.Format(DateFormatDateTime),
-		UpdatedAt: animal.UpdatedAt.Format(DateFormatDateTime),
+		PhotoThumbnail: animalThumbnailURL(animal),
+		Father:         father,
+		Mother:         mother,
+		CreatedAt:      animal.CreatedAt.Format(DateFormatDateTime),
+		UpdatedAt:      animal.UpdatedAt.Format(DateFormatDateTime),
 	}
 }
 
@@ -160,7 +181,17 @@ func (h *AnimalHandler) CreateAnimal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	if !checkBodyFarmID(w, req.FarmID, farmID) {
+		return
+	}
+
 	animal := animalDataToModel(req.AnimalData)
+	animal.FarmID = farmID
 
 	if err := h.service.CreateAnimal(&animal); err != nil {
 		SendErrorResponse(w, "Erro ao criar animal: "+err.Error(), http.StatusBadRequest)
@@ -186,54 +217,64 @@ func (h *AnimalHandler) GetAnimal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	animal, err := h.service.GetAnimalByID(uint(id))
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	animal, err := h.service.GetAnimalByID(uint(id), farmID)
 	if err != nil {
 		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	if animal == nil {
-		SendErrorResponse(w, "Animal não encontrado", http.StatusNotFound)
+		SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
 		return
 	}
 
 	response := modelToAnimalResponse(animal)
+	summary, err := h.service.GetWeightSummary(animal.ID, farmID)
+	if err != nil {
+		log.Printf("Erro ao buscar pesagens do animal %d: %v", animal.ID, err)
+	}
+	response.setWeightSummary(summary)
 	SendSuccessResponse(w, response, "Animal encontrado com sucesso", http.StatusOK)
 }
 
 func (h *AnimalHandler) GetAnimalsByFarm(w http.ResponseWriter, r *http.Request) {
-	farmID := r.URL.Query().Get("farmId")
-	if farmID == "" {
-		fmt.Printf("Erro: farmId não fornecido\n")
-
-		SendErrorResponse(w, "ID da fazenda é obrigatório", http.StatusBadRequest)
+	id, ok := resolveFarmID(w, r, r.URL.Query().Get("farmId"))
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseUint(farmID, 10, 32)
+	animals, err := h.service.GetAnimalsByFarmID(id)
 	if err != nil {
-		fmt.Printf("Erro ao converter farmId: %v\n", err)
-		SendErrorResponse(w, "ID da fazenda inválido", http.StatusBadRequest)
+		fmt.Printf("Erro ao buscar animais: %v\n", err)
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	fmt.Printf("FarmID convertido: %d\n", id)
+	responses := h.animalResponsesWithWeights(animals, id)
 
-	animals, err := h.service.GetAnimalsByFarmID(uint(id))
+	fmt.Printf("FarmID: %d, Animais encontrados: %d\n", id, len(animals))
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Animais encontrados com sucesso (%d animais)", len(animals)), http.StatusOK)
+}
+
+func (h *AnimalHandler) animalResponsesWithWeights(animals []models.Animal, farmID uint) []AnimalResponse {
+	summaries, err := h.service.GetWeightSummaries(farmID)
 	if err != nil {
-		fmt.Printf("Erro ao buscar animais: %v\n", err)
-		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
-		return
+		log.Printf("Erro ao buscar pesagens da fazenda %d: %v", farmID, err)
 	}
 
 	var responses []AnimalResponse
 	for _, animal := range animals {
-		responses = append(responses, modelToAnimalResponse(&animal))
+		response := modelToAnimalResponse(&animal)
+		response.setWeightSummary(summaries[animal.ID])
+		responses = append(responses, response)
 	}
-
-	fmt.Printf("FarmID: %d, Animais encontrados: %d\n", id, len(animals))
-
-	SendSuccessResponse(w, responses, fmt.Sprintf("Animais encontrados com sucesso (%d animais)", len(animals)), http.StatusOK)
+	return responses
 }
 
 func (h *AnimalHandler) UpdateAnimal(w http.ResponseWriter, r *http.Request) {
@@ -248,14 +289,23 @@ func (h *AnimalHandler) UpdateAnimal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	if !checkBodyFarmID(w, req.FarmID, farmID) {
+		return
+	}
+
 	animal := animalDataToModel(req.AnimalData)
 
-	if err := h.service.UpdateAnimal(&animal); err != nil {
+	if err := h.service.UpdateAnimal(&animal, farmID); err != nil {
 		SendErrorResponse(w, "Erro ao atualizar animal: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	updated, err := h.service.GetAnimalByID(animal.ID)
+	updated, err := h.service.GetAnimalByID(animal.ID, farmID)
 	if err != nil || updated == nil {
 		SendSuccessResponse(w, nil, "Animal atualizado com sucesso", http.StatusOK)
 		return
@@ -283,31 +333,35 @@ func (h *AnimalHandler) DeleteAnimal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.DeleteAnimal(uint(id)); err != nil {
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	animal, _ := h.service.GetAnimalByID(uint(id), farmID)
+	attachments, _ := h.attachmentService.GetAttachments(r.Context(), uint(id), farmID, "")
+	if err := h.service.DeleteAnimal(uint(id), farmID); err != nil {
 		SendErrorResponse(w, "Erro ao deletar animal: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if animal != nil {
+		h.photoService.RemovePhotos(r.Context(), animal)
+	}
+	h.attachmentService.RemoveFiles(r.Context(), attachments)
 
 	SendSuccessResponse(w, nil, "Animal deletado com sucesso", http.StatusOK)
 }
 
 func (h *AnimalHandler) GetAnimalsBySex(w http.ResponseWriter, r *http.Request) {
-	farmID := r.URL.Query().Get("farmId")
 	sex := r.URL.Query().Get("sex")
 
-	if farmID == "" {
-		SendErrorResponse(w, "ID da fazenda é obrigatório", http.StatusBadRequest)
-		return
-	}
-
 	if sex == "" {
 		SendErrorResponse(w, "Sexo é obrigatório", http.StatusBadRequest)
 		return
 	}
 
-	farmIDUint, err := strconv.ParseUint(farmID, 10, 32)
-	if err != nil {
-		SendErrorResponse(w, "ID da fazenda inválido", http.StatusBadRequest)
+	farmID, ok := resolveFarmID(w, r, r.URL.Query().Get("farmId"))
+	if !ok {
 		return
 	}
 
@@ -317,16 +371,13 @@ func (h *AnimalHandler) GetAnimalsBySex(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	animals, err := h.service.GetAnimalsByFarmIDAndSex(uint(farmIDUint), sexInt)
+	animals, err := h.service.GetAnimalsByFarmIDAndSex(farmID, sexInt)
 	if err != nil {
 		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	var responses []AnimalResponse
-	for _, animal := range animals {
-		responses = append(responses, modelToAnimalResponse(&animal))
-	}
+	responses := h.animalResponsesWithWeights(animals, farmID)
 
 	SendSuccessResponse(w, responses, fmt.Sprintf("Animais encontrados com sucesso (%d animais)", len(animals)), http.StatusOK)
 }
@@ -355,6 +406,11 @@ func (h *AnimalHandler) UploadAnimalPhoto(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
 	file, _, err := r.FormFile("photo")
 	if err != nil {
 		SendErrorResponse(w, "Erro ao obter arquivo: "+err.Error(), http.StatusBadRequest)
@@ -362,40 +418,113 @@ func (h *AnimalHandler) UploadAnimalPhoto(w http.ResponseWriter, r *http.Request
 	}
 	defer file.Close()
 
-	fileBytes := make([]byte, 0)
-	buffer := make([]byte, 1024)
-	for {
-		n, err := file.Read(buffer)
-		if err != nil && err.Error() != "EOF" {
-			SendErrorResponse(w, "Erro ao ler arquivo: "+err.Error(), http.StatusBadRequest)
-			return
-		}
-		if n == 0 {
-			break
+	fileBytes, err := io.ReadAll(io.LimitReader(file, service.AnimalPhotoMaxSize+1))
+	if err != nil {
+		SendErrorResponse(w, "Erro ao ler arquivo: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updatedAnimal, err := h.photoService.UploadPhoto(r.Context(), uint(id), farmID, fileBytes)
+	if err != nil {
+		switch {
+		case err.Error() == service.ErrAnimalNotFound:
+			SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+		case err.Error() == service.ErrAnimalPhotoTooLarge, errors.Is(err, utils.ErrUnsupportedImage), errors.Is(err, utils.ErrImageTooLarge):
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		default:
+			SendErrorResponse(w, "Erro ao atualizar foto do animal: "+err.Error(), http.StatusInternalServerError)
 		}
-		fileBytes = append(fileBytes, buffer[:n]...)
+		return
+	}
+	if updatedAnimal == nil {
+		SendErrorResponse(w, "Erro ao buscar animal atualizado", http.StatusInternalServerError)
+		return
+	}
+
+	response := modelToAnimalResponse(updatedAnimal)
+	SendSuccessResponse(w, response, "Foto do animal atualizada com sucesso", http.StatusOK)
+}
+
+type AnimalImportRowErrorResponse struct {
+	Row     int    `json:"row"`
+	EarTag  int    `json:"ear_tag,omitempty"`
+	Message string `json:"message"`
+}
+
+type AnimalImportResponse struct {
+	DryRun     bool                           `json:"dry_run"`
+	TotalRows  int                            `json:"total_rows"`
+	ValidRows  int                            `json:"valid_rows"`
+	Imported   int                            `json:"imported"`
+	Duplicates []AnimalImportRowErrorResponse `json:"duplicates"`
+	Errors     []AnimalImportRowErrorResponse `json:"errors"`
+}
+
+func animalImportRowErrorsToResponse(rowErrors []service.AnimalImportRowError) []AnimalImportRowErrorResponse {
+	responses := make([]AnimalImportRowErrorResponse, len(rowErrors))
+	for i, rowErr := range rowErrors {
+		responses[i] = AnimalImportRowErrorResponse{Row: rowErr.Row, EarTag: rowErr.EarTag, Message: rowErr.Message}
+	}
+	return responses
+}
+
+func (h *AnimalHandler) ImportAnimals(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		SendErrorResponse(w, "Erro ao fazer parse do formulário: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		SendErrorResponse(w, "Erro ao obter arquivo: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	format := strings.ToLower(r.FormValue("format"))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
 	}
 
-	photoBase64 := fmt.Sprintf("data:image/jpeg;base64,%s", base64.StdEncoding.EncodeToString(fileBytes))
+	dryRun := true
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			SendErrorResponse(w, "Parâmetro dry_run inválido, use true ou false", http.StatusBadRequest)
+			return
+		}
+		dryRun = parsed
+	}
 
-	animal, err := h.service.GetAnimalByID(uint(id))
-	if err != nil || animal == nil {
-		SendErrorResponse(w, "Animal não encontrado", http.StatusNotFound)
+	result, err := h.service.ImportAnimals(farmID, format, file, dryRun)
+	if err != nil {
+		SendErrorResponse(w, "Erro ao importar animais: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	animal.Photo = photoBase64
-	if err := h.service.UpdateAnimal(animal); err != nil {
-		SendErrorResponse(w, "Erro ao atualizar foto do animal: "+err.Error(), http.StatusInternalServerError)
+	response := AnimalImportResponse{
+		DryRun:     result.DryRun,
+		TotalRows:  result.TotalRows,
+		ValidRows:  result.ValidRows,
+		Imported:   result.Imported,
+		Duplicates: animalImportRowErrorsToResponse(result.Duplicates),
+		Errors:     animalImportRowErrorsToResponse(result.Errors),
+	}
+
+	if len(response.Errors) > 0 {
+		SendErrorResponseWithData(w, response, fmt.Sprintf("Nenhum animal importado: %d linhas com erro", len(response.Errors)), http.StatusUnprocessableEntity)
 		return
 	}
 
-	updatedAnimal, err := h.service.GetAnimalByID(uint(id))
-	if err != nil || updatedAnimal == nil {
-		SendErrorResponse(w, "Erro ao buscar animal atualizado", http.StatusInternalServerError)
+	if dryRun {
+		SendSuccessResponse(w, response, fmt.Sprintf("Arquivo validado: %d animais prontos para importar, %d brincos já cadastrados", response.ValidRows, len(response.Duplicates)), http.StatusOK)
 		return
 	}
 
-	response := modelToAnimalResponse(updatedAnimal)
-	SendSuccessResponse(w, response, "Foto do animal atualizada com sucesso", http.StatusOK)
+	SendSuccessResponse(w, response, fmt.Sprintf("Animais importados com sucesso (%d registros, %d brincos já cadastrados ignorados)", response.Imported, len(response.Duplicates)), http.StatusCreated)
 }
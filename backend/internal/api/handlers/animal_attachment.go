@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
+	"github.com/fazendapro/FazendaPro-api/internal/utils"
+)
+
+type AnimalAttachmentHandler struct {
+	service service.AnimalAttachmentService
+}
+
+func NewAnimalAttachmentHandler(service service.AnimalAttachmentService) *AnimalAttachmentHandler {
+	return &AnimalAttachmentHandler{service: service}
+}
+
+type AnimalAttachmentResponse struct {
+	ID           uint    `json:"id"`
+	AnimalID     uint    `json:"animal_id"`
+	FarmID       uint    `json:"farm_id"`
+	Kind         string  `json:"kind"`
+	FileName     string  `json:"file_name"`
+	ContentType  string  `json:"content_type"`
+	Size         int64   `json:"size"`
+	Description  string  `json:"description"`
+	DocumentDate *string `json:"document_date"`
+	DownloadURL  string  `json:"download_url"`
+	ThumbnailURL string  `json:"thumbnail_url,omitempty"`
+	UploadedByID *uint   `json:"uploaded_by_id"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+func modelToAnimalAttachmentResponse(attachment *models.AnimalAttachment) AnimalAttachmentResponse {
+	downloadURL := fmt.Sprintf("/api/v1/animals/%d/attachments/%d/download", attachment.AnimalID, attachment.ID)
+	response := AnimalAttachmentResponse{
+		ID:           attachment.ID,
+		AnimalID:     attachment.AnimalID,
+		FarmID:       attachment.FarmID,
+		Kind:         attachment.Kind,
+		FileName:     attachment.FileName,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		Description:  attachment.Description,
+		DownloadURL:  downloadURL,
+		UploadedByID: attachment.UploadedByID,
+		CreatedAt:    attachment.CreatedAt.Format(DateFormatDateTime),
+	}
+	if attachment.DocumentDate != nil {
+		date := attachment.DocumentDate.Format(DateFormatISO)
+		response.DocumentDate = &date
+	}
+	if attachment.ThumbnailKey != "" {
+		response.ThumbnailURL = downloadURL + "?thumbnail=true"
+	}
+	return response
+}
+
+func sendAnimalAttachmentError(w http.ResponseWriter, err error) {
+	switch {
+	case err.Error() == service.ErrAnimalNotFound:
+		SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+	case err.Error() == service.ErrAnimalAttachmentNotFoundOrNotBelongsToFarm, errors.Is(err, storage.ErrNotFound):
+		SendErrorResponse(w, ErrAttachmentNotFound, http.StatusNotFound)
+	case err.Error() == service.ErrAnimalAttachmentTooLarge,
+		err.Error() == service.ErrInvalidAnimalAttachmentKind,
+		err.Error() == service.ErrUnsupportedAnimalAttachment,
+		err.Error() == service.ErrAnimalAttachmentPhotoNotImage,
+		errors.Is(err, utils.ErrImageTooLarge):
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Printf("Erro ao processar anexo: %v", err)
+		SendErrorResponse(w, ErrInternalServer, http.StatusInternalServerError)
+	}
+}
+
+func (h *AnimalAttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	animalID, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, service.AnimalAttachmentMaxSize+1<<20)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		SendErrorResponse(w, "Erro ao fazer parse do formulário: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		SendErrorResponse(w, "Erro ao obter arquivo: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, service.AnimalAttachmentMaxSize+1))
+	if err != nil {
+		SendErrorResponse(w, "Erro ao ler arquivo: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	attachment := &models.AnimalAttachment{
+		FarmID:      farmID,
+		AnimalID:    animalID,
+		Kind:        r.FormValue("kind"),
+		FileName:    header.Filename,
+		Description: r.FormValue("description"),
+	}
+	if value := r.FormValue("document_date"); value != "" {
+		date, err := time.Parse(DateFormatISO, value)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+			return
+		}
+		attachment.DocumentDate = &date
+	}
+	if userID, ok := userIDFromContext(r); ok {
+		attachment.UploadedByID = &userID
+	}
+
+	if err := h.service.UploadAttachment(r.Context(), attachment, data); err != nil {
+		sendAnimalAttachmentError(w, err)
+		return
+	}
+
+	SendSuccessResponse(w, modelToAnimalAttachmentResponse(attachment), "Anexo enviado com sucesso", http.StatusCreated)
+}
+
+func (h *AnimalAttachmentHandler) GetAttachments(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	animalID, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return
+	}
+
+	attachments, err := h.service.GetAttachments(r.Context(), animalID, farmID, r.URL.Query().Get("kind"))
+	if err != nil {
+		sendAnimalAttachmentError(w, err)
+		return
+	}
+
+	responses := make([]AnimalAttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		responses = append(responses, modelToAnimalAttachmentResponse(attachment))
+	}
+
+	SendSuccessResponse(w, responses, "Anexos encontrados com sucesso", http.StatusOK)
+}
+
+func (h *AnimalAttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	animalID, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "attachmentId")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAttachmentID, http.StatusBadRequest)
+		return
+	}
+
+	thumbnail := r.URL.Query().Get("thumbnail") == "true"
+	attachment, object, err := h.service.OpenAttachment(r.Context(), id, animalID, farmID, thumbnail)
+	if err != nil {
+		sendAnimalAttachmentError(w, err)
+		return
+	}
+	defer object.Body.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	if thumbnail {
+		disposition = "inline"
+	}
+	w.Header().Set(HeaderContentType, object.ContentType)
+	if object.Size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(object.Size, 10))
+	}
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, object.Body)
+}
+
+func (h *AnimalAttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	animalID, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "attachmentId")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAttachmentID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteAttachment(r.Context(), id, animalID, farmID); err != nil {
+		sendAnimalAttachmentError(w, err)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Anexo removido com sucesso", http.StatusOK)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
+	"github.com/go-chi/chi/v5"
+)
+
+const PhotoURLPrefix = "/api/v1/photos/"
+
+func animalPhotoURL(animal *models.Animal) string {
+	if animal.PhotoKey != "" {
+		return PhotoURLPrefix + animal.PhotoKey
+	}
+	return animal.Photo
+}
+
+func animalThumbnailURL(animal *models.Animal) string {
+	if animal.PhotoThumbnailKey != "" {
+		return PhotoURLPrefix + animal.PhotoThumbnailKey
+	}
+	return animalPhotoURL(animal)
+}
+
+func (h *AnimalHandler) GetAnimalPhoto(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	object, err := h.photoService.GetPhoto(r.Context(), chi.URLParam(r, "*"), farmID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			SendErrorResponse(w, "Foto não encontrada", http.StatusNotFound)
+			return
+		}
+		log.Printf("Erro ao buscar foto %s: %v", chi.URLParam(r, "*"), err)
+		SendErrorResponse(w, ErrInternalServer, http.StatusInternalServerError)
+		return
+	}
+	defer object.Body.Close()
+
+	w.Header().Set(HeaderContentType, object.ContentType)
+	if object.Size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(object.Size, 10))
+	}
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, object.Body)
+}
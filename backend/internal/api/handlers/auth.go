@@ -187,10 +187,16 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AuthHandler) generateJWT(user *models.User) (string, error) {
+	role, err := h.service.GetUserFarmRole(user.ID, user.FarmID)
+	if err != nil || !models.IsValidFarmRole(role) {
+		role = models.FarmRoleReadOnly
+	}
+
 	claims := jwt.MapClaims{
 		"sub":     user.ID,
 		"email":   user.Person.Email,
 		"farm_id": user.FarmID,
+		"role":    role,
 		"iat":     time.Now().Unix(),
 		"exp":     time.Now().Add(time.Hour * 24).Unix(),
 	}
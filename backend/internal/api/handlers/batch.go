@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type BatchHandler struct {
+	service *service.BatchService
+}
+
+func NewBatchHandler(service *service.BatchService) *BatchHandler {
+	return &BatchHandler{service: service}
+}
+
+type BatchRuleData struct {
+	ID                uint     `json:"id,omitempty"`
+	Batch             int      `json:"batch"`
+	Name              string   `json:"name"`
+	Priority          int      `json:"priority,omitempty"`
+	MinLiters         *float64 `json:"min_liters"`
+	MaxLiters         *float64 `json:"max_liters"`
+	AverageWindowDays int      `json:"average_window_days"`
+	Phase             *int     `json:"phase"`
+	MinDaysInMilk     *int     `json:"min_days_in_milk"`
+	MaxDaysInMilk     *int     `json:"max_days_in_milk"`
+}
+
+type SaveBatchRulesRequest struct {
+	Rules []BatchRuleData `json:"rules"`
+}
+
+type BatchRulesResponse struct {
+	UsingDefault bool            `json:"using_default"`
+	Rules        []BatchRuleData `json:"rules"`
+}
+
+type RebatchSummaryResponse struct {
+	FarmsProcessed   int `json:"farms_processed"`
+	AnimalsEvaluated int `json:"animals_evaluated"`
+	AnimalsMoved     int `json:"animals_moved"`
+}
+
+type BatchMoveResponse struct {
+	ID         uint   `json:"id"`
+	AnimalID   uint   `json:"animal_id"`
+	AnimalName string `json:"animal_name"`
+	FromBatch  int    `json:"from_batch"`
+	ToBatch    int    `json:"to_batch"`
+	RuleID     *uint  `json:"rule_id"`
+	RuleName   string `json:"rule_name"`
+	Reason     string `json:"reason"`
+	MovedAt    string `json:"moved_at"`
+}
+
+func (d *BatchRuleData) toModel() models.BatchRule {
+	rule := models.BatchRule{
+		Batch:             d.Batch,
+		Name:              d.Name,
+		MinLiters:         d.MinLiters,
+		MaxLiters:         d.MaxLiters,
+		AverageWindowDays: d.AverageWindowDays,
+		MinDaysInMilk:     d.MinDaysInMilk,
+		MaxDaysInMilk:     d.MaxDaysInMilk,
+	}
+	if d.Phase != nil {
+		phase := models.ReproductionPhase(*d.Phase)
+		rule.Phase = &phase
+	}
+	return rule
+}
+
+func modelToBatchRuleData(rule *models.BatchRule) BatchRuleData {
+	data := BatchRuleData{
+		ID:                rule.ID,
+		Batch:             rule.Batch,
+		Name:              rule.Name,
+		Priority:          rule.Priority,
+		MinLiters:         rule.MinLiters,
+		MaxLiters:         rule.MaxLiters,
+		AverageWindowDays: rule.AverageWindowDays,
+		MinDaysInMilk:     rule.MinDaysInMilk,
+		MaxDaysInMilk:     rule.MaxDaysInMilk,
+	}
+	if rule.Phase != nil {
+		phase := int(*rule.Phase)
+		data.Phase = &phase
+	}
+	return data
+}
+
+func rebatchSummaryToResponse(summary *service.RebatchSummary) RebatchSummaryResponse {
+	return RebatchSummaryResponse{
+		FarmsProcessed:   summary.FarmsProcessed,
+		AnimalsEvaluated: summary.AnimalsEvaluated,
+		AnimalsMoved:     summary.AnimalsMoved,
+	}
+}
+
+func (h *BatchHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	rules, err := h.service.GetRules(farmID)
+	if err != nil {
+		SendErrorResponse(w, "Erro ao buscar regras de lote: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := BatchRulesResponse{
+		UsingDefault: len(rules) == 0,
+		Rules:        make([]BatchRuleData, len(rules)),
+	}
+	for i := range rules {
+		response.Rules[i] = modelToBatchRuleData(&rules[i])
+	}
+
+	SendSuccessResponse(w, response, fmt.Sprintf("Regras de lote encontradas com sucesso (%d registros)", len(rules)), http.StatusOK)
+}
+
+func (h *BatchHandler) SaveRules(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req SaveBatchRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rules := make([]models.BatchRule, len(req.Rules))
+	for i := range req.Rules {
+		rules[i] = req.Rules[i].toModel()
+	}
+
+	summary, err := h.service.SaveRules(farmID, rules)
+	if err != nil {
+		SendErrorResponse(w, "Erro ao salvar regras de lote: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, rebatchSummaryToResponse(summary), fmt.Sprintf("Regras de lote salvas com sucesso (%d animais movidos)", summary.AnimalsMoved), http.StatusOK)
+}
+
+func (h *BatchHandler) Rebatch(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	summary, err := h.service.RebatchFarm(farmID, time.Now())
+	if err != nil {
+		SendErrorResponse(w, "Erro ao reagrupar lotes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, rebatchSummaryToResponse(summary), fmt.Sprintf("Lotes recalculados com sucesso (%d animais movidos)", summary.AnimalsMoved), http.StatusOK)
+}
+
+func (h *BatchHandler) GetMoves(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var animalID *uint
+	if value := r.URL.Query().Get("animal_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+			return
+		}
+		id := uint(parsed)
+		animalID = &id
+	}
+
+	var limit int
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidLimitParam, http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	moves, err := h.service.GetMoves(farmID, animalID, limit)
+	if err != nil {
+		if err.Error() == service.ErrAnimalNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]BatchMoveResponse, len(moves))
+	for i, move := range moves {
+		responses[i] = BatchMoveResponse{
+			ID:         move.ID,
+			AnimalID:   move.AnimalID,
+			AnimalName: move.Animal.AnimalName,
+			FromBatch:  move.FromBatch,
+			ToBatch:    move.ToBatch,
+			RuleID:     move.RuleID,
+			RuleName:   move.RuleName,
+			Reason:     move.Reason,
+			MovedAt:    move.MovedAt.Format(DateFormatDateTime),
+		}
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Movimentações de lote encontradas com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
@@ -1,19 +1,57 @@
 package handlers
 
 const (
-	ErrMethodNotAllowed       = "Método não permitido"
-	ErrAnimalIDRequired       = "ID do animal é obrigatório"
-	ErrInvalidAnimalID        = "ID do animal inválido"
-	ErrDecodeJSON             = "Erro ao decodificar JSON: "
-	ErrInternalServer         = "Erro interno do servidor"
-	ErrGenerateToken          = "Erro ao gerar token"
-	ErrFarmIDNotFound         = "Farm ID not found in context"
-	ErrInvalidFarmID          = "ID da fazenda inválido"
-	ErrInvalidSaleID          = "Invalid sale ID"
-	ErrSaleNotFound           = "Sale not found"
-	ErrSaleNotBelongsToFarm   = "Sale does not belong to the specified farm"
-	ErrAnimalNotBelongsToFarm = "Animal does not belong to the specified farm"
-	ErrInvalidMonthsParam     = "Invalid months parameter"
+	ErrMethodNotAllowed         = "Método não permitido"
+	ErrAnimalIDRequired         = "ID do animal é obrigatório"
+	ErrInvalidAnimalID          = "ID do animal inválido"
+	ErrDecodeJSON               = "Erro ao decodificar JSON: "
+	ErrInternalServer           = "Erro interno do servidor"
+	ErrGenerateToken            = "Erro ao gerar token"
+	ErrFarmIDNotFound           = "Farm ID not found in context"
+	ErrInvalidFarmID            = "ID da fazenda inválido"
+	ErrInvalidSaleID            = "Invalid sale ID"
+	ErrSaleNotFound             = "Sale not found"
+	ErrSaleNotBelongsToFarm     = "Sale does not belong to the specified farm"
+	ErrAnimalNotBelongsToFarm   = "Animal does not belong to the specified farm"
+	ErrInvalidMonthsParam       = "Invalid months parameter"
+	ErrInvalidWeightID          = "ID da pesagem inválido"
+	ErrWeightNotFound           = "Pesagem não encontrada"
+	ErrInvalidExpenseID         = "ID da despesa inválido"
+	ErrExpenseNotFound          = "Despesa não encontrada"
+	ErrInvalidDateFormat        = "Formato de data inválido. Use YYYY-MM-DD"
+	ErrDebtNotFound             = "Dívida não encontrada"
+	ErrFarmAccessDenied         = "Acesso negado aos dados desta fazenda"
+	ErrAnimalNotFound           = "Animal não encontrado"
+	ErrMilkCollectionNotFound   = "Coleta de leite não encontrada"
+	ErrReproductionNotFound     = "Registro de reprodução não encontrado"
+	ErrInvalidGenerations       = "Parâmetro generations inválido"
+	ErrInvalidSireID            = "ID do reprodutor inválido"
+	ErrInvalidDamID             = "ID da matriz inválido"
+	ErrInvalidSemenCatalogID    = "ID do sêmen inválido"
+	ErrSemenCatalogNotFound     = "Sêmen não encontrado no catálogo"
+	ErrInvalidLimitParam        = "Parâmetro limit inválido"
+	ErrInvalidFieldType         = "Campo %s com tipo inválido: esperado %s"
+	ErrInvalidGestationPeriodID = "ID da duração de gestação inválido"
+	ErrGestationPeriodNotFound  = "Duração de gestação não encontrada"
+	ErrInvalidNotificationID    = "ID da notificação inválido"
+	ErrNotificationNotFound     = "Notificação não encontrada"
+	ErrInvalidMilkQualityTestID = "ID da análise de qualidade inválido"
+	ErrMilkQualityTestNotFound  = "Análise de qualidade do leite não encontrada"
+	ErrInvalidMilkQualityType   = "Parâmetro type inválido: use tank ou animal"
+	ErrInvalidMilkPriceTableID  = "ID da tabela de preço inválido"
+	ErrMilkPriceTableNotFound   = "Tabela de preço do leite não encontrada"
+	ErrNoMilkPriceTableForMonth = "Nenhuma tabela de preço vigente para o comprador no mês"
+	ErrInvalidMilkDeliveryID    = "ID da entrega de leite inválido"
+	ErrMilkDeliveryNotFound     = "Entrega de leite não encontrada"
+	ErrInvalidMilkPaymentID     = "ID do pagamento do leite inválido"
+	ErrMilkPaymentNotFound      = "Pagamento do leite não encontrado"
+	ErrInvalidMonthFormat       = "Formato de mês inválido. Use YYYY-MM"
+	ErrBuyerRequired            = "Comprador é obrigatório"
+	ErrFarmNotFound             = "Fazenda não encontrada"
+	ErrUserIDNotFound           = "User ID not found in context"
+	ErrInvalidCompanyID         = "ID da empresa inválido"
+	ErrInvalidAttachmentID      = "ID do anexo inválido"
+	ErrAttachmentNotFound       = "Anexo não encontrado"
 )
 
 const (
@@ -26,4 +64,5 @@ const (
 	DateFormatISO      = "2006-01-02"
 	DateFormatDateTime = "2006-01-02 15:04:05"
 	DateFormatISO8601  = "2006-01-02T15:04:05.000Z"
+	MonthFormat        = "2006-01"
 )
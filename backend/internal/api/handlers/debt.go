@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
 	"github.com/fazendapro/FazendaPro-api/internal/service"
 	"github.com/go-chi/chi/v5"
 )
@@ -19,16 +21,43 @@ func NewDebtHandler(service *service.DebtService) *DebtHandler {
 }
 
 type CreateDebtRequest struct {
-	Person string  `json:"person"`
-	Value  float64 `json:"value"`
+	Person           string  `json:"person"`
+	Value            float64 `json:"value"`
+	CounterpartyType string  `json:"counterparty_type"`
+	SaleID           *uint   `json:"sale_id"`
+	ExpenseID        *uint   `json:"expense_id"`
+	DueDate          string  `json:"due_date"`
+}
+
+type DebtPaymentRequest struct {
+	Amount      float64 `json:"amount"`
+	PaymentDate string  `json:"payment_date"`
+	Notes       string  `json:"notes"`
+}
+
+type DebtPaymentResponse struct {
+	ID          uint    `json:"id"`
+	Amount      float64 `json:"amount"`
+	PaymentDate string  `json:"payment_date"`
+	Notes       string  `json:"notes"`
 }
 
 type DebtResponse struct {
-	ID        uint    `json:"id"`
-	Person    string  `json:"person"`
-	Value     float64 `json:"value"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	ID               uint                  `json:"id"`
+	FarmID           uint                  `json:"farm_id"`
+	Person           string                `json:"person"`
+	CounterpartyType string                `json:"counterparty_type,omitempty"`
+	SaleID           *uint                 `json:"sale_id,omitempty"`
+	ExpenseID        *uint                 `json:"expense_id,omitempty"`
+	Value            float64               `json:"value"`
+	PaidAmount       float64               `json:"paid_amount"`
+	RemainingAmount  float64               `json:"remaining_amount"`
+	DueDate          *string               `json:"due_date"`
+	Status           string                `json:"status"`
+	PaidAt           *string               `json:"paid_at"`
+	Payments         []DebtPaymentResponse `json:"payments,omitempty"`
+	CreatedAt        string                `json:"created_at"`
+	UpdatedAt        string                `json:"updated_at"`
 }
 
 type DebtListResponse struct {
@@ -38,7 +67,63 @@ type DebtListResponse struct {
 	Limit int            `json:"limit"`
 }
 
+func formatOptionalDate(date *time.Time, layout string) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(layout)
+	return &formatted
+}
+
+func modelToDebtResponse(debt *models.Debt) DebtResponse {
+	response := DebtResponse{
+		ID:               debt.ID,
+		FarmID:           debt.FarmID,
+		Person:           debt.Person,
+		CounterpartyType: debt.CounterpartyType,
+		SaleID:           debt.SaleID,
+		ExpenseID:        debt.ExpenseID,
+		Value:            debt.Value,
+		PaidAmount:       debt.PaidAmount,
+		RemainingAmount:  debt.RemainingAmount(),
+		DueDate:          formatOptionalDate(debt.DueDate, DateFormatISO),
+		Status:           debt.Status,
+		PaidAt:           formatOptionalDate(debt.PaidAt, DateFormatISO),
+		CreatedAt:        debt.CreatedAt.Format(DateFormatISO8601),
+		UpdatedAt:        debt.UpdatedAt.Format(DateFormatISO8601),
+	}
+	for _, payment := range debt.Payments {
+		response.Payments = append(response.Payments, DebtPaymentResponse{
+			ID:          payment.ID,
+			Amount:      payment.Amount,
+			PaymentDate: payment.PaymentDate.Format(DateFormatISO),
+			Notes:       payment.Notes,
+		})
+	}
+	return response
+}
+
+func modelsToDebtResponses(debts []models.Debt) []DebtResponse {
+	responses := make([]DebtResponse, len(debts))
+	for i := range debts {
+		responses[i] = modelToDebtResponse(&debts[i])
+	}
+	return responses
+}
+
+func writeDebtJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set(HeaderContentType, ContentTypeJSON)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
 func (h *DebtHandler) CreateDebt(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		http.Error(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
 	var req CreateDebtRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
@@ -46,33 +131,37 @@ func (h *DebtHandler) CreateDebt(w http.ResponseWriter, r *http.Request) {
 	}
 
 	debt := &models.Debt{
-		Person: req.Person,
-		Value:  req.Value,
+		FarmID:           farmID,
+		Person:           req.Person,
+		Value:            req.Value,
+		CounterpartyType: req.CounterpartyType,
+		SaleID:           req.SaleID,
+		ExpenseID:        req.ExpenseID,
 	}
 
-	if err := h.service.CreateDebt(debt); err != nil {
-		http.Error(w, "Erro ao criar dívida: "+err.Error(), http.StatusBadRequest)
-		return
+	if req.DueDate != "" {
+		dueDate, err := time.Parse(DateFormatISO, req.DueDate)
+		if err != nil {
+			http.Error(w, ErrInvalidDateFormat, http.StatusBadRequest)
+			return
+		}
+		debt.DueDate = &dueDate
 	}
 
-	response := DebtResponse{
-		ID:        debt.ID,
-		Person:    debt.Person,
-		Value:     debt.Value,
-		CreatedAt: debt.CreatedAt.Format(DateFormatISO8601),
-		UpdatedAt: debt.UpdatedAt.Format(DateFormatISO8601),
+	if err := h.service.CreateDebt(r.Context(), debt); err != nil {
+		http.Error(w, "Erro ao criar dívida: "+err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	w.Header().Set(HeaderContentType, ContentTypeJSON)
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(response)
+	writeDebtJSON(w, http.StatusCreated, modelToDebtResponse(debt))
 }
 
 type queryParams struct {
-	page  int
-	limit int
-	year  *int
-	month *int
+	page   int
+	limit  int
+	year   *int
+	month  *int
+	status string
 }
 
 func parseQueryParams(r *http.Request) queryParams {
@@ -82,8 +171,9 @@ func parseQueryParams(r *http.Request) queryParams {
 	monthStr := r.URL.Query().Get("month")
 
 	params := queryParams{
-		page:  1,
-		limit: 10,
+		page:   1,
+		limit:  10,
+		status: r.URL.Query().Get("status"),
 	}
 
 	if pageStr != "" {
@@ -114,37 +204,158 @@ func parseQueryParams(r *http.Request) queryParams {
 }
 
 func (h *DebtHandler) GetDebts(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		http.Error(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
 	params := parseQueryParams(r)
+	filter := repository.DebtFilter{
+		Year:   params.year,
+		Month:  params.month,
+		Status: params.status,
+	}
 
-	debts, total, err := h.service.GetDebtsWithPagination(params.page, params.limit, params.year, params.month)
+	debts, total, err := h.service.GetDebtsWithPagination(farmID, params.page, params.limit, filter)
 	if err != nil {
 		http.Error(w, "Erro ao buscar dívidas: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	debtResponses := make([]DebtResponse, len(debts))
-	for i, debt := range debts {
-		debtResponses[i] = DebtResponse{
-			ID:        debt.ID,
-			Person:    debt.Person,
-			Value:     debt.Value,
-			CreatedAt: debt.CreatedAt.Format(DateFormatISO8601),
-			UpdatedAt: debt.UpdatedAt.Format(DateFormatISO8601),
-		}
-	}
-
 	response := DebtListResponse{
-		Debts: debtResponses,
+		Debts: modelsToDebtResponses(debts),
 		Total: total,
 		Page:  params.page,
 		Limit: params.limit,
 	}
 
-	w.Header().Set(HeaderContentType, ContentTypeJSON)
-	json.NewEncoder(w).Encode(response)
+	writeDebtJSON(w, http.StatusOK, response)
+}
+
+func (h *DebtHandler) GetDebtByID(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		http.Error(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	debt, err := h.service.GetDebtByID(id, farmID)
+	if err != nil {
+		http.Error(w, ErrDebtNotFound, http.StatusNotFound)
+		return
+	}
+
+	writeDebtJSON(w, http.StatusOK, modelToDebtResponse(debt))
+}
+
+func (h *DebtHandler) GetOverdueDebts(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		http.Error(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	debts, err := h.service.GetOverdueDebts(farmID)
+	if err != nil {
+		http.Error(w, "Erro ao buscar dívidas vencidas: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeDebtJSON(w, http.StatusOK, map[string]interface{}{
+		"debts": modelsToDebtResponses(debts),
+		"total": len(debts),
+	})
+}
+
+func (h *DebtHandler) GetUpcomingDebts(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		http.Error(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	days := 30
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Parâmetro 'days' deve ser um número positivo", http.StatusBadRequest)
+			return
+		}
+		days = parsed
+	}
+
+	debts, err := h.service.GetUpcomingDebts(farmID, days)
+	if err != nil {
+		http.Error(w, "Erro ao buscar dívidas a vencer: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeDebtJSON(w, http.StatusOK, map[string]interface{}{
+		"days":  days,
+		"debts": modelsToDebtResponses(debts),
+		"total": len(debts),
+	})
+}
+
+func (h *DebtHandler) RegisterPayment(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		http.Error(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		http.Error(w, "ID inválido", http.StatusBadRequest)
+		return
+	}
+
+	var req DebtPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	payment := &models.DebtPayment{
+		Amount: req.Amount,
+		Notes:  req.Notes,
+	}
+	if req.PaymentDate != "" {
+		paymentDate, err := time.Parse(DateFormatISO, req.PaymentDate)
+		if err != nil {
+			http.Error(w, ErrInvalidDateFormat, http.StatusBadRequest)
+			return
+		}
+		payment.PaymentDate = paymentDate
+	}
+
+	debt, err := h.service.RegisterPayment(id, farmID, payment)
+	if err != nil {
+		if err.Error() == service.ErrDebtNotFoundOrNotBelongsToFarm {
+			http.Error(w, ErrDebtNotFound, http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Erro ao registrar pagamento: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeDebtJSON(w, http.StatusCreated, modelToDebtResponse(debt))
 }
 
 func (h *DebtHandler) DeleteDebt(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		http.Error(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
 	idStr := chi.URLParam(r, "id")
 	if idStr == "" {
 		http.Error(w, "ID é obrigatório", http.StatusBadRequest)
@@ -157,17 +368,21 @@ func (h *DebtHandler) DeleteDebt(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.DeleteDebt(uint(id)); err != nil {
+	if err := h.service.DeleteDebt(uint(id), farmID); err != nil {
 		http.Error(w, "Erro ao deletar dívida: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set(HeaderContentType, ContentTypeJSON)
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"message": "Dívida deletada com sucesso"})
+	writeDebtJSON(w, http.StatusOK, map[string]string{"message": "Dívida deletada com sucesso"})
 }
 
 func (h *DebtHandler) GetTotalByPerson(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		http.Error(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
 	yearStr := r.URL.Query().Get("year")
 	monthStr := r.URL.Query().Get("month")
 
@@ -188,7 +403,7 @@ func (h *DebtHandler) GetTotalByPerson(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	totals, err := h.service.GetTotalByPersonInMonth(year, month)
+	totals, err := h.service.GetTotalByPersonInMonth(farmID, year, month)
 	if err != nil {
 		http.Error(w, "Erro ao calcular total por pessoa: "+err.Error(), http.StatusBadRequest)
 		return
@@ -200,6 +415,5 @@ func (h *DebtHandler) GetTotalByPerson(w http.ResponseWriter, r *http.Request) {
 		"totals": totals,
 	}
 
-	w.Header().Set(HeaderContentType, ContentTypeJSON)
-	json.NewEncoder(w).Encode(response)
+	writeDebtJSON(w, http.StatusOK, response)
 }
@@ -26,6 +26,28 @@ func SendErrorResponse(w http.ResponseWriter, message string, statusCode int) {
 	json.NewEncoder(w).Encode(response)
 }
 
+type ErrorResponseWithData struct {
+	ErrorResponse
+	Data interface{} `json:"data"`
+}
+
+func SendErrorResponseWithData(w http.ResponseWriter, data interface{}, message string, statusCode int) {
+	w.Header().Set(HeaderContentType, ContentTypeJSON)
+	w.WriteHeader(statusCode)
+
+	response := ErrorResponseWithData{
+		ErrorResponse: ErrorResponse{
+			Success: false,
+			Error:   http.StatusText(statusCode),
+			Message: message,
+			Code:    statusCode,
+		},
+		Data: data,
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
 func SendSuccessResponse(w http.ResponseWriter, data interface{}, message string, statusCode int) {
 	w.Header().Set(HeaderContentType, ContentTypeJSON)
 	w.WriteHeader(statusCode)
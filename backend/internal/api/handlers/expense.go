@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type ExpenseHandler struct {
+	service service.ExpenseService
+}
+
+func NewExpenseHandler(service service.ExpenseService) *ExpenseHandler {
+	return &ExpenseHandler{service: service}
+}
+
+type ExpenseRequest struct {
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Category    string  `json:"category"`
+	Date        string  `json:"date"`
+	Notes       string  `json:"notes"`
+}
+
+type ExpenseResponse struct {
+	ID          uint    `json:"id"`
+	FarmID      uint    `json:"farm_id"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Category    string  `json:"category"`
+	Date        string  `json:"date"`
+	Notes       string  `json:"notes"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type CategoryTotalsResponse struct {
+	StartDate  string                     `json:"start_date"`
+	EndDate    string                     `json:"end_date"`
+	Total      float64                    `json:"total"`
+	Categories []repository.CategoryTotal `json:"categories"`
+}
+
+func modelToExpenseResponse(expense *models.Expense) ExpenseResponse {
+	return ExpenseResponse{
+		ID:          expense.ID,
+		FarmID:      expense.FarmID,
+		Description: expense.Description,
+		Amount:      expense.Amount,
+		Category:    expense.Category,
+		Date:        expense.Date.Format(DateFormatISO),
+		Notes:       expense.Notes,
+		CreatedAt:   expense.CreatedAt.Format(DateFormatDateTime),
+		UpdatedAt:   expense.UpdatedAt.Format(DateFormatDateTime),
+	}
+}
+
+func parseDateQueryParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(DateFormatISO, value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+func endOfDay(date time.Time) time.Time {
+	return date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+func (req ExpenseRequest) toModel() (*models.Expense, error) {
+	date, err := time.Parse(DateFormatISO, req.Date)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Expense{
+		Description: req.Description,
+		Amount:      req.Amount,
+		Category:    req.Category,
+		Date:        date,
+		Notes:       req.Notes,
+	}, nil
+}
+
+func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req ExpenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	expense, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	expense.FarmID = farmID
+
+	if err := h.service.CreateExpense(r.Context(), expense); err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, modelToExpenseResponse(expense), "Despesa registrada com sucesso", http.StatusCreated)
+}
+
+func (h *ExpenseHandler) GetExpenseByID(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidExpenseID, http.StatusBadRequest)
+		return
+	}
+
+	expense, err := h.service.GetExpenseByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrExpenseNotFound, http.StatusNotFound)
+		return
+	}
+
+	SendSuccessResponse(w, modelToExpenseResponse(expense), "Despesa encontrada com sucesso", http.StatusOK)
+}
+
+func (h *ExpenseHandler) GetExpensesByFarm(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	startDate, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	if endDate != nil {
+		end := endOfDay(*endDate)
+		endDate = &end
+	}
+
+	filter := repository.ExpenseFilter{
+		Category:  r.URL.Query().Get("category"),
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	expenses, err := h.service.GetExpensesByFarmID(r.Context(), farmID, filter)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	responses := make([]ExpenseResponse, len(expenses))
+	for i, expense := range expenses {
+		responses[i] = modelToExpenseResponse(expense)
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Despesas encontradas com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *ExpenseHandler) GetMonthlyExpenses(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	months := 12
+	if monthsStr := r.URL.Query().Get("months"); monthsStr != "" {
+		parsed, err := strconv.Atoi(monthsStr)
+		if err != nil || parsed <= 0 || parsed > 24 {
+			SendErrorResponse(w, ErrInvalidMonthsParam, http.StatusBadRequest)
+			return
+		}
+		months = parsed
+	}
+
+	data, err := h.service.GetMonthlyExpensesData(r.Context(), farmID, months)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, data, "Despesas mensais recuperadas com sucesso", http.StatusOK)
+}
+
+func (h *ExpenseHandler) GetTotalsByCategory(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	endDate := now
+
+	start, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	if start != nil {
+		startDate = *start
+	}
+
+	end, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	if end != nil {
+		endDate = endOfDay(*end)
+	}
+
+	totals, err := h.service.GetTotalsByCategory(r.Context(), farmID, startDate, endDate)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := CategoryTotalsResponse{
+		StartDate:  startDate.Format(DateFormatISO),
+		EndDate:    endDate.Format(DateFormatISO),
+		Categories: totals,
+	}
+	for _, total := range totals {
+		response.Total += total.Total
+	}
+
+	SendSuccessResponse(w, response, "Totais por categoria recuperados com sucesso", http.StatusOK)
+}
+
+func (h *ExpenseHandler) UpdateExpense(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidExpenseID, http.StatusBadRequest)
+		return
+	}
+
+	var req ExpenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	expense, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	expense.ID = id
+
+	if err := h.service.UpdateExpense(r.Context(), expense, farmID); err != nil {
+		if err.Error() == service.ErrExpenseNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrExpenseNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.GetExpenseByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrExpenseNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToExpenseResponse(updated), "Despesa atualizada com sucesso", http.StatusOK)
+}
+
+func (h *ExpenseHandler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidExpenseID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteExpense(r.Context(), id, farmID); err != nil {
+		if err.Error() == service.ErrExpenseNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrExpenseNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Despesa deletada com sucesso", http.StatusOK)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+const ExportWriteTimeout = 10 * time.Minute
+
+type ExportHandler struct {
+	service service.ExportService
+}
+
+func NewExportHandler(service service.ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = service.ExportFormatJSON
+	}
+	entity := r.URL.Query().Get("entity")
+	if err := h.service.ValidateExport(format, entity); err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	name := fmt.Sprintf("fazenda-%d-%s", farmID, time.Now().Format("2006-01-02"))
+	switch format {
+	case service.ExportFormatCSV:
+		w.Header().Set(HeaderContentType, "text/csv; charset=utf-8")
+		name = fmt.Sprintf("fazenda-%d-%s-%s.csv", farmID, entity, time.Now().Format("2006-01-02"))
+	case service.ExportFormatXLSX:
+		w.Header().Set(HeaderContentType, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		name = fmt.Sprintf("fazenda-%d-%s-%s.xlsx", farmID, entity, time.Now().Format("2006-01-02"))
+	default:
+		w.Header().Set(HeaderContentType, ContentTypeJSON)
+		name += ".json"
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+
+	http.NewResponseController(w).SetWriteDeadline(time.Now().Add(ExportWriteTimeout))
+
+	writer := &exportResponseWriter{ResponseWriter: w}
+	var err error
+	if format == service.ExportFormatJSON {
+		err = h.service.WriteArchive(r.Context(), farmID, writer)
+	} else {
+		err = h.service.WriteEntity(r.Context(), farmID, entity, format, writer)
+	}
+	if err == nil {
+		return
+	}
+
+	if !writer.written {
+		w.Header().Del("Content-Disposition")
+		if err.Error() == repository.ErrFarmNotFound {
+			SendErrorResponse(w, ErrFarmNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, ErrInternalServer, http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Erro ao exportar dados da fazenda %d (%s): %v", farmID, format, err)
+	panic(http.ErrAbortHandler)
+}
+
+type exportResponseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
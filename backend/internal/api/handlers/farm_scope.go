@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func farmIDFromContext(r *http.Request) (uint, bool) {
+	farmID, ok := r.Context().Value("farm_id").(uint)
+	return farmID, ok
+}
+
+func userIDFromContext(r *http.Request) (uint, bool) {
+	userID, ok := r.Context().Value("user_id").(uint)
+	return userID, ok
+}
+
+func parseUintURLParam(r *http.Request, name string) (uint, error) {
+	id, err := strconv.ParseUint(chi.URLParam(r, name), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func resolveFarmID(w http.ResponseWriter, r *http.Request, requested string) (uint, bool) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return 0, false
+	}
+
+	if requested == "" {
+		return farmID, true
+	}
+
+	id, err := strconv.ParseUint(requested, 10, 32)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidFarmID, http.StatusBadRequest)
+		return 0, false
+	}
+
+	if uint(id) != farmID {
+		SendErrorResponse(w, ErrFarmAccessDenied, http.StatusForbidden)
+		return 0, false
+	}
+
+	return farmID, true
+}
+
+func checkBodyFarmID(w http.ResponseWriter, bodyFarmID, farmID uint) bool {
+	if bodyFarmID != 0 && bodyFarmID != farmID {
+		SendErrorResponse(w, ErrFarmAccessDenied, http.StatusForbidden)
+		return false
+	}
+	return true
+}
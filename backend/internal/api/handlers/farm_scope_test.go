@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type fakeMilkCollectionRepository struct {
+	repository.MilkCollectionRepositoryInterface
+	requestedFarms []uint
+}
+
+func (r *fakeMilkCollectionRepository) FindByFarmID(farmID uint) ([]models.MilkCollection, error) {
+	r.requestedFarms = append(r.requestedFarms, farmID)
+	return []models.MilkCollection{}, nil
+}
+
+func farmScopedRequest(method, target string, farmID uint, params map[string]string) *http.Request {
+	r := httptest.NewRequest(method, target, nil)
+	routeContext := chi.NewRouteContext()
+	for key, value := range params {
+		routeContext.URLParams.Add(key, value)
+	}
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, routeContext)
+	ctx = context.WithValue(ctx, "farm_id", farmID)
+	return r.WithContext(ctx)
+}
+
+func TestGetMilkCollectionsByFarmIDRejectsOtherFarm(t *testing.T) {
+	repo := &fakeMilkCollectionRepository{}
+	handler := NewMilkCollectionHandler(service.NewMilkCollectionService(repo, nil, nil))
+
+	w := httptest.NewRecorder()
+	handler.GetMilkCollectionsByFarmID(w, farmScopedRequest(http.MethodGet, "/api/v1/milk-collections/farm/2", 1, map[string]string{"farmId": "2"}))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if len(repo.requestedFarms) != 0 {
+		t.Fatalf("repository queried for farms %v on forbidden request", repo.requestedFarms)
+	}
+}
+
+func TestGetMilkCollectionsByFarmIDAllowsTokenFarm(t *testing.T) {
+	repo := &fakeMilkCollectionRepository{}
+	handler := NewMilkCollectionHandler(service.NewMilkCollectionService(repo, nil, nil))
+
+	w := httptest.NewRecorder()
+	handler.GetMilkCollectionsByFarmID(w, farmScopedRequest(http.MethodGet, "/api/v1/milk-collections/farm/1", 1, map[string]string{"farmId": "1"}))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if len(repo.requestedFarms) != 1 || repo.requestedFarms[0] != 1 {
+		t.Fatalf("repository queried for farms %v, want [1]", repo.requestedFarms)
+	}
+}
+
+func TestGetMilkCollectionsByFarmIDRejectsInvalidFarm(t *testing.T) {
+	repo := &fakeMilkCollectionRepository{}
+	handler := NewMilkCollectionHandler(service.NewMilkCollectionService(repo, nil, nil))
+
+	w := httptest.NewRecorder()
+	handler.GetMilkCollectionsByFarmID(w, farmScopedRequest(http.MethodGet, "/api/v1/milk-collections/farm/abc", 1, map[string]string{"farmId": "abc"}))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if len(repo.requestedFarms) != 0 {
+		t.Fatalf("repository queried for farms %v on invalid request", repo.requestedFarms)
+	}
+}
@@ -119,6 +119,48 @@ func (h *FarmSelectionHandler) SelectFarm(w http.ResponseWriter, r *http.Request
 	json.NewEncoder(w).Encode(response)
 }
 
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role"`
+}
+
+func (h *FarmSelectionHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := parseUintURLParam(r, "userId")
+	if err != nil {
+		SendErrorResponse(w, "ID do usuário inválido", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.UpdateUserFarmRole(farmID, userID, req.Role); err != nil {
+		switch err.Error() {
+		case service.ErrUserNotInFarm:
+			SendErrorResponse(w, "Usuário não pertence à fazenda", http.StatusNotFound)
+		case service.ErrInvalidFarmRole:
+			SendErrorResponse(w, "Papel inválido. Use owner, manager, milker, veterinarian ou read_only", http.StatusBadRequest)
+		default:
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	SendSuccessResponse(w, map[string]interface{}{
+		"user_id": userID,
+		"farm_id": farmID,
+		"role":    req.Role,
+	}, "Papel do usuário atualizado com sucesso", http.StatusOK)
+}
+
 func (h *FarmSelectionHandler) extractUserIDFromToken(r *http.Request) (uint, error) {
 	authHeader := r.Header.Get("Authorization")
 	if authHeader == "" {
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+const (
+	GestationSourceDefault = "default"
+	GestationSourceFarm    = "farm"
+)
+
+type GestationHandler struct {
+	service *service.GestationService
+}
+
+func NewGestationHandler(service *service.GestationService) *GestationHandler {
+	return &GestationHandler{service: service}
+}
+
+type GestationPeriodRequest struct {
+	AnimalType int    `json:"animal_type"`
+	Breed      string `json:"breed"`
+	Days       int    `json:"days"`
+}
+
+type GestationPeriodResponse struct {
+	ID             uint   `json:"id"`
+	AnimalType     int    `json:"animal_type"`
+	AnimalTypeName string `json:"animal_type_name"`
+	Breed          string `json:"breed"`
+	Days           int    `json:"days"`
+	Source         string `json:"source"`
+}
+
+type SaveGestationPeriodResponse struct {
+	GestationPeriod     GestationPeriodResponse `json:"gestation_period"`
+	RecalculatedRecords int                     `json:"recalculated_records"`
+}
+
+func modelToGestationPeriodResponse(period *models.GestationPeriod) GestationPeriodResponse {
+	source := GestationSourceDefault
+	if period.FarmID != nil {
+		source = GestationSourceFarm
+	}
+	return GestationPeriodResponse{
+		ID:             period.ID,
+		AnimalType:     period.AnimalType,
+		AnimalTypeName: models.GetAnimalTypeName(period.AnimalType),
+		Breed:          period.Breed,
+		Days:           period.Days,
+		Source:         source,
+	}
+}
+
+func (h *GestationHandler) GetGestationTable(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	periods, err := h.service.GetGestationTable(farmID)
+	if err != nil {
+		SendErrorResponse(w, "Erro ao buscar tabela de gestação: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]GestationPeriodResponse, len(periods))
+	for i := range periods {
+		responses[i] = modelToGestationPeriodResponse(&periods[i])
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Tabela de gestação encontrada com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *GestationHandler) SaveOverride(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req GestationPeriodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	period := &models.GestationPeriod{
+		AnimalType: req.AnimalType,
+		Breed:      req.Breed,
+		Days:       req.Days,
+	}
+
+	recalculated, err := h.service.SaveOverride(period, farmID)
+	if err != nil {
+		SendErrorResponse(w, "Erro ao salvar duração da gestação: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := SaveGestationPeriodResponse{
+		GestationPeriod:     modelToGestationPeriodResponse(period),
+		RecalculatedRecords: recalculated,
+	}
+
+	SendSuccessResponse(w, response, "Duração da gestação salva com sucesso", http.StatusOK)
+}
+
+func (h *GestationHandler) DeleteOverride(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidGestationPeriodID, http.StatusBadRequest)
+		return
+	}
+
+	recalculated, err := h.service.DeleteOverride(id, farmID)
+	if err != nil {
+		if err.Error() == service.ErrGestationPeriodNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrGestationPeriodNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, map[string]int{"recalculated_records": recalculated}, "Duração da gestação da fazenda removida com sucesso", http.StatusOK)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type LactationHandler struct {
+	service service.LactationService
+}
+
+func NewLactationHandler(service service.LactationService) *LactationHandler {
+	return &LactationHandler{service: service}
+}
+
+type LactationPointResponse struct {
+	Date        string   `json:"date"`
+	DaysInMilk  int      `json:"days_in_milk"`
+	Liters      float64  `json:"liters"`
+	FittedValue *float64 `json:"fitted_value"`
+}
+
+type WoodCurveResponse struct {
+	A           float64  `json:"a"`
+	B           float64  `json:"b"`
+	C           float64  `json:"c"`
+	RSquared    float64  `json:"r_squared"`
+	Reliable    bool     `json:"reliable"`
+	PeakDay     *float64 `json:"peak_day"`
+	PeakYield   *float64 `json:"peak_yield"`
+	Persistency *float64 `json:"persistency"`
+}
+
+type LactationResponse struct {
+	Number                int                      `json:"number"`
+	StartDate             string                   `json:"start_date"`
+	EndDate               *string                  `json:"end_date"`
+	Ongoing               bool                     `json:"ongoing"`
+	DaysInMilk            int                      `json:"days_in_milk"`
+	TestDays              int                      `json:"test_days"`
+	TotalYield            float64                  `json:"total_yield"`
+	AverageDailyYield     *float64                 `json:"average_daily_yield"`
+	PeakYield             *float64                 `json:"peak_yield"`
+	PeakDaysInMilk        *int                     `json:"peak_days_in_milk"`
+	Persistency           *float64                 `json:"persistency"`
+	Projected305Yield     *float64                 `json:"projected_305_yield"`
+	Projected305Estimated bool                     `json:"projected_305_estimated"`
+	Curve                 *WoodCurveResponse       `json:"curve"`
+	Points                []LactationPointResponse `json:"points"`
+}
+
+func roundTo(value, precision float64) float64 {
+	return math.Round(value*precision) / precision
+}
+
+func lactationToResponse(lactation *service.Lactation) LactationResponse {
+	response := LactationResponse{
+		Number:                lactation.Number,
+		StartDate:             lactation.StartDate.Format(DateFormatISO),
+		Ongoing:               lactation.Ongoing,
+		DaysInMilk:            lactation.DaysInMilk,
+		TestDays:              lactation.TestDays,
+		TotalYield:            roundTo(lactation.TotalYield, 100),
+		AverageDailyYield:     roundOptional(lactation.AverageDailyYield, 100),
+		PeakYield:             roundOptional(lactation.PeakYield, 100),
+		PeakDaysInMilk:        lactation.PeakDaysInMilk,
+		Persistency:           roundOptional(lactation.Persistency, 10),
+		Projected305Yield:     roundOptional(lactation.Projected305Yield, 10),
+		Projected305Estimated: lactation.Projected305Estimated,
+		Points:                make([]LactationPointResponse, len(lactation.Points)),
+	}
+
+	if lactation.EndDate != nil {
+		endDate := lactation.EndDate.Format(DateFormatISO)
+		response.EndDate = &endDate
+	}
+
+	if lactation.Curve != nil {
+		response.Curve = &WoodCurveResponse{
+			A:           roundTo(lactation.Curve.A, 10000),
+			B:           roundTo(lactation.Curve.B, 10000),
+			C:           roundTo(lactation.Curve.C, 1000000),
+			RSquared:    roundTo(lactation.Curve.RSquared, 1000),
+			Reliable:    lactation.Curve.Reliable,
+			PeakDay:     roundOptional(lactation.Curve.PeakDay, 10),
+			PeakYield:   roundOptional(lactation.Curve.PeakYield, 100),
+			Persistency: roundOptional(lactation.Curve.Persistency, 1000),
+		}
+	}
+
+	for i, point := range lactation.Points {
+		response.Points[i] = LactationPointResponse{
+			Date:        point.Date.Format(DateFormatISO),
+			DaysInMilk:  point.DaysInMilk,
+			Liters:      point.Liters,
+			FittedValue: roundOptional(point.FittedValue, 100),
+		}
+	}
+
+	return response
+}
+
+func (h *LactationHandler) GetLactations(w http.ResponseWriter, r *http.Request) {
+	animalID, err := parseUintURLParam(r, "animalId")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return
+	}
+
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	lactations, err := h.service.GetLactations(r.Context(), animalID, farmID)
+	if err != nil {
+		if err.Error() == service.ErrAnimalNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]LactationResponse, len(lactations))
+	for i := range lactations {
+		response[i] = lactationToResponse(&lactations[i])
+	}
+
+	SendSuccessResponse(w, response, fmt.Sprintf("Lactações encontradas com sucesso (%d registros)", len(response)), http.StatusOK)
+}
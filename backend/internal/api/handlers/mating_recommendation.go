@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type MatingRecommendationHandler struct {
+	service service.MatingRecommendationService
+}
+
+func NewMatingRecommendationHandler(service service.MatingRecommendationService) *MatingRecommendationHandler {
+	return &MatingRecommendationHandler{service: service}
+}
+
+type MatingSemenResponse struct {
+	ID             uint   `json:"id"`
+	BullName       string `json:"bull_name"`
+	Code           string `json:"code"`
+	Breed          string `json:"breed"`
+	Supplier       string `json:"supplier"`
+	DosesAvailable int    `json:"doses_available"`
+}
+
+type MatingCandidateResponse struct {
+	Source                string                    `json:"source"`
+	Sire                  *GenealogyAnimalResponse  `json:"sire,omitempty"`
+	Semen                 *MatingSemenResponse      `json:"semen,omitempty"`
+	InseminationType      string                    `json:"insemination_type"`
+	InbreedingCoefficient float64                   `json:"inbreeding_coefficient"`
+	CommonAncestors       []GenealogyAnimalResponse `json:"common_ancestors"`
+	DaughterCount         int                       `json:"daughter_count"`
+	DaughterAverageLiters *float64                  `json:"daughter_average_liters"`
+	MilkIndex             *float64                  `json:"milk_index"`
+	Recommended           bool                      `json:"recommended"`
+	Explanation           []string                  `json:"explanation"`
+}
+
+type CowMatingRecommendationResponse struct {
+	Cow                  GenealogyAnimalResponse   `json:"cow"`
+	LastInseminationType string                    `json:"last_insemination_type"`
+	Candidates           []MatingCandidateResponse `json:"candidates"`
+}
+
+type MatingRecommendationsResponse struct {
+	FarmID              uint                              `json:"farm_id"`
+	HerdAverageLiters   *float64                          `json:"herd_average_liters"`
+	InbreedingThreshold float64                           `json:"inbreeding_threshold"`
+	Cows                []CowMatingRecommendationResponse `json:"cows"`
+}
+
+func roundOptional(value *float64, precision float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	rounded := math.Round(*value*precision) / precision
+	return &rounded
+}
+
+func matingCandidateToResponse(candidate *service.MatingCandidate) MatingCandidateResponse {
+	response := MatingCandidateResponse{
+		Source:                candidate.Source,
+		InseminationType:      candidate.InseminationType,
+		InbreedingCoefficient: roundCoefficient(candidate.InbreedingCoefficient),
+		CommonAncestors:       make([]GenealogyAnimalResponse, len(candidate.CommonAncestors)),
+		DaughterCount:         candidate.DaughterCount,
+		DaughterAverageLiters: roundOptional(candidate.DaughterAverageLiters, 100),
+		MilkIndex:             roundOptional(candidate.MilkIndex, 10),
+		Recommended:           candidate.Recommended,
+		Explanation:           candidate.Explanation,
+	}
+
+	if candidate.Sire != nil {
+		sire := modelToGenealogyAnimal(candidate.Sire)
+		response.Sire = &sire
+	}
+	if candidate.SemenEntry != nil {
+		response.Semen = &MatingSemenResponse{
+			ID:             candidate.SemenEntry.ID,
+			BullName:       candidate.SemenEntry.BullName,
+			Code:           candidate.SemenEntry.Code,
+			Breed:          candidate.SemenEntry.Breed,
+			Supplier:       candidate.SemenEntry.Supplier,
+			DosesAvailable: candidate.SemenEntry.DosesAvailable,
+		}
+	}
+	for i, ancestor := range candidate.CommonAncestors {
+		response.CommonAncestors[i] = modelToGenealogyAnimal(ancestor)
+	}
+
+	return response
+}
+
+func (h *MatingRecommendationHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := resolveFarmID(w, r, r.URL.Query().Get("farmId"))
+	if !ok {
+		return
+	}
+
+	var cowID uint64
+	if value := r.URL.Query().Get("animal_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+			return
+		}
+		cowID = parsed
+	}
+
+	var limit int
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidLimitParam, http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	recommendations, err := h.service.GetRecommendations(r.Context(), farmID, uint(cowID), limit)
+	if err != nil {
+		sendPedigreeServiceError(w, err)
+		return
+	}
+
+	response := MatingRecommendationsResponse{
+		FarmID:              recommendations.FarmID,
+		HerdAverageLiters:   roundOptional(recommendations.HerdAverageLiters, 100),
+		InbreedingThreshold: recommendations.InbreedingThreshold,
+		Cows:                make([]CowMatingRecommendationResponse, len(recommendations.Cows)),
+	}
+	for i, cow := range recommendations.Cows {
+		candidates := make([]MatingCandidateResponse, len(cow.Candidates))
+		for j, candidate := range cow.Candidates {
+			candidates[j] = matingCandidateToResponse(candidate)
+		}
+		response.Cows[i] = CowMatingRecommendationResponse{
+			Cow:                  modelToGenealogyAnimal(cow.Cow),
+			LastInseminationType: cow.LastInseminationType,
+			Candidates:           candidates,
+		}
+	}
+
+	SendSuccessResponse(w, response, fmt.Sprintf("Recomendações de acasalamento geradas com sucesso (%d vacas)", len(response.Cows)), http.StatusOK)
+}
@@ -26,6 +26,7 @@ type MilkCollectionData struct {
 	Animal    AnimalData `json:"animal"`
 	Liters    float64    `json:"liters"`
 	Date      time.Time  `json:"date"`
+	Shift     string     `json:"shift"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 }
@@ -34,6 +35,42 @@ type CreateMilkCollectionRequest struct {
 	AnimalID uint    `json:"animal_id" validate:"required"`
 	Liters   float64 `json:"liters" validate:"required,min=0"`
 	Date     string  `json:"date" validate:"required"`
+	Shift    string  `json:"shift"`
+}
+
+type MilkSessionRowRequest struct {
+	EarTag int     `json:"ear_tag"`
+	Liters float64 `json:"liters"`
+}
+
+type CreateMilkSessionRequest struct {
+	Date  string                  `json:"date"`
+	Shift string                  `json:"shift"`
+	Rows  []MilkSessionRowRequest `json:"rows"`
+}
+
+type MilkSessionCollectionResponse struct {
+	ID         uint    `json:"id"`
+	AnimalID   uint    `json:"animal_id"`
+	AnimalName string  `json:"animal_name"`
+	EarTag     int     `json:"ear_tag"`
+	Liters     float64 `json:"liters"`
+}
+
+type MilkSessionRowErrorResponse struct {
+	Row     int    `json:"row"`
+	EarTag  int    `json:"ear_tag"`
+	Message string `json:"message"`
+}
+
+type MilkSessionResponse struct {
+	Date         string                          `json:"date"`
+	Shift        string                          `json:"shift"`
+	Created      int                             `json:"created"`
+	TotalLiters  float64                         `json:"total_liters"`
+	AnimalsMoved int                             `json:"animals_moved"`
+	Collections  []MilkSessionCollectionResponse `json:"collections"`
+	Errors       []MilkSessionRowErrorResponse   `json:"errors"`
 }
 
 type MilkCollectionResponse struct {
@@ -61,18 +98,33 @@ func (h *MilkCollectionHandler) CreateMilkCollection(w http.ResponseWriter, r *h
 		return
 	}
 
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
 	milkCollection := &models.MilkCollection{
 		AnimalID: req.AnimalID,
 		Liters:   req.Liters,
 		Date:     date,
-	}
-
-	if err := h.service.CreateMilkCollection(milkCollection); err != nil {
-		http.Error(w, "Failed to create milk collection", http.StatusInternalServerError)
+		Shift:    req.Shift,
+	}
+
+	if err := h.service.CreateMilkCollection(milkCollection, farmID); err != nil {
+		switch err.Error() {
+		case service.ErrAnimalNotFoundOrNotBelongsToFarm:
+			http.Error(w, ErrAnimalNotFound, http.StatusNotFound)
+		case service.ErrInvalidMilkShift:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case service.ErrMilkCollectionAlreadyExists:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Failed to create milk collection", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	createdMilkCollection, err := h.service.GetMilkCollectionByID(milkCollection.ID)
+	createdMilkCollection, err := h.service.GetMilkCollectionByID(milkCollection.ID, farmID)
 	if err != nil {
 		http.Error(w, "Failed to retrieve created milk collection", http.StatusInternalServerError)
 		return
@@ -89,6 +141,65 @@ func (h *MilkCollectionHandler) CreateMilkCollection(w http.ResponseWriter, r *h
 	json.NewEncoder(w).Encode(response)
 }
 
+func (h *MilkCollectionHandler) CreateMilkSession(w http.ResponseWriter, r *http.Request) {
+	var req CreateMilkSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	date, err := time.Parse(DateFormatISO, req.Date)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	rows := make([]service.MilkSessionRow, len(req.Rows))
+	for i, row := range req.Rows {
+		rows[i] = service.MilkSessionRow{EarTag: row.EarTag, Liters: row.Liters}
+	}
+
+	result, err := h.service.CreateMilkSession(farmID, date, req.Shift, rows)
+	if err != nil {
+		SendErrorResponse(w, "Erro ao registrar ordenha: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := MilkSessionResponse{
+		Date:         result.Date.Format(DateFormatISO),
+		Shift:        result.Shift,
+		Created:      len(result.Collections),
+		TotalLiters:  roundTo(result.TotalLiters, 100),
+		AnimalsMoved: result.AnimalsMoved,
+		Collections:  make([]MilkSessionCollectionResponse, len(result.Collections)),
+		Errors:       make([]MilkSessionRowErrorResponse, len(result.Errors)),
+	}
+	for i, collection := range result.Collections {
+		response.Collections[i] = MilkSessionCollectionResponse{
+			ID:         collection.ID,
+			AnimalID:   collection.AnimalID,
+			AnimalName: collection.Animal.AnimalName,
+			EarTag:     collection.Animal.EarTagNumberLocal,
+			Liters:     collection.Liters,
+		}
+	}
+	for i, rowErr := range result.Errors {
+		response.Errors[i] = MilkSessionRowErrorResponse{Row: rowErr.Row, EarTag: rowErr.EarTag, Message: rowErr.Message}
+	}
+
+	if len(response.Errors) > 0 {
+		SendErrorResponseWithData(w, response, fmt.Sprintf("Nenhuma coleta registrada: %d linhas com erro", len(response.Errors)), http.StatusUnprocessableEntity)
+		return
+	}
+
+	SendSuccessResponse(w, response, fmt.Sprintf("Ordenha registrada com sucesso (%d coletas)", response.Created), http.StatusCreated)
+}
+
 func (h *MilkCollectionHandler) UpdateMilkCollection(w http.ResponseWriter, r *http.Request) {
 	milkCollectionIDStr := chi.URLParam(r, "id")
 	milkCollectionID, err := strconv.ParseUint(milkCollectionIDStr, 10, 32)
@@ -109,19 +220,36 @@ func (h *MilkCollectionHandler) UpdateMilkCollection(w http.ResponseWriter, r *h
 		return
 	}
 
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
 	milkCollection := &models.MilkCollection{
 		ID:       uint(milkCollectionID),
 		AnimalID: req.AnimalID,
 		Liters:   req.Liters,
 		Date:     date,
-	}
-
-	if err := h.service.UpdateMilkCollection(milkCollection); err != nil {
-		http.Error(w, "Failed to update milk collection", http.StatusInternalServerError)
+		Shift:    req.Shift,
+	}
+
+	if err := h.service.UpdateMilkCollection(milkCollection, farmID); err != nil {
+		switch err.Error() {
+		case service.ErrAnimalNotFoundOrNotBelongsToFarm:
+			http.Error(w, ErrAnimalNotFound, http.StatusNotFound)
+		case service.ErrMilkCollectionNotFoundOrNotBelongsToFarm:
+			http.Error(w, ErrMilkCollectionNotFound, http.StatusNotFound)
+		case service.ErrInvalidMilkShift:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case service.ErrMilkCollectionAlreadyExists:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Failed to update milk collection", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	updatedMilkCollection, err := h.service.GetMilkCollectionByID(milkCollection.ID)
+	updatedMilkCollection, err := h.service.GetMilkCollectionByID(milkCollection.ID, farmID)
 	if err != nil {
 		http.Error(w, "Failed to retrieve updated milk collection", http.StatusInternalServerError)
 		return
@@ -139,10 +267,8 @@ func (h *MilkCollectionHandler) UpdateMilkCollection(w http.ResponseWriter, r *h
 }
 
 func (h *MilkCollectionHandler) GetMilkCollectionsByFarmID(w http.ResponseWriter, r *http.Request) {
-	farmIDStr := chi.URLParam(r, "farmId")
-	farmID, err := strconv.ParseUint(farmIDStr, 10, 32)
-	if err != nil {
-		http.Error(w, "Invalid farm ID", http.StatusBadRequest)
+	farmID, ok := resolveFarmID(w, r, chi.URLParam(r, "farmId"))
+	if !ok {
 		return
 	}
 
@@ -164,10 +290,11 @@ func (h *MilkCollectionHandler) GetMilkCollectionsByFarmID(w http.ResponseWriter
 	}
 
 	var milkCollections []models.MilkCollection
+	var err error
 	if startDate != nil || endDate != nil {
-		milkCollections, err = h.service.GetMilkCollectionsByFarmIDWithDateRange(uint(farmID), startDate, endDate)
+		milkCollections, err = h.service.GetMilkCollectionsByFarmIDWithDateRange(farmID, startDate, endDate)
 	} else {
-		milkCollections, err = h.service.GetMilkCollectionsByFarmID(uint(farmID))
+		milkCollections, err = h.service.GetMilkCollectionsByFarmID(farmID)
 	}
 
 	if err != nil {
@@ -198,8 +325,17 @@ func (h *MilkCollectionHandler) GetMilkCollectionsByAnimalID(w http.ResponseWrit
 		return
 	}
 
-	milkCollections, err := h.service.GetMilkCollectionsByAnimalID(uint(animalID))
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	milkCollections, err := h.service.GetMilkCollectionsByAnimalID(uint(animalID), farmID)
 	if err != nil {
+		if err.Error() == service.ErrAnimalNotFoundOrNotBelongsToFarm {
+			http.Error(w, ErrAnimalNotFound, http.StatusNotFound)
+			return
+		}
 		http.Error(w, "Failed to retrieve milk collections", http.StatusInternalServerError)
 		return
 	}
@@ -219,6 +355,82 @@ func (h *MilkCollectionHandler) GetMilkCollectionsByAnimalID(w http.ResponseWrit
 	json.NewEncoder(w).Encode(response)
 }
 
+type MilkDailyTotalResponse struct {
+	Date        string             `json:"date"`
+	AnimalID    uint               `json:"animal_id"`
+	AnimalName  string             `json:"animal_name"`
+	EarTag      int                `json:"ear_tag"`
+	Shifts      map[string]float64 `json:"shifts"`
+	Milkings    int                `json:"milkings"`
+	TotalLiters float64            `json:"total_liters"`
+}
+
+func (h *MilkCollectionHandler) GetDailyTotals(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	startDate, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	if endDate == nil {
+		today := time.Now()
+		endDate = &today
+	}
+	if startDate == nil {
+		start := endDate.AddDate(0, 0, -29)
+		startDate = &start
+	}
+
+	var animalID *uint
+	if animalIDStr := r.URL.Query().Get("animal_id"); animalIDStr != "" {
+		parsed, err := strconv.ParseUint(animalIDStr, 10, 32)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+			return
+		}
+		id := uint(parsed)
+		animalID = &id
+	}
+
+	totals, err := h.service.GetDailyTotals(farmID, *startDate, *endDate, animalID)
+	if err != nil {
+		if err.Error() == service.ErrAnimalNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, "Erro ao calcular totais diários: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	responses := make([]MilkDailyTotalResponse, len(totals))
+	for i, total := range totals {
+		shifts := make(map[string]float64, len(models.MilkShifts))
+		for _, shift := range models.MilkShifts {
+			shifts[shift] = roundTo(total.Shifts[shift], 100)
+		}
+		responses[i] = MilkDailyTotalResponse{
+			Date:        total.Date.Format(DateFormatISO),
+			AnimalID:    total.Animal.ID,
+			AnimalName:  total.Animal.AnimalName,
+			EarTag:      total.Animal.EarTagNumberLocal,
+			Shifts:      shifts,
+			Milkings:    total.Milkings,
+			TotalLiters: roundTo(total.TotalLiters, 100),
+		}
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Totais diários de leite calculados com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
 func (h *MilkCollectionHandler) mapToMilkCollectionData(mc *models.MilkCollection) MilkCollectionData {
 	return MilkCollectionData{
 		ID:       mc.ID,
@@ -243,6 +455,7 @@ func (h *MilkCollectionHandler) mapToMilkCollectionData(mc *models.MilkCollectio
 		},
 		Liters:    mc.Liters,
 		Date:      mc.Date,
+		Shift:     mc.Shift,
 		CreatedAt: mc.CreatedAt,
 		UpdatedAt: mc.UpdatedAt,
 	}
@@ -262,6 +475,7 @@ type TopMilkProducerResponse struct {
 	Photo                  string  `json:"photo"`
 	TotalProduction        float64 `json:"total_production"`
 	AverageDailyProduction float64 `json:"average_daily_production"`
+	MilkingDays            int     `json:"milking_days"`
 	FatContent             float64 `json:"fat_content"`
 	LastCollectionDate     string  `json:"last_collection_date"`
 	DaysInLactation        int     `json:"days_in_lactation"`
@@ -273,7 +487,7 @@ type animalStats struct {
 	EarTagNumberLocal  int
 	Photo              string
 	TotalProduction    float64
-	CollectionCount    int
+	MilkingDays        map[string]bool
 	FatContent         float64
 	LastCollectionDate time.Time
 	DaysInLactation    int
@@ -285,7 +499,7 @@ func calculateAnimalStats(milkCollections []models.MilkCollection) map[uint]*ani
 	for _, mc := range milkCollections {
 		if existing, exists := stats[mc.AnimalID]; exists {
 			existing.TotalProduction += mc.Liters
-			existing.CollectionCount++
+			existing.MilkingDays[mc.Date.Format(DateFormatISO)] = true
 			if mc.Date.After(existing.LastCollectionDate) {
 				existing.LastCollectionDate = mc.Date
 			}
@@ -299,9 +513,9 @@ func calculateAnimalStats(milkCollections []models.MilkCollection) map[uint]*ani
 				AnimalID:           mc.AnimalID,
 				AnimalName:         mc.Animal.AnimalName,
 				EarTagNumberLocal:  mc.Animal.EarTagNumberLocal,
-				Photo:              mc.Animal.Photo,
+				Photo:              animalThumbnailURL(&mc.Animal),
 				TotalProduction:    mc.Liters,
-				CollectionCount:    1,
+				MilkingDays:        map[string]bool{mc.Date.Format(DateFormatISO): true},
 				FatContent:         3.5,
 				LastCollectionDate: mc.Date,
 				DaysInLactation:    daysInLactation,
@@ -316,7 +530,7 @@ func buildTopMilkProducerResponses(stats map[uint]*animalStats) []TopMilkProduce
 	responses := make([]TopMilkProducerResponse, 0, len(stats))
 
 	for _, s := range stats {
-		averageDailyProduction := s.TotalProduction / float64(s.CollectionCount)
+		averageDailyProduction := s.TotalProduction / float64(len(s.MilkingDays))
 		responses = append(responses, TopMilkProducerResponse{
 			ID:                     s.AnimalID,
 			AnimalName:             s.AnimalName,
@@ -324,6 +538,7 @@ func buildTopMilkProducerResponses(stats map[uint]*animalStats) []TopMilkProduce
 			Photo:                  s.Photo,
 			TotalProduction:        s.TotalProduction,
 			AverageDailyProduction: averageDailyProduction,
+			MilkingDays:            len(s.MilkingDays),
 			FatContent:             s.FatContent,
 			LastCollectionDate:     s.LastCollectionDate.Format(DateFormatISO),
 			DaysInLactation:        s.DaysInLactation,
@@ -343,17 +558,7 @@ func sortByTotalProduction(responses []TopMilkProducerResponse) {
 	}
 }
 
-func parseTopMilkProducersParams(r *http.Request) (uint, int, int, error) {
-	farmID := r.URL.Query().Get("farmId")
-	if farmID == "" {
-		return 0, 0, 0, fmt.Errorf("ID da fazenda é obrigatório")
-	}
-
-	id, err := strconv.ParseUint(farmID, 10, 32)
-	if err != nil {
-		return 0, 0, 0, fmt.Errorf("ID da fazenda inválido")
-	}
-
+func parseTopMilkProducersParams(r *http.Request) (int, int) {
 	limit := 10
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
@@ -368,16 +573,17 @@ func parseTopMilkProducersParams(r *http.Request) (uint, int, int, error) {
 		}
 	}
 
-	return uint(id), limit, periodDays, nil
+	return limit, periodDays
 }
 
 func (h *MilkCollectionHandler) GetTopMilkProducers(w http.ResponseWriter, r *http.Request) {
-	farmID, limit, periodDays, err := parseTopMilkProducersParams(r)
-	if err != nil {
-		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+	farmID, ok := resolveFarmID(w, r, r.URL.Query().Get("farmId"))
+	if !ok {
 		return
 	}
 
+	limit, periodDays := parseTopMilkProducersParams(r)
+
 	startDate := time.Now().AddDate(0, 0, -periodDays)
 	endDate := time.Now()
 	milkCollections, err := h.service.GetMilkCollectionsByFarmIDWithDateRange(farmID, &startDate, &endDate)
@@ -0,0 +1,709 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type MilkDeliveryHandler struct {
+	service service.MilkDeliveryService
+}
+
+func NewMilkDeliveryHandler(service service.MilkDeliveryService) *MilkDeliveryHandler {
+	return &MilkDeliveryHandler{service: service}
+}
+
+type MilkPriceVolumeTierData struct {
+	MinLiters float64 `json:"min_liters"`
+	Bonus     float64 `json:"bonus"`
+}
+
+type MilkPriceQualityTierData struct {
+	Parameter  string   `json:"parameter"`
+	Min        *float64 `json:"min"`
+	Max        *float64 `json:"max"`
+	Adjustment float64  `json:"adjustment"`
+}
+
+type MilkPriceTableRequest struct {
+	Buyer        string                     `json:"buyer"`
+	Name         string                     `json:"name"`
+	BasePrice    float64                    `json:"base_price"`
+	ValidFrom    string                     `json:"valid_from"`
+	ValidTo      *string                    `json:"valid_to"`
+	VolumeTiers  []MilkPriceVolumeTierData  `json:"volume_tiers"`
+	QualityTiers []MilkPriceQualityTierData `json:"quality_tiers"`
+}
+
+type MilkPriceTableResponse struct {
+	ID           uint                       `json:"id"`
+	Buyer        string                     `json:"buyer"`
+	Name         string                     `json:"name"`
+	BasePrice    float64                    `json:"base_price"`
+	ValidFrom    string                     `json:"valid_from"`
+	ValidTo      *string                    `json:"valid_to"`
+	VolumeTiers  []MilkPriceVolumeTierData  `json:"volume_tiers"`
+	QualityTiers []MilkPriceQualityTierData `json:"quality_tiers"`
+	CreatedAt    string                     `json:"created_at"`
+	UpdatedAt    string                     `json:"updated_at"`
+}
+
+type MilkDeliveryRequest struct {
+	Buyer        string  `json:"buyer"`
+	DeliveryDate string  `json:"delivery_date"`
+	Liters       float64 `json:"liters"`
+	Tank         string  `json:"tank"`
+	Notes        string  `json:"notes"`
+}
+
+type MilkDeliveryResponse struct {
+	ID           uint    `json:"id"`
+	Buyer        string  `json:"buyer"`
+	DeliveryDate string  `json:"delivery_date"`
+	Liters       float64 `json:"liters"`
+	Tank         string  `json:"tank"`
+	Notes        string  `json:"notes"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+}
+
+type MilkPaymentRequest struct {
+	Buyer       string  `json:"buyer"`
+	Month       string  `json:"month"`
+	Amount      float64 `json:"amount"`
+	PaymentDate string  `json:"payment_date"`
+	Notes       string  `json:"notes"`
+}
+
+type MilkPaymentResponse struct {
+	ID          uint    `json:"id"`
+	Buyer       string  `json:"buyer"`
+	Month       string  `json:"month"`
+	Amount      float64 `json:"amount"`
+	PaymentDate string  `json:"payment_date"`
+	Notes       string  `json:"notes"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+type MilkStatementAdjustmentResponse struct {
+	Parameter  string                    `json:"parameter"`
+	Value      *float64                  `json:"value"`
+	Tier       *MilkPriceQualityTierData `json:"tier"`
+	Adjustment float64                   `json:"adjustment"`
+}
+
+type MilkStatementQualityResponse struct {
+	Tests            int      `json:"tests"`
+	FatPercent       *float64 `json:"fat_percent"`
+	ProteinPercent   *float64 `json:"protein_percent"`
+	SomaticCellCount *float64 `json:"somatic_cell_count"`
+	BacterialCount   *float64 `json:"bacterial_count"`
+}
+
+type MilkStatementResponse struct {
+	Buyer           string                            `json:"buyer"`
+	Month           string                            `json:"month"`
+	PriceTableID    uint                              `json:"price_table_id"`
+	PriceTableName  string                            `json:"price_table_name"`
+	Deliveries      int                               `json:"deliveries"`
+	DeliveredLiters float64                           `json:"delivered_liters"`
+	CollectedLiters float64                           `json:"collected_liters"`
+	Quality         MilkStatementQualityResponse      `json:"quality"`
+	BasePrice       float64                           `json:"base_price"`
+	VolumeBonus     float64                           `json:"volume_bonus"`
+	Adjustments     []MilkStatementAdjustmentResponse `json:"adjustments"`
+	UnitPrice       float64                           `json:"unit_price"`
+	EstimatedAmount float64                           `json:"estimated_amount"`
+	ReceivedAmount  float64                           `json:"received_amount"`
+	Difference      *float64                          `json:"difference"`
+	Payments        []MilkPaymentResponse             `json:"payments"`
+}
+
+func (req MilkPriceTableRequest) toModel() (*models.MilkPriceTable, error) {
+	validFrom, err := time.Parse(DateFormatISO, req.ValidFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &models.MilkPriceTable{
+		Buyer:        req.Buyer,
+		Name:         req.Name,
+		BasePrice:    req.BasePrice,
+		ValidFrom:    validFrom,
+		VolumeTiers:  make([]models.MilkPriceVolumeTier, len(req.VolumeTiers)),
+		QualityTiers: make([]models.MilkPriceQualityTier, len(req.QualityTiers)),
+	}
+	if req.ValidTo != nil && *req.ValidTo != "" {
+		validTo, err := time.Parse(DateFormatISO, *req.ValidTo)
+		if err != nil {
+			return nil, err
+		}
+		table.ValidTo = &validTo
+	}
+	for i, tier := range req.VolumeTiers {
+		table.VolumeTiers[i] = models.MilkPriceVolumeTier{MinLiters: tier.MinLiters, Bonus: tier.Bonus}
+	}
+	for i, tier := range req.QualityTiers {
+		table.QualityTiers[i] = models.MilkPriceQualityTier{
+			Parameter:  tier.Parameter,
+			Min:        tier.Min,
+			Max:        tier.Max,
+			Adjustment: tier.Adjustment,
+		}
+	}
+	return table, nil
+}
+
+func modelToMilkPriceQualityTierData(tier *models.MilkPriceQualityTier) MilkPriceQualityTierData {
+	return MilkPriceQualityTierData{
+		Parameter:  tier.Parameter,
+		Min:        tier.Min,
+		Max:        tier.Max,
+		Adjustment: tier.Adjustment,
+	}
+}
+
+func modelToMilkPriceTableResponse(table *models.MilkPriceTable) MilkPriceTableResponse {
+	response := MilkPriceTableResponse{
+		ID:           table.ID,
+		Buyer:        table.Buyer,
+		Name:         table.Name,
+		BasePrice:    table.BasePrice,
+		ValidFrom:    table.ValidFrom.Format(DateFormatISO),
+		VolumeTiers:  make([]MilkPriceVolumeTierData, len(table.VolumeTiers)),
+		QualityTiers: make([]MilkPriceQualityTierData, len(table.QualityTiers)),
+		CreatedAt:    table.CreatedAt.Format(DateFormatDateTime),
+		UpdatedAt:    table.UpdatedAt.Format(DateFormatDateTime),
+	}
+	if table.ValidTo != nil {
+		validTo := table.ValidTo.Format(DateFormatISO)
+		response.ValidTo = &validTo
+	}
+	for i, tier := range table.VolumeTiers {
+		response.VolumeTiers[i] = MilkPriceVolumeTierData{MinLiters: tier.MinLiters, Bonus: tier.Bonus}
+	}
+	for i := range table.QualityTiers {
+		response.QualityTiers[i] = modelToMilkPriceQualityTierData(&table.QualityTiers[i])
+	}
+	return response
+}
+
+func (req MilkDeliveryRequest) toModel() (*models.MilkDelivery, error) {
+	date, err := time.Parse(DateFormatISO, req.DeliveryDate)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MilkDelivery{
+		Buyer:        req.Buyer,
+		DeliveryDate: date,
+		Liters:       req.Liters,
+		Tank:         req.Tank,
+		Notes:        req.Notes,
+	}, nil
+}
+
+func modelToMilkDeliveryResponse(delivery *models.MilkDelivery) MilkDeliveryResponse {
+	return MilkDeliveryResponse{
+		ID:           delivery.ID,
+		Buyer:        delivery.Buyer,
+		DeliveryDate: delivery.DeliveryDate.Format(DateFormatISO),
+		Liters:       delivery.Liters,
+		Tank:         delivery.Tank,
+		Notes:        delivery.Notes,
+		CreatedAt:    delivery.CreatedAt.Format(DateFormatDateTime),
+		UpdatedAt:    delivery.UpdatedAt.Format(DateFormatDateTime),
+	}
+}
+
+func modelToMilkPaymentResponse(payment *models.MilkPayment) MilkPaymentResponse {
+	return MilkPaymentResponse{
+		ID:          payment.ID,
+		Buyer:       payment.Buyer,
+		Month:       payment.Month.Format(MonthFormat),
+		Amount:      payment.Amount,
+		PaymentDate: payment.PaymentDate.Format(DateFormatISO),
+		Notes:       payment.Notes,
+		CreatedAt:   payment.CreatedAt.Format(DateFormatDateTime),
+	}
+}
+
+func milkStatementToResponse(statement *service.MilkStatement) MilkStatementResponse {
+	response := MilkStatementResponse{
+		Buyer:           statement.Buyer,
+		Month:           statement.Month.Format(MonthFormat),
+		PriceTableID:    statement.PriceTable.ID,
+		PriceTableName:  statement.PriceTable.Name,
+		Deliveries:      statement.Deliveries,
+		DeliveredLiters: roundTo(statement.DeliveredLiters, 100),
+		CollectedLiters: roundTo(statement.CollectedLiters, 100),
+		Quality: MilkStatementQualityResponse{
+			Tests:            statement.QualityTests,
+			FatPercent:       roundOptional(statement.FatPercent, 100),
+			ProteinPercent:   roundOptional(statement.ProteinPercent, 100),
+			SomaticCellCount: roundOptional(statement.SomaticCellCount, 10),
+			BacterialCount:   roundOptional(statement.BacterialCount, 10),
+		},
+		BasePrice:       statement.BasePrice,
+		VolumeBonus:     statement.VolumeBonus,
+		Adjustments:     make([]MilkStatementAdjustmentResponse, len(statement.Adjustments)),
+		UnitPrice:       roundTo(statement.UnitPrice, 10000),
+		EstimatedAmount: roundTo(statement.EstimatedAmount, 100),
+		ReceivedAmount:  roundTo(statement.ReceivedAmount, 100),
+		Payments:        make([]MilkPaymentResponse, len(statement.Payments)),
+	}
+
+	for i, adjustment := range statement.Adjustments {
+		response.Adjustments[i] = MilkStatementAdjustmentResponse{
+			Parameter:  adjustment.Parameter,
+			Value:      roundOptional(adjustment.Value, 100),
+			Adjustment: adjustment.Adjustment,
+		}
+		if adjustment.Tier != nil {
+			tier := modelToMilkPriceQualityTierData(adjustment.Tier)
+			response.Adjustments[i].Tier = &tier
+		}
+	}
+	for i, payment := range statement.Payments {
+		response.Payments[i] = modelToMilkPaymentResponse(payment)
+	}
+	if len(statement.Payments) > 0 {
+		difference := roundTo(statement.Difference, 100)
+		response.Difference = &difference
+	}
+
+	return response
+}
+
+func sendMilkDeliveryServiceError(w http.ResponseWriter, err error, fallbackStatus int) {
+	switch err.Error() {
+	case service.ErrMilkPriceTableNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrMilkPriceTableNotFound, http.StatusNotFound)
+	case service.ErrMilkDeliveryNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrMilkDeliveryNotFound, http.StatusNotFound)
+	case service.ErrMilkPaymentNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrMilkPaymentNotFound, http.StatusNotFound)
+	case service.ErrMilkPriceTableNotFoundForMonth:
+		SendErrorResponse(w, ErrNoMilkPriceTableForMonth, http.StatusNotFound)
+	default:
+		SendErrorResponse(w, err.Error(), fallbackStatus)
+	}
+}
+
+func parseMonthQueryParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	month, err := time.Parse(MonthFormat, value)
+	if err != nil {
+		return nil, err
+	}
+	return &month, nil
+}
+
+func (h *MilkDeliveryHandler) CreatePriceTable(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req MilkPriceTableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	table, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreatePriceTable(r.Context(), table, farmID); err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.GetPriceTableByID(r.Context(), table.ID, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrMilkPriceTableNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkPriceTableResponse(created), "Tabela de preço do leite criada com sucesso", http.StatusCreated)
+}
+
+func (h *MilkDeliveryHandler) GetPriceTables(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	tables, err := h.service.GetPriceTables(r.Context(), farmID, r.URL.Query().Get("buyer"))
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]MilkPriceTableResponse, len(tables))
+	for i, table := range tables {
+		responses[i] = modelToMilkPriceTableResponse(table)
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Tabelas de preço do leite encontradas com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) GetPriceTableByID(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkPriceTableID, http.StatusBadRequest)
+		return
+	}
+
+	table, err := h.service.GetPriceTableByID(r.Context(), id, farmID)
+	if err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkPriceTableResponse(table), "Tabela de preço do leite encontrada com sucesso", http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) UpdatePriceTable(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkPriceTableID, http.StatusBadRequest)
+		return
+	}
+
+	var req MilkPriceTableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	table, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	table.ID = id
+
+	if err := h.service.UpdatePriceTable(r.Context(), table, farmID); err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.GetPriceTableByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrMilkPriceTableNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkPriceTableResponse(updated), "Tabela de preço do leite atualizada com sucesso", http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) DeletePriceTable(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkPriceTableID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeletePriceTable(r.Context(), id, farmID); err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Tabela de preço do leite deletada com sucesso", http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) CreateDelivery(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req MilkDeliveryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreateDelivery(r.Context(), delivery, farmID); err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkDeliveryResponse(delivery), "Entrega de leite registrada com sucesso", http.StatusCreated)
+}
+
+func (h *MilkDeliveryHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	startDate, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	filter := repository.MilkDeliveryFilter{Buyer: r.URL.Query().Get("buyer"), StartDate: startDate}
+	if endDate != nil {
+		end := endOfDay(*endDate)
+		filter.EndDate = &end
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), farmID, filter)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]MilkDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = modelToMilkDeliveryResponse(delivery)
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Entregas de leite encontradas com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) GetDeliveryByID(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkDeliveryID, http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.service.GetDeliveryByID(r.Context(), id, farmID)
+	if err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkDeliveryResponse(delivery), "Entrega de leite encontrada com sucesso", http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) UpdateDelivery(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkDeliveryID, http.StatusBadRequest)
+		return
+	}
+
+	var req MilkDeliveryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	delivery.ID = id
+
+	if err := h.service.UpdateDelivery(r.Context(), delivery, farmID); err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.GetDeliveryByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrMilkDeliveryNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkDeliveryResponse(updated), "Entrega de leite atualizada com sucesso", http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) DeleteDelivery(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkDeliveryID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteDelivery(r.Context(), id, farmID); err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Entrega de leite deletada com sucesso", http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req MilkPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	month, err := time.Parse(MonthFormat, req.Month)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMonthFormat, http.StatusBadRequest)
+		return
+	}
+	paymentDate, err := time.Parse(DateFormatISO, req.PaymentDate)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	payment := &models.MilkPayment{
+		Buyer:       req.Buyer,
+		Month:       month,
+		Amount:      req.Amount,
+		PaymentDate: paymentDate,
+		Notes:       req.Notes,
+	}
+
+	if err := h.service.CreatePayment(r.Context(), payment, farmID); err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkPaymentResponse(payment), "Pagamento do leite registrado com sucesso", http.StatusCreated)
+}
+
+func (h *MilkDeliveryHandler) GetPayments(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	month, err := parseMonthQueryParam(r, "month")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMonthFormat, http.StatusBadRequest)
+		return
+	}
+
+	payments, err := h.service.GetPayments(r.Context(), farmID, r.URL.Query().Get("buyer"), month)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]MilkPaymentResponse, len(payments))
+	for i, payment := range payments {
+		responses[i] = modelToMilkPaymentResponse(payment)
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Pagamentos do leite encontrados com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) DeletePayment(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkPaymentID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeletePayment(r.Context(), id, farmID); err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Pagamento do leite deletado com sucesso", http.StatusOK)
+}
+
+func (h *MilkDeliveryHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	buyer := r.URL.Query().Get("buyer")
+	if buyer == "" {
+		SendErrorResponse(w, ErrBuyerRequired, http.StatusBadRequest)
+		return
+	}
+
+	month, err := parseMonthQueryParam(r, "month")
+	if err != nil || month == nil {
+		SendErrorResponse(w, ErrInvalidMonthFormat, http.StatusBadRequest)
+		return
+	}
+
+	statement, err := h.service.GetStatement(r.Context(), farmID, buyer, *month)
+	if err != nil {
+		sendMilkDeliveryServiceError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, milkStatementToResponse(statement), "Extrato do leite calculado com sucesso", http.StatusOK)
+}
@@ -0,0 +1,457 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+const (
+	MilkQualityTypeTank   = "tank"
+	MilkQualityTypeAnimal = "animal"
+)
+
+type MilkQualityHandler struct {
+	service service.MilkQualityService
+}
+
+func NewMilkQualityHandler(service service.MilkQualityService) *MilkQualityHandler {
+	return &MilkQualityHandler{service: service}
+}
+
+type MilkQualityTestRequest struct {
+	AnimalID         *uint    `json:"animal_id"`
+	MilkCollectionID *uint    `json:"milk_collection_id"`
+	Tank             string   `json:"tank"`
+	SampleDate       string   `json:"sample_date"`
+	FatPercent       *float64 `json:"fat_percent"`
+	ProteinPercent   *float64 `json:"protein_percent"`
+	SomaticCellCount *float64 `json:"somatic_cell_count"`
+	BacterialCount   *float64 `json:"bacterial_count"`
+	Laboratory       string   `json:"laboratory"`
+}
+
+type MilkQualityTestResponse struct {
+	ID               uint     `json:"id"`
+	AnimalID         *uint    `json:"animal_id"`
+	AnimalName       string   `json:"animal_name,omitempty"`
+	EarTag           int      `json:"ear_tag,omitempty"`
+	MilkCollectionID *uint    `json:"milk_collection_id"`
+	Tank             string   `json:"tank"`
+	SampleDate       string   `json:"sample_date"`
+	FatPercent       *float64 `json:"fat_percent"`
+	ProteinPercent   *float64 `json:"protein_percent"`
+	SomaticCellCount *float64 `json:"somatic_cell_count"`
+	BacterialCount   *float64 `json:"bacterial_count"`
+	Laboratory       string   `json:"laboratory"`
+	Source           string   `json:"source"`
+	PossibleMastitis bool     `json:"possible_mastitis"`
+	AboveTankLimits  bool     `json:"above_tank_limits"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+}
+
+type MilkQualityImportErrorResponse struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type MilkQualityImportResponse struct {
+	Imported int                              `json:"imported"`
+	Errors   []MilkQualityImportErrorResponse `json:"errors"`
+}
+
+type MastitisFlagResponse struct {
+	AnimalID         uint    `json:"animal_id"`
+	AnimalName       string  `json:"animal_name"`
+	EarTag           int     `json:"ear_tag"`
+	CurrentBatch     int     `json:"current_batch"`
+	TestID           uint    `json:"test_id"`
+	SampleDate       string  `json:"sample_date"`
+	SomaticCellCount float64 `json:"somatic_cell_count"`
+	ConsecutiveTests int     `json:"consecutive_tests"`
+	Chronic          bool    `json:"chronic"`
+}
+
+type MilkQualityTrendResponse struct {
+	Month            string   `json:"month"`
+	Tests            int      `json:"tests"`
+	FatPercent       *float64 `json:"fat_percent"`
+	ProteinPercent   *float64 `json:"protein_percent"`
+	SomaticCellCount *float64 `json:"somatic_cell_count"`
+	BacterialCount   *float64 `json:"bacterial_count"`
+	HighSCCTests     int      `json:"high_scc_tests"`
+}
+
+func (req MilkQualityTestRequest) toModel() (*models.MilkQualityTest, error) {
+	test := &models.MilkQualityTest{
+		AnimalID:         req.AnimalID,
+		MilkCollectionID: req.MilkCollectionID,
+		Tank:             req.Tank,
+		FatPercent:       req.FatPercent,
+		ProteinPercent:   req.ProteinPercent,
+		SomaticCellCount: req.SomaticCellCount,
+		BacterialCount:   req.BacterialCount,
+		Laboratory:       req.Laboratory,
+	}
+	if req.SampleDate != "" {
+		date, err := time.Parse(DateFormatISO, req.SampleDate)
+		if err != nil {
+			return nil, err
+		}
+		test.SampleDate = date
+	}
+	return test, nil
+}
+
+func modelToMilkQualityTestResponse(test *models.MilkQualityTest) MilkQualityTestResponse {
+	response := MilkQualityTestResponse{
+		ID:               test.ID,
+		AnimalID:         test.AnimalID,
+		MilkCollectionID: test.MilkCollectionID,
+		Tank:             test.Tank,
+		SampleDate:       test.SampleDate.Format(DateFormatISO),
+		FatPercent:       test.FatPercent,
+		ProteinPercent:   test.ProteinPercent,
+		SomaticCellCount: test.SomaticCellCount,
+		BacterialCount:   test.BacterialCount,
+		Laboratory:       test.Laboratory,
+		Source:           test.Source,
+		PossibleMastitis: test.PossibleMastitis(),
+		AboveTankLimits:  test.AboveTankLimits(),
+		CreatedAt:        test.CreatedAt.Format(DateFormatDateTime),
+		UpdatedAt:        test.UpdatedAt.Format(DateFormatDateTime),
+	}
+	if test.Animal != nil {
+		response.AnimalName = test.Animal.AnimalName
+		response.EarTag = test.Animal.EarTagNumberLocal
+	}
+	return response
+}
+
+func sendMilkQualityServiceError(w http.ResponseWriter, err error, fallbackStatus int) {
+	switch err.Error() {
+	case service.ErrMilkQualityTestNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrMilkQualityTestNotFound, http.StatusNotFound)
+	case service.ErrAnimalNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+	case service.ErrMilkCollectionNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrMilkCollectionNotFound, http.StatusNotFound)
+	default:
+		SendErrorResponse(w, err.Error(), fallbackStatus)
+	}
+}
+
+func (h *MilkQualityHandler) CreateTest(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req MilkQualityTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	test, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreateTest(r.Context(), test, farmID); err != nil {
+		sendMilkQualityServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.GetTestByID(r.Context(), test.ID, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrMilkQualityTestNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkQualityTestResponse(created), "Análise de qualidade registrada com sucesso", http.StatusCreated)
+}
+
+func (h *MilkQualityHandler) GetTestByID(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkQualityTestID, http.StatusBadRequest)
+		return
+	}
+
+	test, err := h.service.GetTestByID(r.Context(), id, farmID)
+	if err != nil {
+		sendMilkQualityServiceError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkQualityTestResponse(test), "Análise de qualidade encontrada com sucesso", http.StatusOK)
+}
+
+func (h *MilkQualityHandler) GetTests(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := repository.MilkQualityFilter{Tank: query.Get("tank")}
+
+	if value := query.Get("animal_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+			return
+		}
+		animalID := uint(parsed)
+		filter.AnimalID = &animalID
+	}
+
+	switch query.Get("type") {
+	case "":
+	case MilkQualityTypeTank:
+		filter.TankOnly = true
+	case MilkQualityTypeAnimal:
+		filter.AnimalOnly = true
+	default:
+		SendErrorResponse(w, ErrInvalidMilkQualityType, http.StatusBadRequest)
+		return
+	}
+
+	startDate, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	filter.StartDate = startDate
+	if endDate != nil {
+		end := endOfDay(*endDate)
+		filter.EndDate = &end
+	}
+
+	tests, err := h.service.GetTests(r.Context(), farmID, filter)
+	if err != nil {
+		sendMilkQualityServiceError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]MilkQualityTestResponse, len(tests))
+	for i, test := range tests {
+		responses[i] = modelToMilkQualityTestResponse(test)
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Análises de qualidade encontradas com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *MilkQualityHandler) UpdateTest(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkQualityTestID, http.StatusBadRequest)
+		return
+	}
+
+	var req MilkQualityTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	test, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	test.ID = id
+
+	if err := h.service.UpdateTest(r.Context(), test, farmID); err != nil {
+		sendMilkQualityServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.GetTestByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrMilkQualityTestNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToMilkQualityTestResponse(updated), "Análise de qualidade atualizada com sucesso", http.StatusOK)
+}
+
+func (h *MilkQualityHandler) DeleteTest(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidMilkQualityTestID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTest(r.Context(), id, farmID); err != nil {
+		sendMilkQualityServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Análise de qualidade deletada com sucesso", http.StatusOK)
+}
+
+func (h *MilkQualityHandler) ImportTests(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		SendErrorResponse(w, "Erro ao fazer parse do formulário: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		SendErrorResponse(w, "Erro ao obter arquivo: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportCSV(r.Context(), farmID, file)
+	if err != nil {
+		SendErrorResponse(w, "Erro ao importar análises: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := MilkQualityImportResponse{
+		Imported: result.Imported,
+		Errors:   make([]MilkQualityImportErrorResponse, len(result.Errors)),
+	}
+	for i, importErr := range result.Errors {
+		response.Errors[i] = MilkQualityImportErrorResponse{Line: importErr.Line, Message: importErr.Message}
+	}
+
+	if len(response.Errors) > 0 {
+		SendErrorResponseWithData(w, response, fmt.Sprintf("Nenhuma análise importada: %d linhas com erro", len(response.Errors)), http.StatusUnprocessableEntity)
+		return
+	}
+
+	SendSuccessResponse(w, response, fmt.Sprintf("Análises importadas com sucesso (%d registros)", response.Imported), http.StatusCreated)
+}
+
+func (h *MilkQualityHandler) GetMastitisFlags(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	flags, err := h.service.GetMastitisFlags(r.Context(), farmID)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]MastitisFlagResponse, len(flags))
+	for i, flag := range flags {
+		responses[i] = MastitisFlagResponse{
+			AnimalID:         flag.Animal.ID,
+			AnimalName:       flag.Animal.AnimalName,
+			EarTag:           flag.Animal.EarTagNumberLocal,
+			CurrentBatch:     flag.Animal.CurrentBatch,
+			TestID:           flag.LatestTest.ID,
+			SampleDate:       flag.LatestTest.SampleDate.Format(DateFormatISO),
+			SomaticCellCount: *flag.LatestTest.SomaticCellCount,
+			ConsecutiveTests: flag.ConsecutiveTests,
+			Chronic:          flag.Chronic,
+		}
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Animais com suspeita de mastite (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *MilkQualityHandler) GetTrends(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	tankOnly := true
+	switch r.URL.Query().Get("type") {
+	case "", MilkQualityTypeTank:
+	case MilkQualityTypeAnimal:
+		tankOnly = false
+	default:
+		SendErrorResponse(w, ErrInvalidMilkQualityType, http.StatusBadRequest)
+		return
+	}
+
+	startDate, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	end := endOfDay(time.Now().Truncate(24 * time.Hour))
+	if endDate != nil {
+		end = endOfDay(*endDate)
+	}
+	start := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -11, 0)
+	if startDate != nil {
+		start = *startDate
+	}
+
+	points, err := h.service.GetTrends(r.Context(), farmID, tankOnly, start, end)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	responses := make([]MilkQualityTrendResponse, len(points))
+	for i, point := range points {
+		responses[i] = MilkQualityTrendResponse{
+			Month:            point.Month,
+			Tests:            point.Tests,
+			FatPercent:       roundOptional(point.FatPercent, 100),
+			ProteinPercent:   roundOptional(point.ProteinPercent, 100),
+			SomaticCellCount: roundOptional(point.SomaticCellCount, 10),
+			BacterialCount:   roundOptional(point.BacterialCount, 10),
+			HighSCCTests:     point.HighSCCTests,
+		}
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Tendência de qualidade do leite (%d meses)", len(responses)), http.StatusOK)
+}
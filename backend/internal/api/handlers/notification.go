@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type NotificationHandler struct {
+	service service.NotificationService
+}
+
+func NewNotificationHandler(service service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+type NotificationResponse struct {
+	ID         uint    `json:"id"`
+	Type       string  `json:"type"`
+	Title      string  `json:"title"`
+	Message    string  `json:"message"`
+	AnimalID   *uint   `json:"animal_id,omitempty"`
+	AnimalName string  `json:"animal_name,omitempty"`
+	DueDate    *string `json:"due_date,omitempty"`
+	Read       bool    `json:"read"`
+	ReadAt     *string `json:"read_at,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	UnreadCount   int64                  `json:"unread_count"`
+	Notifications []NotificationResponse `json:"notifications"`
+}
+
+func modelToNotificationResponse(notification *models.Notification) NotificationResponse {
+	response := NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		AnimalID:  notification.AnimalID,
+		Read:      notification.ReadAt != nil,
+		CreatedAt: notification.CreatedAt.Format(DateFormatDateTime),
+	}
+
+	if notification.Animal != nil {
+		response.AnimalName = notification.Animal.AnimalName
+	}
+	if notification.DueDate != nil {
+		dueDate := notification.DueDate.Format(DateFormatISO)
+		response.DueDate = &dueDate
+	}
+	if notification.ReadAt != nil {
+		readAt := notification.ReadAt.Format(DateFormatDateTime)
+		response.ReadAt = &readAt
+	}
+
+	return response
+}
+
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	var limit int
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidLimitParam, http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	notifications, err := h.service.GetNotifications(r.Context(), farmID, unreadOnly, limit)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	unreadCount, err := h.service.CountUnread(r.Context(), farmID)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := NotificationListResponse{
+		UnreadCount:   unreadCount,
+		Notifications: make([]NotificationResponse, len(notifications)),
+	}
+	for i, notification := range notifications {
+		response.Notifications[i] = modelToNotificationResponse(notification)
+	}
+
+	SendSuccessResponse(w, response, fmt.Sprintf("Notificações encontradas com sucesso (%d não lidas)", unreadCount), http.StatusOK)
+}
+
+func (h *NotificationHandler) MarkAsRead(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidNotificationID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.MarkAsRead(r.Context(), id, farmID); err != nil {
+		if err.Error() == service.ErrNotificationNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrNotificationNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Notificação marcada como lida", http.StatusOK)
+}
+
+func (h *NotificationHandler) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	updated, err := h.service.MarkAllAsRead(r.Context(), farmID)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, map[string]int64{"updated": updated}, fmt.Sprintf("%d notificações marcadas como lidas", updated), http.StatusOK)
+}
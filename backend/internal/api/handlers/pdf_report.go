@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+const ReproductionCalendarDefaultDays = 90
+
+type PDFReportHandler struct {
+	service service.PDFReportService
+}
+
+func NewPDFReportHandler(service service.PDFReportService) *PDFReportHandler {
+	return &PDFReportHandler{service: service}
+}
+
+func (h *PDFReportHandler) HerdInventory(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	h.sendPDF(w, fmt.Sprintf("rebanho-%d-%s.pdf", farmID, time.Now().Format(DateFormatISO)), func(out io.Writer) error {
+		return h.service.HerdInventory(r.Context(), farmID, out)
+	})
+}
+
+func (h *PDFReportHandler) SalesStatement(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now()
+	startDate, endDate, ok := parseReportPeriod(w, r, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), now)
+	if !ok {
+		return
+	}
+
+	h.sendPDF(w, fmt.Sprintf("vendas-%d-%s-%s.pdf", farmID, startDate.Format(DateFormatISO), endDate.Format(DateFormatISO)), func(out io.Writer) error {
+		return h.service.SalesStatement(r.Context(), farmID, startDate, endOfDay(endDate), out)
+	})
+}
+
+func (h *PDFReportHandler) ReproductionCalendar(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	startDate, endDate, ok := parseReportPeriod(w, r, today, today.AddDate(0, 0, ReproductionCalendarDefaultDays))
+	if !ok {
+		return
+	}
+
+	h.sendPDF(w, fmt.Sprintf("calendario-reprodutivo-%d-%s-%s.pdf", farmID, startDate.Format(DateFormatISO), endDate.Format(DateFormatISO)), func(out io.Writer) error {
+		return h.service.ReproductionCalendar(r.Context(), farmID, startDate, endOfDay(endDate), out)
+	})
+}
+
+func parseReportPeriod(w http.ResponseWriter, r *http.Request, defaultStart, defaultEnd time.Time) (time.Time, time.Time, bool) {
+	startDate, endDate := defaultStart, defaultEnd
+
+	start, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return startDate, endDate, false
+	}
+	if start != nil {
+		startDate = *start
+	}
+
+	end, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return startDate, endDate, false
+	}
+	if end != nil {
+		endDate = *end
+	}
+
+	return startDate, endDate, true
+}
+
+func (h *PDFReportHandler) sendPDF(w http.ResponseWriter, name string, render func(io.Writer) error) {
+	var buffer bytes.Buffer
+	if err := render(&buffer); err != nil {
+		if err.Error() == service.ErrInvalidReportPeriod {
+			SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Erro ao gerar relatório %s: %v", name, err)
+		SendErrorResponse(w, ErrInternalServer, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set(HeaderContentType, "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buffer.Bytes())
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type PedigreeHandler struct {
+	service service.PedigreeService
+}
+
+func NewPedigreeHandler(service service.PedigreeService) *PedigreeHandler {
+	return &PedigreeHandler{service: service}
+}
+
+type GenealogyAnimalResponse struct {
+	ID                uint   `json:"id"`
+	AnimalName        string `json:"animal_name"`
+	EarTagNumberLocal int    `json:"ear_tag_number_local"`
+	Sex               int    `json:"sex"`
+	Breed             string `json:"breed"`
+	BirthDate         string `json:"birth_date,omitempty"`
+}
+
+type PedigreeNodeResponse struct {
+	GenealogyAnimalResponse
+	Generation            int                   `json:"generation"`
+	InbreedingCoefficient float64               `json:"inbreeding_coefficient"`
+	Father                *PedigreeNodeResponse `json:"father,omitempty"`
+	Mother                *PedigreeNodeResponse `json:"mother,omitempty"`
+}
+
+type PedigreeResponse struct {
+	AnimalID              uint                 `json:"animal_id"`
+	Generations           int                  `json:"generations"`
+	InbreedingCoefficient float64              `json:"inbreeding_coefficient"`
+	Pedigree              PedigreeNodeResponse `json:"pedigree"`
+}
+
+type DescendantNodeResponse struct {
+	GenealogyAnimalResponse
+	Generation int                      `json:"generation"`
+	Offspring  []DescendantNodeResponse `json:"offspring"`
+}
+
+type DescendantsResponse struct {
+	AnimalID    uint                     `json:"animal_id"`
+	Generations int                      `json:"generations"`
+	Total       int                      `json:"total"`
+	Descendants []DescendantNodeResponse `json:"descendants"`
+}
+
+type MatingInbreedingResponse struct {
+	Sire                  GenealogyAnimalResponse   `json:"sire"`
+	Dam                   GenealogyAnimalResponse   `json:"dam"`
+	InbreedingCoefficient float64                   `json:"inbreeding_coefficient"`
+	CommonAncestors       []GenealogyAnimalResponse `json:"common_ancestors"`
+}
+
+func roundCoefficient(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
+
+func modelToGenealogyAnimal(animal *models.Animal) GenealogyAnimalResponse {
+	return GenealogyAnimalResponse{
+		ID:                animal.ID,
+		AnimalName:        animal.AnimalName,
+		EarTagNumberLocal: animal.EarTagNumberLocal,
+		Sex:               animal.Sex,
+		Breed:             animal.Breed,
+		BirthDate:         formatBirthDate(animal.BirthDate),
+	}
+}
+
+func pedigreeNodeToResponse(node *service.PedigreeNode) *PedigreeNodeResponse {
+	if node == nil {
+		return nil
+	}
+
+	return &PedigreeNodeResponse{
+		GenealogyAnimalResponse: modelToGenealogyAnimal(node.Animal),
+		Generation:              node.Generation,
+		InbreedingCoefficient:   roundCoefficient(node.InbreedingCoefficient),
+		Father:                  pedigreeNodeToResponse(node.Father),
+		Mother:                  pedigreeNodeToResponse(node.Mother),
+	}
+}
+
+func descendantNodesToResponse(nodes []*service.DescendantNode) []DescendantNodeResponse {
+	responses := make([]DescendantNodeResponse, len(nodes))
+	for i, node := range nodes {
+		responses[i] = DescendantNodeResponse{
+			GenealogyAnimalResponse: modelToGenealogyAnimal(node.Animal),
+			Generation:              node.Generation,
+			Offspring:               descendantNodesToResponse(node.Offspring),
+		}
+	}
+	return responses
+}
+
+func parseGenerationsParam(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("generations")
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func sendPedigreeServiceError(w http.ResponseWriter, err error) {
+	if err.Error() == service.ErrAnimalNotFoundOrNotBelongsToFarm {
+		SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+		return
+	}
+	SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+}
+
+func (h *PedigreeHandler) GetPedigree(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	animalID, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return
+	}
+
+	generations, err := parseGenerationsParam(r)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidGenerations, http.StatusBadRequest)
+		return
+	}
+
+	pedigree, err := h.service.GetPedigree(r.Context(), animalID, farmID, generations)
+	if err != nil {
+		sendPedigreeServiceError(w, err)
+		return
+	}
+
+	root := pedigreeNodeToResponse(pedigree.Root)
+	response := PedigreeResponse{
+		AnimalID:              pedigree.AnimalID,
+		Generations:           pedigree.Generations,
+		InbreedingCoefficient: root.InbreedingCoefficient,
+		Pedigree:              *root,
+	}
+
+	SendSuccessResponse(w, response, "Genealogia encontrada com sucesso", http.StatusOK)
+}
+
+func (h *PedigreeHandler) GetDescendants(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	animalID, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return
+	}
+
+	generations, err := parseGenerationsParam(r)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidGenerations, http.StatusBadRequest)
+		return
+	}
+
+	descendants, err := h.service.GetDescendants(r.Context(), animalID, farmID, generations)
+	if err != nil {
+		sendPedigreeServiceError(w, err)
+		return
+	}
+
+	response := DescendantsResponse{
+		AnimalID:    descendants.AnimalID,
+		Generations: descendants.Generations,
+		Total:       descendants.Total,
+		Descendants: descendantNodesToResponse(descendants.Offspring),
+	}
+
+	SendSuccessResponse(w, response, fmt.Sprintf("Descendentes encontrados com sucesso (%d animais)", response.Total), http.StatusOK)
+}
+
+func (h *PedigreeHandler) GetMatingInbreeding(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	sireID, err := strconv.ParseUint(r.URL.Query().Get("sire_id"), 10, 32)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidSireID, http.StatusBadRequest)
+		return
+	}
+
+	damID, err := strconv.ParseUint(r.URL.Query().Get("dam_id"), 10, 32)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDamID, http.StatusBadRequest)
+		return
+	}
+
+	mating, err := h.service.GetMatingInbreeding(r.Context(), uint(sireID), uint(damID), farmID)
+	if err != nil {
+		sendPedigreeServiceError(w, err)
+		return
+	}
+
+	response := MatingInbreedingResponse{
+		Sire:                  modelToGenealogyAnimal(mating.Sire),
+		Dam:                   modelToGenealogyAnimal(mating.Dam),
+		InbreedingCoefficient: roundCoefficient(mating.InbreedingCoefficient),
+		CommonAncestors:       make([]GenealogyAnimalResponse, len(mating.CommonAncestors)),
+	}
+	for i, ancestor := range mating.CommonAncestors {
+		response.CommonAncestors[i] = modelToGenealogyAnimal(ancestor)
+	}
+
+	SendSuccessResponse(w, response, "Coeficiente de endogamia calculado com sucesso", http.StatusOK)
+}
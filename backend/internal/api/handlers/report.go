@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type ReportHandler struct {
+	service service.ReportService
+}
+
+func NewReportHandler(service service.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+func (h *ReportHandler) GetProfitAndLoss(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	months := 12
+	if monthsStr := r.URL.Query().Get("months"); monthsStr != "" {
+		parsed, err := strconv.Atoi(monthsStr)
+		if err != nil || parsed <= 0 || parsed > service.PnLMaxMonths {
+			SendErrorResponse(w, ErrInvalidMonthsParam, http.StatusBadRequest)
+			return
+		}
+		months = parsed
+	}
+
+	endDate := time.Now()
+	end, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	if end != nil {
+		endDate = *end
+	}
+
+	startDate := time.Date(endDate.Year(), endDate.Month(), 1, 0, 0, 0, 0, endDate.Location()).AddDate(0, -months+1, 0)
+	start, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	if start != nil {
+		startDate = *start
+	}
+
+	report, err := h.service.GetProfitAndLoss(r.Context(), farmID, startDate, endDate)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, report, "Demonstrativo de resultados gerado com sucesso", http.StatusOK)
+}
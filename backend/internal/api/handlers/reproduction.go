@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -41,10 +42,29 @@ type CreateReproductionRequest struct {
 	ReproductionData
 }
 
+type ReproductionPhaseData struct {
+	InseminationDate       *string `json:"insemination_date,omitempty"`
+	InseminationType       string  `json:"insemination_type,omitempty"`
+	PregnancyDate          *string `json:"pregnancy_date,omitempty"`
+	ActualBirthDate        *string `json:"actual_birth_date,omitempty"`
+	LactationStartDate     *string `json:"lactation_start_date,omitempty"`
+	LactationEndDate       *string `json:"lactation_end_date,omitempty"`
+	DryPeriodStartDate     *string `json:"dry_period_start_date,omitempty"`
+	VeterinaryConfirmation *bool   `json:"veterinary_confirmation,omitempty"`
+	Observations           *string `json:"observations,omitempty"`
+}
+
+type UpdateReproductionRequest struct {
+	ID                uint    `json:"id"`
+	CurrentPhase      *int    `json:"current_phase,omitempty"`
+	ExpectedBirthDate *string `json:"expected_birth_date,omitempty"`
+	ReproductionPhaseData
+}
+
 type UpdateReproductionPhaseRequest struct {
-	AnimalID       uint                   `json:"animal_id"`
-	NewPhase       int                    `json:"new_phase"`
-	AdditionalData map[string]interface{} `json:"additional_data,omitempty"`
+	AnimalID       uint                  `json:"animal_id"`
+	NewPhase       int                   `json:"new_phase"`
+	AdditionalData ReproductionPhaseData `json:"additional_data"`
 }
 
 type ReproductionResponse struct {
@@ -138,6 +158,83 @@ func modelToReproductionResponse(reproduction *models.Reproduction) Reproduction
 	return response
 }
 
+func parsePhaseDate(field string, dateStr *string) (*time.Time, error) {
+	if dateStr == nil || *dateStr == "" {
+		return nil, nil
+	}
+	parsedDate, err := time.Parse(DateFormatISO, *dateStr)
+	if err != nil {
+		return nil, &service.PhaseTransitionError{Field: field, Message: "formato de data inválido, use YYYY-MM-DD"}
+	}
+	return &parsedDate, nil
+}
+
+func (d ReproductionPhaseData) toTransitionData() (service.PhaseTransitionData, error) {
+	data := service.PhaseTransitionData{
+		InseminationType:       d.InseminationType,
+		VeterinaryConfirmation: d.VeterinaryConfirmation,
+		Observations:           d.Observations,
+	}
+
+	dates := []struct {
+		field  string
+		value  *string
+		target **time.Time
+	}{
+		{"insemination_date", d.InseminationDate, &data.InseminationDate},
+		{"pregnancy_date", d.PregnancyDate, &data.PregnancyDate},
+		{"actual_birth_date", d.ActualBirthDate, &data.ActualBirthDate},
+		{"lactation_start_date", d.LactationStartDate, &data.LactationStartDate},
+		{"lactation_end_date", d.LactationEndDate, &data.LactationEndDate},
+		{"dry_period_start_date", d.DryPeriodStartDate, &data.DryPeriodStartDate},
+	}
+	for _, date := range dates {
+		parsed, err := parsePhaseDate(date.field, date.value)
+		if err != nil {
+			return data, err
+		}
+		*date.target = parsed
+	}
+
+	return data, nil
+}
+
+func (req UpdateReproductionRequest) toReproductionUpdate() (service.ReproductionUpdate, error) {
+	data, err := req.ReproductionPhaseData.toTransitionData()
+	if err != nil {
+		return service.ReproductionUpdate{}, err
+	}
+
+	update := service.ReproductionUpdate{PhaseTransitionData: data}
+	if req.CurrentPhase != nil {
+		phase := models.ReproductionPhase(*req.CurrentPhase)
+		update.CurrentPhase = &phase
+	}
+	update.ExpectedBirthDate, err = parsePhaseDate("expected_birth_date", req.ExpectedBirthDate)
+	if err != nil {
+		return service.ReproductionUpdate{}, err
+	}
+
+	return update, nil
+}
+
+func sendReproductionServiceError(w http.ResponseWriter, prefix string, err error) {
+	var transitionErr *service.PhaseTransitionError
+	if errors.As(err, &transitionErr) {
+		SendErrorResponse(w, prefix+transitionErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	switch err.Error() {
+	case service.ErrAnimalNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+	case service.ErrReproductionNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrReproductionNotFound, http.StatusNotFound)
+	default:
+		SendErrorResponse(w, prefix+err.Error(), http.StatusBadRequest)
+	}
+}
+
 func (h *ReproductionHandler) CreateReproduction(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		SendErrorResponse(w, ErrMethodNotAllowed, http.StatusMethodNotAllowed)
@@ -150,10 +247,15 @@ func (h *ReproductionHandler) CreateReproduction(w http.ResponseWriter, r *http.
 		return
 	}
 
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
 	reproduction := reproductionDataToModel(req.ReproductionData)
 
-	if err := h.service.CreateReproduction(&reproduction); err != nil {
-		SendErrorResponse(w, "Erro ao criar registro de reprodução: "+err.Error(), http.StatusBadRequest)
+	if err := h.service.CreateReproduction(&reproduction, farmID); err != nil {
+		sendReproductionServiceError(w, "Erro ao criar registro de reprodução: ", err)
 		return
 	}
 
@@ -176,14 +278,19 @@ func (h *ReproductionHandler) GetReproduction(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	reproduction, err := h.service.GetReproductionByID(uint(id))
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	reproduction, err := h.service.GetReproductionByID(uint(id), farmID)
 	if err != nil {
 		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	if reproduction == nil {
-		SendErrorResponse(w, "Registro de reprodução não encontrado", http.StatusNotFound)
+		SendErrorResponse(w, ErrReproductionNotFound, http.StatusNotFound)
 		return
 	}
 
@@ -204,7 +311,12 @@ func (h *ReproductionHandler) GetReproductionByAnimal(w http.ResponseWriter, r *
 		return
 	}
 
-	reproduction, err := h.service.GetReproductionByAnimalID(uint(id))
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	reproduction, err := h.service.GetReproductionByAnimalID(uint(id), farmID)
 	if err != nil {
 		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -220,19 +332,12 @@ func (h *ReproductionHandler) GetReproductionByAnimal(w http.ResponseWriter, r *
 }
 
 func (h *ReproductionHandler) GetReproductionsByFarm(w http.ResponseWriter, r *http.Request) {
-	farmID := r.URL.Query().Get("farmId")
-	if farmID == "" {
-		SendErrorResponse(w, "ID da fazenda é obrigatório", http.StatusBadRequest)
+	farmID, ok := resolveFarmID(w, r, r.URL.Query().Get("farmId"))
+	if !ok {
 		return
 	}
 
-	id, err := strconv.ParseUint(farmID, 10, 32)
-	if err != nil {
-		SendErrorResponse(w, "ID da fazenda inválido", http.StatusBadRequest)
-		return
-	}
-
-	reproductions, err := h.service.GetReproductionsByFarmID(uint(id))
+	reproductions, err := h.service.GetReproductionsByFarmID(farmID)
 	if err != nil {
 		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -259,7 +364,12 @@ func (h *ReproductionHandler) GetReproductionsByPhase(w http.ResponseWriter, r *
 		return
 	}
 
-	reproductions, err := h.service.GetReproductionsByPhase(models.ReproductionPhase(phase))
+	farmID, ok := resolveFarmID(w, r, r.URL.Query().Get("farmId"))
+	if !ok {
+		return
+	}
+
+	reproductions, err := h.service.GetReproductionsByPhase(farmID, models.ReproductionPhase(phase))
 	if err != nil {
 		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -279,16 +389,25 @@ func (h *ReproductionHandler) UpdateReproduction(w http.ResponseWriter, r *http.
 		return
 	}
 
-	var req CreateReproductionRequest
+	var req UpdateReproductionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	reproduction := reproductionDataToModel(req.ReproductionData)
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
 
-	if err := h.service.UpdateReproduction(&reproduction); err != nil {
-		SendErrorResponse(w, "Erro ao atualizar registro de reprodução: "+err.Error(), http.StatusBadRequest)
+	update, err := req.toReproductionUpdate()
+	if err != nil {
+		sendReproductionServiceError(w, "Erro ao atualizar registro de reprodução: ", err)
+		return
+	}
+
+	if err := h.service.UpdateReproduction(req.ID, farmID, update); err != nil {
+		sendReproductionServiceError(w, "Erro ao atualizar registro de reprodução: ", err)
 		return
 	}
 
@@ -303,17 +422,33 @@ func (h *ReproductionHandler) UpdateReproductionPhase(w http.ResponseWriter, r *
 
 	var req UpdateReproductionPhaseRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			SendErrorResponse(w, fmt.Sprintf(ErrInvalidFieldType, typeErr.Field, typeErr.Type.String()), http.StatusUnprocessableEntity)
+			return
+		}
 		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	additionalData := make(map[string]interface{})
-	for key, value := range req.AdditionalData {
-		additionalData[key] = value
+	if req.AnimalID == 0 {
+		SendErrorResponse(w, ErrAnimalIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	data, err := req.AdditionalData.toTransitionData()
+	if err != nil {
+		sendReproductionServiceError(w, "Erro ao atualizar fase de reprodução: ", err)
+		return
 	}
 
-	if err := h.service.UpdateReproductionPhase(req.AnimalID, models.ReproductionPhase(req.NewPhase), additionalData); err != nil {
-		SendErrorResponse(w, "Erro ao atualizar fase de reprodução: "+err.Error(), http.StatusBadRequest)
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	if err := h.service.UpdateReproductionPhase(req.AnimalID, farmID, models.ReproductionPhase(req.NewPhase), data); err != nil {
+		sendReproductionServiceError(w, "Erro ao atualizar fase de reprodução: ", err)
 		return
 	}
 
@@ -338,14 +473,206 @@ func (h *ReproductionHandler) DeleteReproduction(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if err := h.service.DeleteReproduction(uint(id)); err != nil {
-		SendErrorResponse(w, "Erro ao deletar registro de reprodução: "+err.Error(), http.StatusBadRequest)
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteReproduction(uint(id), farmID); err != nil {
+		sendReproductionServiceError(w, "Erro ao deletar registro de reprodução: ", err)
 		return
 	}
 
 	SendSuccessResponse(w, nil, "Registro de reprodução deletado com sucesso", http.StatusOK)
 }
 
+type ReproductionEventRequest struct {
+	AnimalID               uint   `json:"animal_id"`
+	Type                   string `json:"type"`
+	Date                   string `json:"date"`
+	InseminationType       string `json:"insemination_type,omitempty"`
+	SireID                 *uint  `json:"sire_id,omitempty"`
+	PregnancyPositive      *bool  `json:"pregnancy_positive,omitempty"`
+	VeterinaryConfirmation bool   `json:"veterinary_confirmation"`
+	CalfID                 *uint  `json:"calf_id,omitempty"`
+	Observations           string `json:"observations,omitempty"`
+}
+
+type ReproductionEventResponse struct {
+	ID                     uint   `json:"id"`
+	AnimalID               uint   `json:"animal_id"`
+	Type                   string `json:"type"`
+	Date                   string `json:"date"`
+	InseminationType       string `json:"insemination_type,omitempty"`
+	SireID                 *uint  `json:"sire_id,omitempty"`
+	PregnancyPositive      *bool  `json:"pregnancy_positive,omitempty"`
+	VeterinaryConfirmation bool   `json:"veterinary_confirmation"`
+	CalfID                 *uint  `json:"calf_id,omitempty"`
+	Observations           string `json:"observations,omitempty"`
+	CreatedAt              string `json:"createdAt"`
+}
+
+type CalvingRecordResponse struct {
+	EventID      uint   `json:"event_id"`
+	Date         string `json:"date"`
+	CalfID       *uint  `json:"calf_id,omitempty"`
+	IntervalDays *int   `json:"interval_days"`
+	Observations string `json:"observations,omitempty"`
+}
+
+type CalvingHistoryResponse struct {
+	AnimalID                   uint                    `json:"animal_id"`
+	TotalCalvings              int                     `json:"total_calvings"`
+	TotalAbortions             int                     `json:"total_abortions"`
+	AgeAtFirstCalvingDays      *int                    `json:"age_at_first_calving_days"`
+	AverageCalvingIntervalDays *float64                `json:"average_calving_interval_days"`
+	Calvings                   []CalvingRecordResponse `json:"calvings"`
+}
+
+func (req ReproductionEventRequest) toModel() (*models.ReproductionEvent, error) {
+	date, err := time.Parse(DateFormatISO, req.Date)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReproductionEvent{
+		AnimalID:               req.AnimalID,
+		Type:                   req.Type,
+		Date:                   date,
+		InseminationType:       req.InseminationType,
+		SireID:                 req.SireID,
+		PregnancyPositive:      req.PregnancyPositive,
+		VeterinaryConfirmation: req.VeterinaryConfirmation,
+		CalfID:                 req.CalfID,
+		Observations:           req.Observations,
+	}, nil
+}
+
+func modelToReproductionEventResponse(event *models.ReproductionEvent) ReproductionEventResponse {
+	return ReproductionEventResponse{
+		ID:                     event.ID,
+		AnimalID:               event.AnimalID,
+		Type:                   event.Type,
+		Date:                   event.Date.Format(DateFormatISO),
+		InseminationType:       event.InseminationType,
+		SireID:                 event.SireID,
+		PregnancyPositive:      event.PregnancyPositive,
+		VeterinaryConfirmation: event.VeterinaryConfirmation,
+		CalfID:                 event.CalfID,
+		Observations:           event.Observations,
+		CreatedAt:              event.CreatedAt.Format(DateFormatDateTime),
+	}
+}
+
+func parseAnimalIDQueryParam(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	animalID := r.URL.Query().Get("animalId")
+	if animalID == "" {
+		SendErrorResponse(w, "ID do animal é obrigatório", http.StatusBadRequest)
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(animalID, 10, 32)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+func (h *ReproductionHandler) CreateReproductionEvent(w http.ResponseWriter, r *http.Request) {
+	var req ReproductionEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	event, err := req.toModel()
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	reproduction, err := h.service.RecordEvent(event, farmID)
+	if err != nil {
+		sendReproductionServiceError(w, "Erro ao registrar evento reprodutivo: ", err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"id":            event.ID,
+		"current_phase": int(reproduction.CurrentPhase),
+	}
+	SendSuccessResponse(w, data, "Evento reprodutivo registrado com sucesso", http.StatusCreated)
+}
+
+func (h *ReproductionHandler) GetReproductionEvents(w http.ResponseWriter, r *http.Request) {
+	animalID, ok := parseAnimalIDQueryParam(w, r)
+	if !ok {
+		return
+	}
+
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	events, err := h.service.GetEventsByAnimalID(animalID, farmID)
+	if err != nil {
+		sendReproductionServiceError(w, "Erro ao buscar eventos reprodutivos: ", err)
+		return
+	}
+
+	responses := make([]ReproductionEventResponse, len(events))
+	for i := range events {
+		responses[i] = modelToReproductionEventResponse(&events[i])
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Eventos reprodutivos encontrados com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *ReproductionHandler) GetCalvingHistory(w http.ResponseWriter, r *http.Request) {
+	animalID, ok := parseAnimalIDQueryParam(w, r)
+	if !ok {
+		return
+	}
+
+	farmID, ok := resolveFarmID(w, r, "")
+	if !ok {
+		return
+	}
+
+	history, err := h.service.GetCalvingHistory(animalID, farmID)
+	if err != nil {
+		sendReproductionServiceError(w, "Erro ao buscar histórico de partos: ", err)
+		return
+	}
+
+	response := CalvingHistoryResponse{
+		AnimalID:                   history.AnimalID,
+		TotalCalvings:              history.TotalCalvings,
+		TotalAbortions:             history.TotalAbortions,
+		AgeAtFirstCalvingDays:      history.AgeAtFirstCalvingDays,
+		AverageCalvingIntervalDays: roundOptional(history.AverageCalvingIntervalDays, 10),
+		Calvings:                   make([]CalvingRecordResponse, len(history.Calvings)),
+	}
+	for i, calving := range history.Calvings {
+		response.Calvings[i] = CalvingRecordResponse{
+			EventID:      calving.EventID,
+			Date:         calving.Date.Format(DateFormatISO),
+			CalfID:       calving.CalfID,
+			IntervalDays: calving.IntervalDays,
+			Observations: calving.Observations,
+		}
+	}
+
+	SendSuccessResponse(w, response, "Histórico de partos encontrado com sucesso", http.StatusOK)
+}
+
 type NextToCalveResponse struct {
 	ID                uint   `json:"id"`
 	AnimalName        string `json:"animal_name"`
@@ -385,14 +712,17 @@ func buildNextToCalveResponses(reproductions []models.Reproduction, farmID uint,
 			continue
 		}
 
-		expectedBirth := reproduction.PregnancyDate.AddDate(0, 0, 283)
+		expectedBirth := reproduction.PregnancyDate.AddDate(0, 0, models.DefaultGestationDays)
+		if reproduction.ExpectedBirthDate != nil {
+			expectedBirth = *reproduction.ExpectedBirthDate
+		}
 		daysUntilBirth := int(expectedBirth.Sub(now).Hours() / 24)
 
 		response := NextToCalveResponse{
 			ID:                reproduction.Animal.ID,
 			AnimalName:        reproduction.Animal.AnimalName,
 			EarTagNumberLocal: reproduction.Animal.EarTagNumberLocal,
-			Photo:             reproduction.Animal.Photo,
+			Photo:             animalThumbnailURL(&reproduction.Animal),
 			PregnancyDate:     reproduction.PregnancyDate.Format(DateFormatISO),
 			ExpectedBirthDate: expectedBirth.Format(DateFormatISO),
 			DaysUntilBirth:    daysUntilBirth,
@@ -406,25 +736,18 @@ func buildNextToCalveResponses(reproductions []models.Reproduction, farmID uint,
 }
 
 func (h *ReproductionHandler) GetNextToCalve(w http.ResponseWriter, r *http.Request) {
-	farmID := r.URL.Query().Get("farmId")
-	if farmID == "" {
-		SendErrorResponse(w, "ID da fazenda é obrigatório", http.StatusBadRequest)
-		return
-	}
-
-	id, err := strconv.ParseUint(farmID, 10, 32)
-	if err != nil {
-		SendErrorResponse(w, "ID da fazenda inválido", http.StatusBadRequest)
+	farmID, ok := resolveFarmID(w, r, r.URL.Query().Get("farmId"))
+	if !ok {
 		return
 	}
 
-	reproductions, err := h.service.GetReproductionsByPhase(models.PhasePrenhas)
+	reproductions, err := h.service.GetReproductionsByPhase(farmID, models.PhasePrenhas)
 	if err != nil {
 		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	responses := buildNextToCalveResponses(reproductions, uint(id), time.Now())
+	responses := buildNextToCalveResponses(reproductions, farmID, time.Now())
 	sortByDaysUntilBirth(responses)
 
 	SendSuccessResponse(w, responses, fmt.Sprintf("Próximas vacas a parir encontradas com sucesso (%d registros)", len(responses)), http.StatusOK)
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type ReproductiveKPIHandler struct {
+	service service.ReproductiveKPIService
+}
+
+func NewReproductiveKPIHandler(service service.ReproductiveKPIService) *ReproductiveKPIHandler {
+	return &ReproductiveKPIHandler{service: service}
+}
+
+type ReproductiveKPIMetricResponse struct {
+	Value      *float64 `json:"value"`
+	SampleSize int      `json:"sample_size"`
+}
+
+type PregnancyRateWindowResponse struct {
+	StartDate string   `json:"start_date"`
+	EndDate   string   `json:"end_date"`
+	Eligible  int      `json:"eligible"`
+	Pregnant  int      `json:"pregnant"`
+	Rate      *float64 `json:"rate"`
+}
+
+type ReproductiveKPIsResponse struct {
+	FarmID                     uint                          `json:"farm_id"`
+	StartDate                  string                        `json:"start_date"`
+	EndDate                    string                        `json:"end_date"`
+	CalvingIntervalDays        ReproductiveKPIMetricResponse `json:"calving_interval_days"`
+	DaysOpen                   ReproductiveKPIMetricResponse `json:"days_open"`
+	ServicesPerConception      ReproductiveKPIMetricResponse `json:"services_per_conception"`
+	FirstServiceConceptionRate ReproductiveKPIMetricResponse `json:"first_service_conception_rate"`
+	PregnancyRate              ReproductiveKPIMetricResponse `json:"pregnancy_rate"`
+	AgeAtFirstCalvingMonths    ReproductiveKPIMetricResponse `json:"age_at_first_calving_months"`
+	PregnancyRateWindows       []PregnancyRateWindowResponse `json:"pregnancy_rate_windows"`
+}
+
+func kpiMetricToResponse(metric service.ReproductiveKPIMetric) ReproductiveKPIMetricResponse {
+	return ReproductiveKPIMetricResponse{
+		Value:      roundOptional(metric.Value, 10),
+		SampleSize: metric.SampleSize,
+	}
+}
+
+func (h *ReproductiveKPIHandler) GetKPIs(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := resolveFarmID(w, r, r.URL.Query().Get("farmId"))
+	if !ok {
+		return
+	}
+
+	endDate := time.Now()
+	end, err := parseDateQueryParam(r, "end_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	if end != nil {
+		endDate = *end
+	}
+
+	startDate := endDate.AddDate(-1, 0, 1)
+	start, err := parseDateQueryParam(r, "start_date")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+	if start != nil {
+		startDate = *start
+	}
+
+	kpis, err := h.service.GetKPIs(r.Context(), farmID, startDate, endDate)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := ReproductiveKPIsResponse{
+		FarmID:                     kpis.FarmID,
+		StartDate:                  kpis.StartDate.Format(DateFormatISO),
+		EndDate:                    kpis.EndDate.Format(DateFormatISO),
+		CalvingIntervalDays:        kpiMetricToResponse(kpis.CalvingIntervalDays),
+		DaysOpen:                   kpiMetricToResponse(kpis.DaysOpen),
+		ServicesPerConception:      kpiMetricToResponse(kpis.ServicesPerConception),
+		FirstServiceConceptionRate: kpiMetricToResponse(kpis.FirstServiceConceptionRate),
+		PregnancyRate:              kpiMetricToResponse(kpis.PregnancyRate),
+		AgeAtFirstCalvingMonths:    kpiMetricToResponse(kpis.AgeAtFirstCalvingMonths),
+		PregnancyRateWindows:       make([]PregnancyRateWindowResponse, len(kpis.PregnancyRateWindows)),
+	}
+	for i, window := range kpis.PregnancyRateWindows {
+		response.PregnancyRateWindows[i] = PregnancyRateWindowResponse{
+			StartDate: window.StartDate.Format(DateFormatISO),
+			EndDate:   window.EndDate.Format(DateFormatISO),
+			Eligible:  window.Eligible,
+			Pregnant:  window.Pregnant,
+			Rate:      roundOptional(window.Rate, 10),
+		}
+	}
+
+	SendSuccessResponse(w, response, "Indicadores reprodutivos calculados com sucesso", http.StatusOK)
+}
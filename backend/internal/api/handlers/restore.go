@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+const (
+	RestoreMaxArchiveSize = 200 << 20
+	RestoreTimeout        = 10 * time.Minute
+)
+
+type RestoreHandler struct {
+	service service.RestoreService
+}
+
+func NewRestoreHandler(service service.RestoreService) *RestoreHandler {
+	return &RestoreHandler{service: service}
+}
+
+type RestoreRowErrorResponse struct {
+	Entity  string `json:"entity"`
+	Row     int    `json:"row"`
+	ID      uint   `json:"id"`
+	Message string `json:"message"`
+}
+
+type RestoreResponse struct {
+	DryRun                         bool                      `json:"dry_run"`
+	Version                        int                       `json:"version"`
+	SourceFarmID                   uint                      `json:"source_farm_id"`
+	CompanyID                      uint                      `json:"company_id"`
+	FarmID                         *uint                     `json:"farm_id"`
+	Counts                         map[string]int            `json:"counts"`
+	Errors                         []RestoreRowErrorResponse `json:"errors"`
+	ReproductionEventsFromSnapshot bool                      `json:"reproduction_events_from_snapshot"`
+}
+
+func (h *RestoreHandler) RestoreFarm(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+	userID, ok := userIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrUserIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	controller.SetReadDeadline(time.Now().Add(RestoreTimeout))
+	controller.SetWriteDeadline(time.Now().Add(RestoreTimeout))
+	r.Body = http.MaxBytesReader(w, r.Body, RestoreMaxArchiveSize)
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		SendErrorResponse(w, "Erro ao fazer parse do formulário: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		SendErrorResponse(w, "Erro ao obter arquivo: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	var companyID uint
+	if value := r.FormValue("company_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			SendErrorResponse(w, ErrInvalidCompanyID, http.StatusBadRequest)
+			return
+		}
+		companyID = uint(parsed)
+	}
+
+	dryRun := true
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			SendErrorResponse(w, "Parâmetro dry_run inválido, use true ou false", http.StatusBadRequest)
+			return
+		}
+		dryRun = parsed
+	}
+
+	companyID, err = h.service.ResolveCompany(r.Context(), farmID, userID, companyID)
+	if err != nil {
+		h.sendRestoreError(w, err)
+		return
+	}
+
+	result, err := h.service.RestoreArchive(r.Context(), companyID, userID, file, dryRun)
+	if err != nil {
+		h.sendRestoreError(w, err)
+		return
+	}
+
+	response := RestoreResponse{
+		DryRun:                         result.DryRun,
+		Version:                        result.Version,
+		SourceFarmID:                   result.SourceFarmID,
+		CompanyID:                      result.CompanyID,
+		Counts:                         result.Counts,
+		Errors:                         make([]RestoreRowErrorResponse, len(result.Errors)),
+		ReproductionEventsFromSnapshot: result.ReproductionEventsFromSnapshot,
+	}
+	if result.FarmID != 0 {
+		response.FarmID = &result.FarmID
+	}
+	for i, rowErr := range result.Errors {
+		response.Errors[i] = RestoreRowErrorResponse{Entity: rowErr.Entity, Row: rowErr.Row, ID: rowErr.ID, Message: rowErr.Message}
+	}
+
+	if len(response.Errors) > 0 {
+		SendErrorResponseWithData(w, response, fmt.Sprintf("Nenhum dado restaurado: %d registros com erro", len(response.Errors)), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if dryRun {
+		SendSuccessResponse(w, response, "Arquivo validado: pronto para restaurar", http.StatusOK)
+		return
+	}
+
+	SendSuccessResponse(w, response, fmt.Sprintf("Fazenda restaurada com sucesso (fazenda %d)", result.FarmID), http.StatusCreated)
+}
+
+func (h *RestoreHandler) sendRestoreError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case service.ErrCompanyAccessDenied:
+		SendErrorResponse(w, err.Error(), http.StatusForbidden)
+	case service.ErrCompanyNotFound:
+		SendErrorResponse(w, err.Error(), http.StatusNotFound)
+	case repository.ErrFarmNotFound:
+		SendErrorResponse(w, ErrFarmNotFound, http.StatusNotFound)
+	default:
+		SendErrorResponse(w, "Erro ao restaurar fazenda: "+err.Error(), http.StatusBadRequest)
+	}
+}
@@ -12,11 +12,12 @@ import (
 )
 
 type SaleChiHandler struct {
-	service service.SaleService
+	service        service.SaleService
+	expenseService service.ExpenseService
 }
 
-func NewSaleChiHandler(service service.SaleService) *SaleChiHandler {
-	return &SaleChiHandler{service: service}
+func NewSaleChiHandler(service service.SaleService, expenseService service.ExpenseService) *SaleChiHandler {
+	return &SaleChiHandler{service: service, expenseService: expenseService}
 }
 
 type CreateSaleRequest struct {
@@ -506,13 +507,10 @@ func (h *SaleChiHandler) GetMonthlySalesAndPurchases(w http.ResponseWriter, r *h
 		return
 	}
 
-	purchasesData := make([]map[string]interface{}, len(salesData))
-	for i := range purchasesData {
-		purchasesData[i] = map[string]interface{}{
-			"month": salesData[i].Month,
-			"year":  salesData[i].Year,
-			"total": 0.0,
-		}
+	purchasesData, err := h.expenseService.GetMonthlyExpensesData(r.Context(), farmID, months)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
 	data := map[string]interface{}{
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type SemenCatalogHandler struct {
+	service service.SemenCatalogService
+}
+
+func NewSemenCatalogHandler(service service.SemenCatalogService) *SemenCatalogHandler {
+	return &SemenCatalogHandler{service: service}
+}
+
+type SemenCatalogRequest struct {
+	BullName       string   `json:"bull_name"`
+	Code           string   `json:"code"`
+	Breed          string   `json:"breed"`
+	Supplier       string   `json:"supplier"`
+	FatherID       *uint    `json:"father_id"`
+	MotherID       *uint    `json:"mother_id"`
+	PTAMilk        *float64 `json:"pta_milk"`
+	DosesAvailable int      `json:"doses_available"`
+	Active         *bool    `json:"active"`
+	Notes          string   `json:"notes"`
+}
+
+type SemenCatalogResponse struct {
+	ID             uint     `json:"id"`
+	FarmID         uint     `json:"farm_id"`
+	BullName       string   `json:"bull_name"`
+	Code           string   `json:"code"`
+	Breed          string   `json:"breed"`
+	Supplier       string   `json:"supplier"`
+	FatherID       *uint    `json:"father_id"`
+	MotherID       *uint    `json:"mother_id"`
+	PTAMilk        *float64 `json:"pta_milk"`
+	DosesAvailable int      `json:"doses_available"`
+	Active         bool     `json:"active"`
+	Notes          string   `json:"notes"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+}
+
+func (req SemenCatalogRequest) toModel() *models.SemenCatalogEntry {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+	return &models.SemenCatalogEntry{
+		BullName:       req.BullName,
+		Code:           req.Code,
+		Breed:          req.Breed,
+		Supplier:       req.Supplier,
+		FatherID:       req.FatherID,
+		MotherID:       req.MotherID,
+		PTAMilk:        req.PTAMilk,
+		DosesAvailable: req.DosesAvailable,
+		Active:         active,
+		Notes:          req.Notes,
+	}
+}
+
+func modelToSemenCatalogResponse(entry *models.SemenCatalogEntry) SemenCatalogResponse {
+	return SemenCatalogResponse{
+		ID:             entry.ID,
+		FarmID:         entry.FarmID,
+		BullName:       entry.BullName,
+		Code:           entry.Code,
+		Breed:          entry.Breed,
+		Supplier:       entry.Supplier,
+		FatherID:       entry.FatherID,
+		MotherID:       entry.MotherID,
+		PTAMilk:        entry.PTAMilk,
+		DosesAvailable: entry.DosesAvailable,
+		Active:         entry.Active,
+		Notes:          entry.Notes,
+		CreatedAt:      entry.CreatedAt.Format(DateFormatDateTime),
+		UpdatedAt:      entry.UpdatedAt.Format(DateFormatDateTime),
+	}
+}
+
+func (h *SemenCatalogHandler) CreateEntry(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req SemenCatalogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry := req.toModel()
+	entry.FarmID = farmID
+
+	if err := h.service.CreateEntry(r.Context(), entry); err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, modelToSemenCatalogResponse(entry), "Sêmen cadastrado com sucesso", http.StatusCreated)
+}
+
+func (h *SemenCatalogHandler) GetEntryByID(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidSemenCatalogID, http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.service.GetEntryByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrSemenCatalogNotFound, http.StatusNotFound)
+		return
+	}
+
+	SendSuccessResponse(w, modelToSemenCatalogResponse(entry), "Sêmen encontrado com sucesso", http.StatusOK)
+}
+
+func (h *SemenCatalogHandler) GetEntriesByFarm(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	activeOnly := r.URL.Query().Get("active") == "true"
+
+	entries, err := h.service.GetEntriesByFarmID(r.Context(), farmID, activeOnly)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]SemenCatalogResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = modelToSemenCatalogResponse(entry)
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Catálogo de sêmen encontrado com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *SemenCatalogHandler) UpdateEntry(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidSemenCatalogID, http.StatusBadRequest)
+		return
+	}
+
+	var req SemenCatalogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry := req.toModel()
+	entry.ID = id
+
+	if err := h.service.UpdateEntry(r.Context(), entry, farmID); err != nil {
+		if err.Error() == service.ErrSemenCatalogNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrSemenCatalogNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.GetEntryByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrSemenCatalogNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToSemenCatalogResponse(updated), "Sêmen atualizado com sucesso", http.StatusOK)
+}
+
+func (h *SemenCatalogHandler) DeleteEntry(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidSemenCatalogID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteEntry(r.Context(), id, farmID); err != nil {
+		if err.Error() == service.ErrSemenCatalogNotFoundOrNotBelongsToFarm {
+			SendErrorResponse(w, ErrSemenCatalogNotFound, http.StatusNotFound)
+			return
+		}
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Sêmen removido do catálogo com sucesso", http.StatusOK)
+}
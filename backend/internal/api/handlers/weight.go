@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+type WeightHandler struct {
+	service service.WeightService
+}
+
+func NewWeightHandler(service service.WeightService) *WeightHandler {
+	return &WeightHandler{service: service}
+}
+
+type CreateWeightRequest struct {
+	AnimalID     uint    `json:"animal_id"`
+	Date         string  `json:"date"`
+	AnimalWeight float64 `json:"animal_weight"`
+}
+
+type UpdateWeightRequest struct {
+	Date         string  `json:"date"`
+	AnimalWeight float64 `json:"animal_weight"`
+}
+
+type WeightResponse struct {
+	ID           uint     `json:"id"`
+	AnimalID     uint     `json:"animal_id"`
+	AnimalName   string   `json:"animal_name,omitempty"`
+	EarTag       int      `json:"ear_tag,omitempty"`
+	Date         string   `json:"date"`
+	AnimalWeight float64  `json:"animal_weight"`
+	DaysSince    *int     `json:"days_since_previous,omitempty"`
+	DailyGain    *float64 `json:"daily_gain,omitempty"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+}
+
+type AnimalWeightHistoryResponse struct {
+	AnimalID         uint             `json:"animal_id"`
+	LatestWeight     *WeightResponse  `json:"latest_weight,omitempty"`
+	AverageDailyGain *float64         `json:"average_daily_gain,omitempty"`
+	Weights          []WeightResponse `json:"weights"`
+}
+
+func modelToWeightResponse(weight *models.Weight) WeightResponse {
+	return WeightResponse{
+		ID:           weight.ID,
+		AnimalID:     weight.AnimalID,
+		AnimalName:   weight.Animal.AnimalName,
+		EarTag:       weight.Animal.EarTagNumberLocal,
+		Date:         weight.Date.Format(DateFormatISO),
+		AnimalWeight: weight.AnimalWeight,
+		CreatedAt:    weight.CreatedAt.Format(DateFormatDateTime),
+		UpdatedAt:    weight.UpdatedAt.Format(DateFormatDateTime),
+	}
+}
+
+func sendWeightServiceError(w http.ResponseWriter, err error, fallbackStatus int) {
+	switch err.Error() {
+	case service.ErrWeightNotFoundOrNotBelongsToFarm:
+		SendErrorResponse(w, ErrWeightNotFound, http.StatusNotFound)
+	case service.ErrAnimalNotFound:
+		SendErrorResponse(w, ErrAnimalNotFound, http.StatusNotFound)
+	case service.ErrAnimalNotBelongsToFarm:
+		SendErrorResponse(w, ErrAnimalNotBelongsToFarm, http.StatusForbidden)
+	default:
+		SendErrorResponse(w, err.Error(), fallbackStatus)
+	}
+}
+
+func (h *WeightHandler) CreateWeight(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateWeightRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	date, err := time.Parse(DateFormatISO, req.Date)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	weight := &models.Weight{
+		AnimalID:     req.AnimalID,
+		Date:         date,
+		AnimalWeight: req.AnimalWeight,
+	}
+
+	if err := h.service.CreateWeight(r.Context(), weight, farmID); err != nil {
+		sendWeightServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.GetWeightByID(r.Context(), weight.ID, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrWeightNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToWeightResponse(created), "Pesagem registrada com sucesso", http.StatusCreated)
+}
+
+func (h *WeightHandler) GetWeightByID(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidWeightID, http.StatusBadRequest)
+		return
+	}
+
+	weight, err := h.service.GetWeightByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrWeightNotFound, http.StatusNotFound)
+		return
+	}
+
+	SendSuccessResponse(w, modelToWeightResponse(weight), "Pesagem encontrada com sucesso", http.StatusOK)
+}
+
+func (h *WeightHandler) GetWeightsByFarm(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	weights, err := h.service.GetWeightsByFarmID(r.Context(), farmID)
+	if err != nil {
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	responses := make([]WeightResponse, len(weights))
+	for i, weight := range weights {
+		responses[i] = modelToWeightResponse(weight)
+	}
+
+	SendSuccessResponse(w, responses, fmt.Sprintf("Pesagens encontradas com sucesso (%d registros)", len(responses)), http.StatusOK)
+}
+
+func (h *WeightHandler) GetWeightsByAnimal(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	animalID, err := parseUintURLParam(r, "animalId")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidAnimalID, http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.service.GetAnimalWeightSummary(r.Context(), animalID, farmID)
+	if err != nil {
+		sendWeightServiceError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	response := AnimalWeightHistoryResponse{
+		AnimalID:         summary.AnimalID,
+		AverageDailyGain: summary.AverageDailyGain,
+		Weights:          make([]WeightResponse, len(summary.History)),
+	}
+	for i, entry := range summary.History {
+		weightResponse := modelToWeightResponse(entry.Weight)
+		if i > 0 {
+			daysSince := entry.DaysSince
+			weightResponse.DaysSince = &daysSince
+			weightResponse.DailyGain = entry.DailyGain
+		}
+		response.Weights[i] = weightResponse
+	}
+	if len(response.Weights) > 0 {
+		latest := response.Weights[len(response.Weights)-1]
+		response.LatestWeight = &latest
+	}
+
+	SendSuccessResponse(w, response, "Histórico de pesagens encontrado com sucesso", http.StatusOK)
+}
+
+func (h *WeightHandler) UpdateWeight(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidWeightID, http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateWeightRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		SendErrorResponse(w, ErrDecodeJSON+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	date, err := time.Parse(DateFormatISO, req.Date)
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidDateFormat, http.StatusBadRequest)
+		return
+	}
+
+	weight := &models.Weight{
+		ID:           id,
+		Date:         date,
+		AnimalWeight: req.AnimalWeight,
+	}
+
+	if err := h.service.UpdateWeight(r.Context(), weight, farmID); err != nil {
+		sendWeightServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.service.GetWeightByID(r.Context(), id, farmID)
+	if err != nil {
+		SendErrorResponse(w, ErrWeightNotFound, http.StatusInternalServerError)
+		return
+	}
+
+	SendSuccessResponse(w, modelToWeightResponse(updated), "Pesagem atualizada com sucesso", http.StatusOK)
+}
+
+func (h *WeightHandler) DeleteWeight(w http.ResponseWriter, r *http.Request) {
+	farmID, ok := farmIDFromContext(r)
+	if !ok {
+		SendErrorResponse(w, ErrFarmIDNotFound, http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseUintURLParam(r, "id")
+	if err != nil {
+		SendErrorResponse(w, ErrInvalidWeightID, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteWeight(r.Context(), id, farmID); err != nil {
+		sendWeightServiceError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	SendSuccessResponse(w, nil, "Pesagem deletada com sucesso", http.StatusOK)
+}
@@ -3,10 +3,13 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
 )
 
 const (
@@ -61,7 +64,37 @@ func extractFarmID(token *jwt.Token) (uint, bool) {
 	return uint(farmIDFloat), true
 }
 
-func Auth(jwtSecret string) func(http.Handler) http.Handler {
+func extractUserID(token *jwt.Token) (uint, bool) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, false
+	}
+	userIDFloat, ok := claims["sub"].(float64)
+	if !ok {
+		return 0, false
+	}
+	return uint(userIDFloat), true
+}
+
+type FarmRoleReader interface {
+	GetUserFarmRole(userID, farmID uint) (string, error)
+}
+
+func currentRole(roles FarmRoleReader, userID, farmID uint) (string, error) {
+	role, err := roles.GetUserFarmRole(userID, farmID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.FarmRoleReadOnly, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !models.IsValidFarmRole(role) {
+		return models.FarmRoleReadOnly, nil
+	}
+	return role, nil
+}
+
+func Auth(jwtSecret string, roles FarmRoleReader) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
@@ -76,10 +109,24 @@ func Auth(jwtSecret string) func(http.Handler) http.Handler {
 				return
 			}
 
-			if farmID, ok := extractFarmID(token); ok {
-				ctx := context.WithValue(r.Context(), "farm_id", farmID)
-				r = r.WithContext(ctx)
+			role := models.FarmRoleReadOnly
+			ctx := r.Context()
+			farmID, hasFarm := extractFarmID(token)
+			if hasFarm {
+				ctx = context.WithValue(ctx, "farm_id", farmID)
+			}
+			userID, hasUser := extractUserID(token)
+			if hasUser {
+				ctx = context.WithValue(ctx, "user_id", userID)
+			}
+			if hasFarm && hasUser {
+				role, err = currentRole(roles, userID, farmID)
+				if err != nil {
+					SendErrorResponse(w, "Erro ao verificar o papel do usuário na fazenda", http.StatusInternalServerError)
+					return
+				}
 			}
+			r = r.WithContext(context.WithValue(ctx, "role", role))
 
 			next.ServeHTTP(w, r)
 		})
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+const testJWTSecret = "test-secret"
+
+type fakeFarmRoles map[uint]string
+
+func (r fakeFarmRoles) GetUserFarmRole(userID, farmID uint) (string, error) {
+	role, ok := r[userID]
+	if !ok {
+		return "", gorm.ErrRecordNotFound
+	}
+	if role == "" {
+		return "", errors.New("banco indisponível")
+	}
+	return role, nil
+}
+
+func signedRequest(t *testing.T, method string, userID uint, role string) *http.Request {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": userID, "farm_id": 1, "role": role}).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	req := httptest.NewRequest(method, "/animals", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestAuthUsesCurrentFarmRole(t *testing.T) {
+	roles := fakeFarmRoles{7: models.FarmRoleReadOnly, 8: models.FarmRoleManager, 9: ""}
+	handler := Auth(testJWTSecret, roles)(RequirePermission(PermissionHerdRead, PermissionHerdWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+
+	tests := []struct {
+		name   string
+		userID uint
+		claim  string
+		want   int
+	}{
+		{"demoted after login", 7, models.FarmRoleOwner, http.StatusForbidden},
+		{"promoted after login", 8, models.FarmRoleReadOnly, http.StatusNoContent},
+		{"membership removed", 10, models.FarmRoleOwner, http.StatusForbidden},
+		{"role lookup fails", 9, models.FarmRoleOwner, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, signedRequest(t, http.MethodPost, tt.userID, tt.claim))
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
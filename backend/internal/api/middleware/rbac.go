@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+)
+
+type Permission string
+
+const (
+	PermissionHerdRead          Permission = "herd:read"
+	PermissionHerdWrite         Permission = "herd:write"
+	PermissionMilkRead          Permission = "milk:read"
+	PermissionMilkWrite         Permission = "milk:write"
+	PermissionReproductionRead  Permission = "reproduction:read"
+	PermissionReproductionWrite Permission = "reproduction:write"
+	PermissionFinanceRead       Permission = "finance:read"
+	PermissionFinanceWrite      Permission = "finance:write"
+	PermissionFarmRead          Permission = "farm:read"
+	PermissionFarmWrite         Permission = "farm:write"
+	PermissionMembersManage     Permission = "members:manage"
+)
+
+var rolePermissions = map[string][]Permission{
+	models.FarmRoleOwner: {
+		PermissionHerdRead, PermissionHerdWrite,
+		PermissionMilkRead, PermissionMilkWrite,
+		PermissionReproductionRead, PermissionReproductionWrite,
+		PermissionFinanceRead, PermissionFinanceWrite,
+		PermissionFarmRead, PermissionFarmWrite,
+		PermissionMembersManage,
+	},
+	models.FarmRoleManager: {
+		PermissionHerdRead, PermissionHerdWrite,
+		PermissionMilkRead, PermissionMilkWrite,
+		PermissionReproductionRead, PermissionReproductionWrite,
+		PermissionFinanceRead, PermissionFinanceWrite,
+		PermissionFarmRead, PermissionFarmWrite,
+	},
+	models.FarmRoleMilker: {
+		PermissionHerdRead,
+		PermissionMilkRead, PermissionMilkWrite,
+		PermissionReproductionRead,
+		PermissionFarmRead,
+	},
+	models.FarmRoleVeterinarian: {
+		PermissionHerdRead, PermissionHerdWrite,
+		PermissionMilkRead,
+		PermissionReproductionRead, PermissionReproductionWrite,
+		PermissionFarmRead,
+	},
+	models.FarmRoleReadOnly: {
+		PermissionHerdRead,
+		PermissionMilkRead,
+		PermissionReproductionRead,
+		PermissionFinanceRead,
+		PermissionFarmRead,
+	},
+}
+
+func HasPermission(role string, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func RoleFromContext(r *http.Request) string {
+	role, ok := r.Context().Value("role").(string)
+	if !ok {
+		return models.FarmRoleReadOnly
+	}
+	return role
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func RequirePermission(readPermission, writePermission Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			permission := writePermission
+			if isReadMethod(r.Method) {
+				permission = readPermission
+			}
+
+			if !HasPermission(RoleFromContext(r), permission) {
+				SendErrorResponse(w, "Permissão insuficiente para esta operação", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestBatchRulesRequireFarmWrite(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }
+	router := chi.NewRouter()
+	router.Route("/batches", func(r chi.Router) {
+		r.Use(RequirePermission(PermissionHerdRead, PermissionHerdWrite))
+		r.Get("/rules", ok)
+		r.With(RequirePermission(PermissionFarmWrite, PermissionFarmWrite)).Put("/rules", ok)
+		r.Post("/rebatch", ok)
+	})
+
+	tests := []struct {
+		role   string
+		method string
+		path   string
+		want   int
+	}{
+		{models.FarmRoleVeterinarian, http.MethodPut, "/batches/rules", http.StatusForbidden},
+		{models.FarmRoleVeterinarian, http.MethodGet, "/batches/rules", http.StatusNoContent},
+		{models.FarmRoleVeterinarian, http.MethodPost, "/batches/rebatch", http.StatusNoContent},
+		{models.FarmRoleMilker, http.MethodPut, "/batches/rules", http.StatusForbidden},
+		{models.FarmRoleManager, http.MethodPut, "/batches/rules", http.StatusNoContent},
+		{models.FarmRoleOwner, http.MethodPut, "/batches/rules", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role+" "+tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), "role", tt.role))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
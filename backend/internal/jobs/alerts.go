@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/config"
+	"github.com/fazendapro/FazendaPro-api/internal/notification"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+const AlertsJobName = "reproduction-alerts"
+
+func AlertChannels(cfg config.AlertsConfig) []notification.Channel {
+	var channels []notification.Channel
+	if cfg.SMTPHost != "" {
+		channels = append(channels, notification.NewSMTPChannel(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPFrom))
+	}
+	if cfg.WebhookURL != "" {
+		channels = append(channels, notification.NewWebhookChannel(cfg.WebhookURL, cfg.WebhookSecret))
+	}
+	return channels
+}
+
+func NewAlertsJob(alertService service.AlertService, interval time.Duration, logger *log.Logger) Job {
+	return Job{
+		Name:     AlertsJobName,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			summary, err := alertService.GenerateAlerts(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+			logger.Printf("Alertas: %d reproduções verificadas, %d com erro, %d notificações criadas, %d fazendas notificadas, %d falhas de envio",
+				summary.ReproductionsScanned, summary.ReproductionsFailed, summary.NotificationsCreated, summary.FarmsNotified, summary.DeliveryFailures)
+			if summary.ReproductionsFailed > 0 {
+				return fmt.Errorf("falha ao gerar alertas de %d reproduções", summary.ReproductionsFailed)
+			}
+			return nil
+		},
+	}
+}
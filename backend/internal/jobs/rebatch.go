@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+)
+
+const RebatchJobName = "rebatch"
+
+func NewRebatchJob(batchService *service.BatchService, interval time.Duration, logger *log.Logger) Job {
+	return Job{
+		Name:     RebatchJobName,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			summary, err := batchService.RebatchAllFarms(time.Now())
+			if err != nil {
+				return err
+			}
+			logger.Printf("Lotes: %d fazendas processadas, %d com erro, %d animais avaliados, %d animais movidos",
+				summary.FarmsProcessed, summary.FarmsFailed, summary.AnimalsEvaluated, summary.AnimalsMoved)
+			if summary.FarmsFailed > 0 {
+				return fmt.Errorf("falha ao recalcular lotes de %d fazendas", summary.FarmsFailed)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	logger *log.Logger
+	jobs   []Job
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(logger *log.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+		s.logger.Printf("Job %s agendado a cada %s", job.Name, job.Interval)
+	}
+}
+
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.runOnce(ctx, job)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx, job)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	start := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.logger.Printf("Job %s falhou com panic: %v", job.Name, recovered)
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil {
+		s.logger.Printf("Job %s falhou: %v", job.Name, err)
+		return
+	}
+	s.logger.Printf("Job %s concluído em %s", job.Name, time.Since(start).Round(time.Millisecond))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const ErrRevertingMigration = "error reverting migration %s: %w"
@@ -72,6 +73,23 @@ func RunMigrations(db *gorm.DB) error {
 		{"019_migrate_users_to_user_farms", migrateUsersToUserFarms},
 		{"020_create_sales_table", createSalesTable},
 		{"021_create_debts_table", createDebtsTable},
+		{"022_add_farm_scope_to_debts", addFarmScopeToDebts},
+		{"023_create_debt_payments_table", createDebtPaymentsTable},
+		{"024_add_role_to_user_farms", addRoleToUserFarms},
+		{"025_create_semen_catalog_table", createSemenCatalogTable},
+		{"026_create_reproduction_events_table", createReproductionEventsTable},
+		{"027_check_reproduction_phase_data", checkReproductionPhaseData},
+		{"028_create_gestation_periods_table", createGestationPeriodsTable},
+		{"029_create_notifications_table", createNotificationsTable},
+		{"030_create_batch_rules_and_moves_tables", createBatchRulesAndMovesTables},
+		{"031_create_milk_quality_tests_table", createMilkQualityTestsTable},
+		{"032_create_milk_pricing_tables", createMilkPricingTables},
+		{"033_add_shift_to_milk_collections", addShiftToMilkCollections},
+		{"034_backfill_milk_collection_shifts", backfillMilkCollectionShifts},
+		{"035_add_animal_photo_keys", addAnimalPhotoKeys},
+		{"036_create_animal_attachments_table", createAnimalAttachmentsTable},
+		{"037_add_rule_name_to_batch_moves", addRuleNameToBatchMoves},
+		{"038_add_milk_collection_shift_unique_index", addMilkCollectionShiftUniqueIndex},
 	}
 
 	for _, migration := range migrations {
@@ -204,6 +222,13 @@ func revertDropColumn(db *gorm.DB, model interface{}, columnName, migrationName
 	return nil
 }
 
+func revertDropIndex(db *gorm.DB, model interface{}, indexName, migrationName string) error {
+	if err := db.Migrator().DropIndex(model, indexName); err != nil {
+		return fmt.Errorf(ErrRevertingMigration, migrationName, err)
+	}
+	return nil
+}
+
 func revertRecreateUsersTable(db *gorm.DB, migrationName string) error {
 	if err := db.Migrator().DropTable(&models.User{}); err != nil {
 		return fmt.Errorf(ErrRevertingMigration, migrationName, err)
@@ -300,6 +325,67 @@ func RollbackMigrations(db *gorm.DB, steps int) error {
 		"016_update_reproductions_table": func(db *gorm.DB, name string) error {
 			return revertAutoMigrate(db, &models.Reproduction{}, name)
 		},
+		"022_add_farm_scope_to_debts": func(db *gorm.DB, name string) error {
+			for _, column := range []string{"farm_id", "counterparty_type", "sale_id", "expense_id", "due_date", "status", "paid_amount", "paid_at"} {
+				if err := revertDropColumn(db, &models.Debt{}, column, name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		"023_create_debt_payments_table": func(db *gorm.DB, name string) error {
+			return revertDropTable(db, &models.DebtPayment{}, name)
+		},
+		"024_add_role_to_user_farms": func(db *gorm.DB, name string) error {
+			return revertDropColumn(db, &models.UserFarm{}, "role", name)
+		},
+		"025_create_semen_catalog_table": func(db *gorm.DB, name string) error {
+			return revertDropTable(db, &models.SemenCatalogEntry{}, name)
+		},
+		"026_create_reproduction_events_table": func(db *gorm.DB, name string) error {
+			return revertDropTable(db, &models.ReproductionEvent{}, name)
+		},
+		"028_create_gestation_periods_table": func(db *gorm.DB, name string) error {
+			return revertDropTable(db, &models.GestationPeriod{}, name)
+		},
+		"029_create_notifications_table": func(db *gorm.DB, name string) error {
+			return revertDropTable(db, &models.Notification{}, name)
+		},
+		"030_create_batch_rules_and_moves_tables": func(db *gorm.DB, name string) error {
+			if err := revertDropTable(db, &models.BatchMove{}, name); err != nil {
+				return err
+			}
+			return revertDropTable(db, &models.BatchRule{}, name)
+		},
+		"031_create_milk_quality_tests_table": func(db *gorm.DB, name string) error {
+			return revertDropTable(db, &models.MilkQualityTest{}, name)
+		},
+		"032_create_milk_pricing_tables": func(db *gorm.DB, name string) error {
+			for _, model := range []interface{}{&models.MilkPayment{}, &models.MilkDelivery{}, &models.MilkPriceQualityTier{}, &models.MilkPriceVolumeTier{}} {
+				if err := revertDropTable(db, model, name); err != nil {
+					return err
+				}
+			}
+			return revertDropTable(db, &models.MilkPriceTable{}, name)
+		},
+		"033_add_shift_to_milk_collections": func(db *gorm.DB, name string) error {
+			return revertDropColumn(db, &models.MilkCollection{}, "shift", name)
+		},
+		"035_add_animal_photo_keys": func(db *gorm.DB, name string) error {
+			if err := revertDropColumn(db, &models.Animal{}, "photo_thumbnail_key", name); err != nil {
+				return err
+			}
+			return revertDropColumn(db, &models.Animal{}, "photo_key", name)
+		},
+		"036_create_animal_attachments_table": func(db *gorm.DB, name string) error {
+			return revertDropTable(db, &models.AnimalAttachment{}, name)
+		},
+		"037_add_rule_name_to_batch_moves": func(db *gorm.DB, name string) error {
+			return revertDropColumn(db, &models.BatchMove{}, "rule_name", name)
+		},
+		"038_add_milk_collection_shift_unique_index": func(db *gorm.DB, name string) error {
+			return revertDropIndex(db, &models.MilkCollection{}, milkCollectionShiftIndex, name)
+		},
 	}
 
 	for _, migration := range migrations {
@@ -400,3 +486,414 @@ func createDebtsTable(db *gorm.DB) error {
 	log.Printf("Debts table created successfully")
 	return nil
 }
+
+func addFarmScopeToDebts(db *gorm.DB) error {
+	log.Printf("Adding farm scope, due date and status to debts table...")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&models.Debt{}); err != nil {
+			return fmt.Errorf("error updating debts table: %w", err)
+		}
+
+		var orphanCount int64
+		if err := tx.Model(&models.Debt{}).Where("farm_id IS NULL OR farm_id = 0").Count(&orphanCount).Error; err != nil {
+			return fmt.Errorf("error counting debts without farm: %w", err)
+		}
+		if orphanCount > 0 {
+			var farmIDs []uint
+			if err := tx.Model(&models.Farm{}).Limit(2).Pluck("id", &farmIDs).Error; err != nil {
+				return fmt.Errorf("error finding farms for debts: %w", err)
+			}
+			if len(farmIDs) != 1 {
+				return fmt.Errorf("%d debts have no farm and cannot be assigned automatically (the database has %d farms); set debts.farm_id before running this migration", orphanCount, len(farmIDs))
+			}
+			if err := tx.Model(&models.Debt{}).Where("farm_id IS NULL OR farm_id = 0").Update("farm_id", farmIDs[0]).Error; err != nil {
+				return fmt.Errorf("error assigning debts to farm %d: %w", farmIDs[0], err)
+			}
+			log.Printf("%d debts assigned to farm %d", orphanCount, farmIDs[0])
+		}
+
+		log.Printf("Debts table updated successfully")
+		return nil
+	})
+}
+
+func createDebtPaymentsTable(db *gorm.DB) error {
+	log.Printf("Creating debt payments table...")
+
+	if err := db.AutoMigrate(&models.DebtPayment{}); err != nil {
+		return fmt.Errorf("error creating debt payments table: %w", err)
+	}
+
+	log.Printf("Debt payments table created successfully")
+	return nil
+}
+
+func addRoleToUserFarms(db *gorm.DB) error {
+	log.Printf("Adding role to user_farms table...")
+
+	if err := db.AutoMigrate(&models.UserFarm{}); err != nil {
+		return fmt.Errorf("error adding role to user_farms table: %w", err)
+	}
+
+	farmCreators := db.Model(&models.UserFarm{}).Select("MIN(id)").Group("farm_id")
+	owners := db.Model(&models.UserFarm{}).Where("id IN (?)", farmCreators).Update("role", models.FarmRoleOwner)
+	if owners.Error != nil {
+		return fmt.Errorf("error assigning owner role to farm creators: %w", owners.Error)
+	}
+
+	managers := db.Model(&models.UserFarm{}).Where("id NOT IN (?)", farmCreators).Update("role", models.FarmRoleManager)
+	if managers.Error != nil {
+		return fmt.Errorf("error assigning manager role to existing members: %w", managers.Error)
+	}
+
+	log.Printf("User farms table updated successfully, %d farm creators set as owner, %d existing members set as manager", owners.RowsAffected, managers.RowsAffected)
+	return nil
+}
+
+func createSemenCatalogTable(db *gorm.DB) error {
+	log.Printf("Creating semen catalog table...")
+
+	if err := db.AutoMigrate(&models.SemenCatalogEntry{}); err != nil {
+		return fmt.Errorf("error creating semen catalog table: %w", err)
+	}
+
+	log.Printf("Semen catalog table created successfully")
+	return nil
+}
+
+func createReproductionEventsTable(db *gorm.DB) error {
+	log.Printf("Creating reproduction events table...")
+
+	if err := db.AutoMigrate(&models.ReproductionEvent{}); err != nil {
+		return fmt.Errorf("error creating reproduction events table: %w", err)
+	}
+
+	var reproductions []models.Reproduction
+	if err := db.Find(&reproductions).Error; err != nil {
+		return fmt.Errorf("error finding reproductions: %w", err)
+	}
+
+	for _, reproduction := range reproductions {
+		events := models.ReproductionEventsFromSnapshot(&reproduction, reproduction.UpdatedAt)
+		for i := range events {
+			events[i].Observations = "Evento gerado a partir do registro de reprodução existente"
+		}
+
+		if len(events) > 0 {
+			if err := db.Omit(clause.Associations).Create(&events).Error; err != nil {
+				return fmt.Errorf("error creating reproduction events for animal %d: %w", reproduction.AnimalID, err)
+			}
+		}
+		log.Printf("Migrated reproduction %d to %d events", reproduction.ID, len(events))
+	}
+
+	log.Printf("Reproduction events table created successfully: %d reproductions processed", len(reproductions))
+	return nil
+}
+
+func checkReproductionPhaseData(db *gorm.DB) error {
+	log.Printf("Checking reproduction phases against transition rules...")
+
+	var reproductions []models.Reproduction
+	if err := db.Find(&reproductions).Error; err != nil {
+		return fmt.Errorf("error finding reproductions: %w", err)
+	}
+
+	missingData := 0
+	for _, reproduction := range reproductions {
+		if field := missingPhaseField(&reproduction); field != "" {
+			missingData++
+			log.Printf("Atenção: reprodução do animal %d está na fase %s sem %s", reproduction.AnimalID, reproduction.CurrentPhase, field)
+		}
+	}
+
+	var events []models.ReproductionEvent
+	if err := db.Order("animal_id, date, id").Find(&events).Error; err != nil {
+		return fmt.Errorf("error finding reproduction events: %w", err)
+	}
+
+	invalidTransitions := 0
+	phases := make(map[uint]models.ReproductionPhase)
+	for _, event := range events {
+		target, changesPhase := models.EventTargetPhase(event)
+		if !changesPhase {
+			continue
+		}
+
+		current, known := phases[event.AnimalID]
+		phases[event.AnimalID] = target
+		if !known || current == target {
+			continue
+		}
+
+		if !models.CanTransitionPhase(current, target) {
+			invalidTransitions++
+			log.Printf("Atenção: evento %d (%s em %s) do animal %d leva de %s para %s, transição não permitida", event.ID, event.Type, event.Date.Format("2006-01-02"), event.AnimalID, current, target)
+		}
+	}
+
+	log.Printf("Reproduction phase check finished: %d reproductions, %d without required data, %d invalid transitions", len(reproductions), missingData, invalidTransitions)
+	return nil
+}
+
+func missingPhaseField(reproduction *models.Reproduction) string {
+	switch reproduction.CurrentPhase {
+	case models.PhasePrenhas:
+		if reproduction.PregnancyDate == nil {
+			return "pregnancy_date"
+		}
+	case models.PhaseLactacao:
+		if reproduction.ActualBirthDate == nil && reproduction.LactationStartDate == nil {
+			return "actual_birth_date"
+		}
+	case models.PhaseSecando:
+		if reproduction.DryPeriodStartDate == nil && reproduction.LactationEndDate == nil {
+			return "dry_period_start_date"
+		}
+	}
+	return ""
+}
+
+func createGestationPeriodsTable(db *gorm.DB) error {
+	log.Printf("Creating gestation periods table...")
+
+	if err := db.AutoMigrate(&models.GestationPeriod{}); err != nil {
+		return fmt.Errorf("error creating gestation periods table: %w", err)
+	}
+
+	var count int64
+	db.Model(&models.GestationPeriod{}).Where("farm_id IS NULL").Count(&count)
+	if count == 0 {
+		periods := make([]models.GestationPeriod, len(models.DefaultGestationPeriods))
+		copy(periods, models.DefaultGestationPeriods)
+		if err := db.Omit(clause.Associations).Create(&periods).Error; err != nil {
+			return fmt.Errorf("error seeding gestation periods: %w", err)
+		}
+	}
+
+	var periods []models.GestationPeriod
+	if err := db.Where("farm_id IS NULL").Find(&periods).Error; err != nil {
+		return fmt.Errorf("error finding gestation periods: %w", err)
+	}
+
+	var reproductions []models.Reproduction
+	if err := db.Preload("Animal").Where("pregnancy_date IS NOT NULL").Find(&reproductions).Error; err != nil {
+		return fmt.Errorf("error finding reproductions: %w", err)
+	}
+
+	changed := models.ExpectedBirthDateChanges(reproductions, periods)
+	for id, expectedBirthDate := range changed {
+		if err := db.Model(&models.Reproduction{}).Where("id = ?", id).Update("expected_birth_date", expectedBirthDate).Error; err != nil {
+			return fmt.Errorf("error updating expected birth date of reproduction %d: %w", id, err)
+		}
+	}
+
+	log.Printf("Gestation periods table created successfully: %d expected birth dates recalculated", len(changed))
+	return nil
+}
+
+func createNotificationsTable(db *gorm.DB) error {
+	log.Printf("Creating notifications table...")
+
+	if err := db.AutoMigrate(&models.Notification{}); err != nil {
+		return fmt.Errorf("error creating notifications table: %w", err)
+	}
+
+	log.Printf("Notifications table created successfully")
+	return nil
+}
+
+func createBatchRulesAndMovesTables(db *gorm.DB) error {
+	log.Printf("Creating batch_rules and batch_moves tables...")
+
+	if err := db.AutoMigrate(&models.BatchRule{}, &models.BatchMove{}); err != nil {
+		return fmt.Errorf("error creating batch tables: %w", err)
+	}
+
+	log.Printf("Batch tables created successfully")
+	return nil
+}
+
+func createMilkQualityTestsTable(db *gorm.DB) error {
+	log.Printf("Creating milk_quality_tests table...")
+
+	if err := db.AutoMigrate(&models.MilkQualityTest{}); err != nil {
+		return fmt.Errorf("error creating milk_quality_tests table: %w", err)
+	}
+
+	log.Printf("Milk quality tests table created successfully")
+	return nil
+}
+
+func createMilkPricingTables(db *gorm.DB) error {
+	log.Printf("Creating milk pricing, deliveries and payments tables...")
+
+	if err := db.AutoMigrate(&models.MilkPriceTable{}, &models.MilkPriceVolumeTier{}, &models.MilkPriceQualityTier{}, &models.MilkDelivery{}, &models.MilkPayment{}); err != nil {
+		return fmt.Errorf("error creating milk pricing tables: %w", err)
+	}
+
+	log.Printf("Milk pricing tables created successfully")
+	return nil
+}
+
+func addShiftToMilkCollections(db *gorm.DB) error {
+	log.Printf("Adding shift to milk_collections table...")
+
+	if err := db.AutoMigrate(&models.MilkCollection{}); err != nil {
+		return fmt.Errorf("error adding shift to milk_collections table: %w", err)
+	}
+
+	log.Printf("Milk collections table updated successfully")
+	return nil
+}
+
+func backfillMilkCollectionShifts(db *gorm.DB) error {
+	log.Printf("Backfilling shift in milk_collections table...")
+
+	result := db.Model(&models.MilkCollection{}).
+		Where("shift IS NULL OR shift = ''").
+		Update("shift", models.MilkShiftMorning)
+	if result.Error != nil {
+		return fmt.Errorf("error backfilling milk collection shifts: %w", result.Error)
+	}
+	log.Printf("%d milk collections set to shift %s", result.RowsAffected, models.MilkShiftMorning)
+
+	if err := db.AutoMigrate(&models.MilkCollection{}); err != nil {
+		return fmt.Errorf("error updating milk_collections table: %w", err)
+	}
+
+	var duplicateCount int64
+	db.Raw(`SELECT COUNT(*) FROM (
+		SELECT animal_id FROM milk_collections
+		GROUP BY animal_id, ` + milkCollectionDay + `, shift
+		HAVING COUNT(*) > 1
+	) duplicates`).Scan(&duplicateCount)
+	if duplicateCount > 0 {
+		log.Printf("Atenção: %d animais possuem mais de uma coleta no mesmo dia e turno; ajuste o turno dessas coletas", duplicateCount)
+	}
+
+	log.Printf("Milk collections table updated successfully")
+	return nil
+}
+
+func addAnimalPhotoKeys(db *gorm.DB) error {
+	log.Printf("Adding photo keys to animals table...")
+
+	if err := db.AutoMigrate(&models.Animal{}); err != nil {
+		return fmt.Errorf("error adding photo keys to animals table: %w", err)
+	}
+
+	var legacyCount int64
+	db.Model(&models.Animal{}).Where("photo IS NOT NULL AND photo <> ''").Count(&legacyCount)
+	if legacyCount > 0 {
+		log.Printf("Atenção: %d animais ainda têm a foto salva no banco; execute `main.go migrate-photos` para movê-las para o armazenamento", legacyCount)
+	}
+
+	log.Printf("Animals table updated successfully")
+	return nil
+}
+
+func createAnimalAttachmentsTable(db *gorm.DB) error {
+	log.Printf("Creating animal_attachments table...")
+
+	if err := db.AutoMigrate(&models.AnimalAttachment{}); err != nil {
+		return fmt.Errorf("error creating animal_attachments table: %w", err)
+	}
+
+	log.Printf("Animal attachments table created successfully")
+	return nil
+}
+
+func addRuleNameToBatchMoves(db *gorm.DB) error {
+	log.Printf("Adding rule_name to batch_moves table...")
+
+	if err := db.AutoMigrate(&models.BatchMove{}); err != nil {
+		return fmt.Errorf("error adding rule_name to batch_moves table: %w", err)
+	}
+
+	ruleName := db.Model(&models.BatchRule{}).Select("name").Where("batch_rules.id = batch_moves.rule_id")
+	if err := db.Model(&models.BatchMove{}).Where("rule_id IS NOT NULL").Update("rule_name", ruleName).Error; err != nil {
+		return fmt.Errorf("error backfilling batch move rule names: %w", err)
+	}
+
+	existingRules := db.Model(&models.BatchRule{}).Select("id")
+	result := db.Model(&models.BatchMove{}).
+		Where("rule_id IS NOT NULL AND rule_id NOT IN (?)", existingRules).
+		Update("rule_id", nil)
+	if result.Error != nil {
+		return fmt.Errorf("error clearing removed batch rules from moves: %w", result.Error)
+	}
+
+	log.Printf("Batch moves table updated successfully, %d moves pointed to removed rules", result.RowsAffected)
+	return nil
+}
+
+const (
+	milkCollectionShiftIndex = "idx_milk_collections_animal_day_shift"
+	milkCollectionDay        = "(date AT TIME ZONE 'UTC')::date"
+)
+
+func addMilkCollectionShiftUniqueIndex(db *gorm.DB) error {
+	log.Printf("Adding unique index on milk_collections animal, day and shift...")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var collections []models.MilkCollection
+		err := tx.Where(`(animal_id, ` + milkCollectionDay + `, shift) IN (
+			SELECT animal_id, ` + milkCollectionDay + `, shift FROM milk_collections
+			GROUP BY animal_id, ` + milkCollectionDay + `, shift
+			HAVING COUNT(*) > 1
+		)`).Order("animal_id, date, id").Find(&collections).Error
+		if err != nil {
+			return fmt.Errorf("error finding duplicated milk collections: %w", err)
+		}
+
+		merged, err := mergeDuplicatedMilkCollections(tx, collections)
+		if err != nil {
+			return err
+		}
+		log.Printf("%d duplicated milk collections merged into the collection of the same day and shift", merged)
+
+		if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ` + milkCollectionShiftIndex + `
+			ON milk_collections (animal_id, (` + milkCollectionDay + `), shift)`).Error; err != nil {
+			return fmt.Errorf("error creating milk collection shift index: %w", err)
+		}
+
+		log.Printf("Milk collections shift index created successfully")
+		return nil
+	})
+}
+
+func mergeDuplicatedMilkCollections(tx *gorm.DB, collections []models.MilkCollection) (int, error) {
+	type shiftKey struct {
+		animalID uint
+		day      string
+		shift    string
+	}
+
+	kept := make(map[shiftKey]*models.MilkCollection)
+	merged := 0
+	for i := range collections {
+		collection := &collections[i]
+		key := shiftKey{animalID: collection.AnimalID, day: collection.Date.UTC().Format("2006-01-02"), shift: collection.Shift}
+		target, found := kept[key]
+		if !found {
+			kept[key] = collection
+			continue
+		}
+
+		target.Liters += collection.Liters
+		if err := tx.Model(&models.MilkCollection{}).Where("id = ?", target.ID).Update("liters", target.Liters).Error; err != nil {
+			return 0, fmt.Errorf("error merging milk collection %d: %w", collection.ID, err)
+		}
+		if err := tx.Model(&models.MilkQualityTest{}).Where("milk_collection_id = ?", collection.ID).Update("milk_collection_id", target.ID).Error; err != nil {
+			return 0, fmt.Errorf("error moving quality tests of milk collection %d: %w", collection.ID, err)
+		}
+		if err := tx.Delete(&models.MilkCollection{}, collection.ID).Error; err != nil {
+			return 0, fmt.Errorf("error removing merged milk collection %d: %w", collection.ID, err)
+		}
+		merged++
+	}
+
+	return merged, nil
+}
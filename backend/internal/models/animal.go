@@ -16,6 +16,8 @@ type Animal struct {
 	Type                 string `gorm:"not null"`
 	BirthDate            *time.Time
 	Photo                string
+	PhotoKey             string
+	PhotoThumbnailKey    string
 	FatherID             *uint
 	Father               *Animal `gorm:"foreignKey:FatherID"`
 	MotherID             *uint
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+const (
+	AnimalAttachmentKindPhoto        = "photo"
+	AnimalAttachmentKindRegistration = "registration"
+	AnimalAttachmentKindGTA          = "gta"
+	AnimalAttachmentKindVetReport    = "vet_report"
+	AnimalAttachmentKindOther        = "other"
+)
+
+var AnimalAttachmentKinds = []string{
+	AnimalAttachmentKindPhoto,
+	AnimalAttachmentKindRegistration,
+	AnimalAttachmentKindGTA,
+	AnimalAttachmentKindVetReport,
+	AnimalAttachmentKindOther,
+}
+
+func IsValidAnimalAttachmentKind(kind string) bool {
+	for _, valid := range AnimalAttachmentKinds {
+		if kind == valid {
+			return true
+		}
+	}
+	return false
+}
+
+type AnimalAttachment struct {
+	ID           uint   `gorm:"primaryKey"`
+	FarmID       uint   `gorm:"not null;index"`
+	Farm         Farm   `gorm:"foreignKey:FarmID"`
+	AnimalID     uint   `gorm:"not null;index"`
+	Animal       Animal `gorm:"foreignKey:AnimalID;constraint:OnDelete:CASCADE"`
+	Kind         string `gorm:"not null"`
+	FileName     string `gorm:"not null"`
+	ContentType  string `gorm:"not null"`
+	Size         int64  `gorm:"not null"`
+	StorageKey   string `gorm:"not null"`
+	ThumbnailKey string
+	Description  string
+	DocumentDate *time.Time
+	UploadedByID *uint
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
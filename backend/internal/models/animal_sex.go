@@ -0,0 +1,6 @@
+package models
+
+const (
+	AnimalSexFemale = 0
+	AnimalSexMale   = 1
+)
@@ -0,0 +1,35 @@
+package models
+
+const (
+	AnimalTypeBovine  = 0
+	AnimalTypeSwine   = 1
+	AnimalTypeOvine   = 2
+	AnimalTypeCaprine = 3
+	AnimalTypeEquine  = 4
+	AnimalTypePoultry = 5
+	AnimalTypeFish    = 6
+	AnimalTypeOther   = 7
+)
+
+func GetAnimalTypeName(animalType int) string {
+	switch animalType {
+	case AnimalTypeBovine:
+		return "Bovino"
+	case AnimalTypeSwine:
+		return "Suíno"
+	case AnimalTypeOvine:
+		return "Ovino"
+	case AnimalTypeCaprine:
+		return "Caprino"
+	case AnimalTypeEquine:
+		return "Equino"
+	case AnimalTypePoultry:
+		return "Ave"
+	case AnimalTypeFish:
+		return "Peixe"
+	case AnimalTypeOther:
+		return "Outro"
+	default:
+		return "Desconhecido"
+	}
+}
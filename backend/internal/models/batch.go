@@ -1,5 +1,10 @@
 package models
 
+import (
+	"sort"
+	"time"
+)
+
 const (
 	Batch1 = 1
 	Batch2 = 2
@@ -21,3 +26,119 @@ func GetBatchByLiters(liters float64) int {
 	}
 	return Batch3
 }
+
+const (
+	DefaultBatchAverageWindowDays = 7
+	MaxBatchAverageWindowDays     = 90
+)
+
+const (
+	BatchMoveReasonMilkCollection = "milk_collection"
+	BatchMoveReasonRebatch        = "rebatch"
+)
+
+type BatchRule struct {
+	ID                uint   `gorm:"primaryKey"`
+	FarmID            uint   `gorm:"not null;index"`
+	Farm              Farm   `gorm:"foreignKey:FarmID"`
+	Batch             int    `gorm:"not null"`
+	Name              string `gorm:"not null"`
+	Priority          int    `gorm:"not null"`
+	MinLiters         *float64
+	MaxLiters         *float64
+	AverageWindowDays int `gorm:"not null;default:7"`
+	Phase             *ReproductionPhase
+	MinDaysInMilk     *int
+	MaxDaysInMilk     *int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type BatchMove struct {
+	ID        uint   `gorm:"primaryKey"`
+	FarmID    uint   `gorm:"not null;index"`
+	AnimalID  uint   `gorm:"not null;index"`
+	Animal    Animal `gorm:"foreignKey:AnimalID"`
+	FromBatch int    `gorm:"not null"`
+	ToBatch   int    `gorm:"not null"`
+	RuleID    *uint
+	RuleName  string
+	Reason    string    `gorm:"not null"`
+	MovedAt   time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+type BatchMetrics struct {
+	DailyLiters  map[time.Time]float64
+	ReferenceDay time.Time
+	Phase        *ReproductionPhase
+	DaysInMilk   *int
+}
+
+func (m BatchMetrics) AverageLiters(windowDays int) *float64 {
+	if windowDays < 1 {
+		windowDays = DefaultBatchAverageWindowDays
+	}
+
+	start := m.ReferenceDay.AddDate(0, 0, -(windowDays - 1))
+	total, days := 0.0, 0
+	for day, liters := range m.DailyLiters {
+		if day.Before(start) || day.After(m.ReferenceDay) {
+			continue
+		}
+		total += liters
+		days++
+	}
+	if days == 0 {
+		return nil
+	}
+
+	average := total / float64(days)
+	return &average
+}
+
+func (r *BatchRule) Matches(metrics BatchMetrics) bool {
+	if r.MinLiters != nil || r.MaxLiters != nil {
+		average := metrics.AverageLiters(r.AverageWindowDays)
+		if average == nil {
+			return false
+		}
+		if r.MinLiters != nil && *average < *r.MinLiters {
+			return false
+		}
+		if r.MaxLiters != nil && *average >= *r.MaxLiters {
+			return false
+		}
+	}
+
+	if r.Phase != nil && (metrics.Phase == nil || *metrics.Phase != *r.Phase) {
+		return false
+	}
+
+	if r.MinDaysInMilk != nil || r.MaxDaysInMilk != nil {
+		if metrics.DaysInMilk == nil {
+			return false
+		}
+		if r.MinDaysInMilk != nil && *metrics.DaysInMilk < *r.MinDaysInMilk {
+			return false
+		}
+		if r.MaxDaysInMilk != nil && *metrics.DaysInMilk >= *r.MaxDaysInMilk {
+			return false
+		}
+	}
+
+	return true
+}
+
+func MatchBatchRule(rules []BatchRule, metrics BatchMetrics) *BatchRule {
+	ordered := make([]BatchRule, len(rules))
+	copy(ordered, rules)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Priority < ordered[j].Priority })
+
+	for i := range ordered {
+		if ordered[i].Matches(metrics) {
+			return &ordered[i]
+		}
+	}
+	return nil
+}
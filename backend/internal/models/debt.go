@@ -4,10 +4,47 @@ import (
 	"time"
 )
 
+const (
+	DebtStatusOpen = "open"
+	DebtStatusPaid = "paid"
+
+	DebtCounterpartyBuyer    = "buyer"
+	DebtCounterpartySupplier = "supplier"
+)
+
 type Debt struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	Person    string    `gorm:"not null" json:"person"`
-	Value     float64   `gorm:"not null" json:"value"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uint          `gorm:"primaryKey" json:"id"`
+	FarmID           uint          `gorm:"index" json:"farm_id"`
+	Person           string        `gorm:"not null" json:"person"`
+	CounterpartyType string        `json:"counterparty_type,omitempty"`
+	SaleID           *uint         `json:"sale_id,omitempty"`
+	Sale             *Sale         `gorm:"foreignKey:SaleID" json:"-"`
+	ExpenseID        *uint         `json:"expense_id,omitempty"`
+	Expense          *Expense      `gorm:"foreignKey:ExpenseID" json:"-"`
+	Value            float64       `gorm:"not null" json:"value"`
+	PaidAmount       float64       `gorm:"not null;default:0" json:"paid_amount"`
+	DueDate          *time.Time    `json:"due_date"`
+	Status           string        `gorm:"not null;default:open;index" json:"status"`
+	PaidAt           *time.Time    `json:"paid_at"`
+	Payments         []DebtPayment `gorm:"foreignKey:DebtID" json:"payments,omitempty"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+func (d Debt) RemainingAmount() float64 {
+	remaining := d.Value - d.PaidAmount
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+type DebtPayment struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	DebtID      uint      `gorm:"not null;index" json:"debt_id"`
+	Amount      float64   `gorm:"not null" json:"amount"`
+	PaymentDate time.Time `gorm:"not null" json:"payment_date"`
+	Notes       string    `json:"notes"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const DefaultGestationDays = 283
+
+type GestationPeriod struct {
+	ID         uint  `gorm:"primaryKey"`
+	FarmID     *uint `gorm:"index"`
+	Farm       *Farm `gorm:"foreignKey:FarmID"`
+	AnimalType int   `gorm:"not null"`
+	Breed      string
+	Days       int `gorm:"not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+var DefaultGestationPeriods = []GestationPeriod{
+	{AnimalType: AnimalTypeBovine, Days: DefaultGestationDays},
+	{AnimalType: AnimalTypeBovine, Breed: "Holandesa", Days: 279},
+	{AnimalType: AnimalTypeBovine, Breed: "Jersey", Days: 279},
+	{AnimalType: AnimalTypeBovine, Breed: "Gir", Days: 290},
+	{AnimalType: AnimalTypeBovine, Breed: "Nelore", Days: 292},
+	{AnimalType: AnimalTypeBovine, Breed: "Murrah", Days: 310},
+	{AnimalType: AnimalTypeBovine, Breed: "Mediterrâneo", Days: 315},
+	{AnimalType: AnimalTypeBovine, Breed: "Jafarabadi", Days: 310},
+	{AnimalType: AnimalTypeSwine, Days: 114},
+	{AnimalType: AnimalTypeOvine, Days: 150},
+	{AnimalType: AnimalTypeCaprine, Days: 150},
+	{AnimalType: AnimalTypeEquine, Days: 340},
+}
+
+func SameBreed(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+func GestationDaysFor(periods []GestationPeriod, animal *Animal) int {
+	days := DefaultGestationDays
+	if animal == nil {
+		return days
+	}
+
+	bestScore := -1
+	for _, period := range periods {
+		if period.AnimalType != animal.AnimalType {
+			continue
+		}
+
+		score := 0
+		if period.Breed != "" {
+			if !SameBreed(period.Breed, animal.Breed) {
+				continue
+			}
+			score += 2
+		}
+		if period.FarmID != nil {
+			score++
+		}
+
+		if score > bestScore {
+			bestScore = score
+			days = period.Days
+		}
+	}
+
+	return days
+}
+
+func ExpectedBirthDateChanges(reproductions []Reproduction, periods []GestationPeriod) map[uint]time.Time {
+	changed := make(map[uint]time.Time)
+	for i := range reproductions {
+		reproduction := &reproductions[i]
+		if reproduction.PregnancyDate == nil {
+			continue
+		}
+
+		expected := reproduction.PregnancyDate.AddDate(0, 0, GestationDaysFor(periods, &reproduction.Animal))
+		if reproduction.ExpectedBirthDate == nil || !reproduction.ExpectedBirthDate.Equal(expected) {
+			changed[reproduction.ID] = expected
+		}
+	}
+	return changed
+}
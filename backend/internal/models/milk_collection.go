@@ -1,15 +1,37 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
+const (
+	MilkShiftMorning   = "morning"
+	MilkShiftAfternoon = "afternoon"
+	MilkShiftNight     = "night"
+)
+
+var MilkShifts = []string{MilkShiftMorning, MilkShiftAfternoon, MilkShiftNight}
+
 type MilkCollection struct {
 	ID        uint      `gorm:"primaryKey"`
 	AnimalID  uint      `gorm:"not null"`
 	Animal    Animal    `gorm:"foreignKey:AnimalID"`
 	Liters    float64   `gorm:"not null"`
 	Date      time.Time `gorm:"not null"`
+	Shift     string    `gorm:"not null;default:morning"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+func ParseMilkShift(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "am", MilkShiftMorning, "manha", "manhã":
+		return MilkShiftMorning, true
+	case "pm", MilkShiftAfternoon, "tarde":
+		return MilkShiftAfternoon, true
+	case MilkShiftNight, "noite":
+		return MilkShiftNight, true
+	}
+	return "", false
+}
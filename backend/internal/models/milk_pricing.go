@@ -0,0 +1,128 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	MilkPriceParameterFat     = "fat"
+	MilkPriceParameterProtein = "protein"
+	MilkPriceParameterSCC     = "scc"
+	MilkPriceParameterCBT     = "cbt"
+)
+
+var MilkPriceParameters = []string{
+	MilkPriceParameterFat,
+	MilkPriceParameterProtein,
+	MilkPriceParameterSCC,
+	MilkPriceParameterCBT,
+}
+
+type MilkPriceTable struct {
+	ID           uint      `gorm:"primaryKey"`
+	FarmID       uint      `gorm:"not null;index"`
+	Farm         Farm      `gorm:"foreignKey:FarmID"`
+	Buyer        string    `gorm:"not null"`
+	Name         string    `gorm:"not null"`
+	BasePrice    float64   `gorm:"not null"`
+	ValidFrom    time.Time `gorm:"not null"`
+	ValidTo      *time.Time
+	VolumeTiers  []MilkPriceVolumeTier  `gorm:"foreignKey:PriceTableID;constraint:OnDelete:CASCADE"`
+	QualityTiers []MilkPriceQualityTier `gorm:"foreignKey:PriceTableID;constraint:OnDelete:CASCADE"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type MilkPriceVolumeTier struct {
+	ID           uint    `gorm:"primaryKey"`
+	PriceTableID uint    `gorm:"not null;index"`
+	MinLiters    float64 `gorm:"not null"`
+	Bonus        float64 `gorm:"not null"`
+}
+
+type MilkPriceQualityTier struct {
+	ID           uint   `gorm:"primaryKey"`
+	PriceTableID uint   `gorm:"not null;index"`
+	Parameter    string `gorm:"not null"`
+	Position     int    `gorm:"not null;default:0"`
+	Min          *float64
+	Max          *float64
+	Adjustment   float64 `gorm:"not null"`
+}
+
+type MilkDelivery struct {
+	ID           uint      `gorm:"primaryKey"`
+	FarmID       uint      `gorm:"not null;index"`
+	Farm         Farm      `gorm:"foreignKey:FarmID"`
+	Buyer        string    `gorm:"not null"`
+	DeliveryDate time.Time `gorm:"not null"`
+	Liters       float64   `gorm:"not null"`
+	Tank         string
+	Notes        string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type MilkPayment struct {
+	ID          uint      `gorm:"primaryKey"`
+	FarmID      uint      `gorm:"not null;index"`
+	Farm        Farm      `gorm:"foreignKey:FarmID"`
+	Buyer       string    `gorm:"not null"`
+	Month       time.Time `gorm:"not null"`
+	Amount      float64   `gorm:"not null"`
+	PaymentDate time.Time `gorm:"not null"`
+	Notes       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (t *MilkPriceTable) ValidOn(date time.Time) bool {
+	if date.Before(t.ValidFrom) {
+		return false
+	}
+	return t.ValidTo == nil || !date.After(*t.ValidTo)
+}
+
+func (t *MilkPriceTable) VolumeBonus(liters float64) float64 {
+	bonus := 0.0
+	best := -1.0
+	for _, tier := range t.VolumeTiers {
+		if liters >= tier.MinLiters && tier.MinLiters > best {
+			best = tier.MinLiters
+			bonus = tier.Bonus
+		}
+	}
+	return bonus
+}
+
+func (t *MilkPriceTable) QualityTier(parameter string, value *float64) *MilkPriceQualityTier {
+	if value == nil {
+		return nil
+	}
+
+	var tiers []*MilkPriceQualityTier
+	for i := range t.QualityTiers {
+		if t.QualityTiers[i].Parameter == parameter {
+			tiers = append(tiers, &t.QualityTiers[i])
+		}
+	}
+	sort.SliceStable(tiers, func(i, j int) bool { return tiers[i].Position < tiers[j].Position })
+
+	for _, tier := range tiers {
+		if tier.Matches(*value) {
+			return tier
+		}
+	}
+	return nil
+}
+
+func (t *MilkPriceQualityTier) Matches(value float64) bool {
+	if t.Min != nil && value < *t.Min {
+		return false
+	}
+	if t.Max != nil && value >= *t.Max {
+		return false
+	}
+	return true
+}
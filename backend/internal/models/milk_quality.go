@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+const (
+	MilkQualitySourceManual = "manual"
+	MilkQualitySourceImport = "import"
+)
+
+const (
+	MastitisSCCThreshold = 200.0
+	TankSCCLimit         = 500.0
+	TankCBTLimit         = 300.0
+)
+
+type MilkQualityTest struct {
+	ID               uint            `gorm:"primaryKey"`
+	FarmID           uint            `gorm:"not null;index"`
+	Farm             Farm            `gorm:"foreignKey:FarmID"`
+	AnimalID         *uint           `gorm:"index"`
+	Animal           *Animal         `gorm:"foreignKey:AnimalID"`
+	MilkCollectionID *uint           `gorm:"index"`
+	MilkCollection   *MilkCollection `gorm:"foreignKey:MilkCollectionID"`
+	Tank             string
+	SampleDate       time.Time `gorm:"not null"`
+	FatPercent       *float64
+	ProteinPercent   *float64
+	SomaticCellCount *float64
+	BacterialCount   *float64
+	Laboratory       string
+	Source           string `gorm:"not null;default:manual"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (t *MilkQualityTest) IsTankTest() bool {
+	return t.AnimalID == nil
+}
+
+func (t *MilkQualityTest) PossibleMastitis() bool {
+	return !t.IsTankTest() && t.SomaticCellCount != nil && *t.SomaticCellCount >= MastitisSCCThreshold
+}
+
+func (t *MilkQualityTest) AboveTankLimits() bool {
+	if !t.IsTankTest() {
+		return false
+	}
+	return (t.SomaticCellCount != nil && *t.SomaticCellCount > TankSCCLimit) ||
+		(t.BacterialCount != nil && *t.BacterialCount > TankCBTLimit)
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+const (
+	NotificationPregnancyCheckDue = "pregnancy_check_due"
+	NotificationDryOffDue         = "dry_off_due"
+	NotificationCalvingOverdue    = "calving_overdue"
+)
+
+type Notification struct {
+	ID        uint    `gorm:"primaryKey"`
+	FarmID    uint    `gorm:"not null;uniqueIndex:idx_notifications_farm_dedup"`
+	Farm      Farm    `gorm:"foreignKey:FarmID"`
+	AnimalID  *uint   `gorm:"index"`
+	Animal    *Animal `gorm:"foreignKey:AnimalID"`
+	Type      string  `gorm:"not null"`
+	Title     string  `gorm:"not null"`
+	Message   string  `gorm:"not null"`
+	DueDate   *time.Time
+	DedupKey  string `gorm:"not null;uniqueIndex:idx_notifications_farm_dedup"`
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
@@ -28,6 +28,27 @@ func (rp ReproductionPhase) String() string {
 	}
 }
 
+var AllowedPhaseTransitions = map[ReproductionPhase][]ReproductionPhase{
+	PhaseVazias:   {PhasePrenhas},
+	PhasePrenhas:  {PhaseLactacao, PhaseSecando, PhaseVazias},
+	PhaseLactacao: {PhaseSecando, PhasePrenhas, PhaseVazias},
+	PhaseSecando:  {PhaseLactacao, PhasePrenhas, PhaseVazias},
+}
+
+func CanTransitionPhase(from, to ReproductionPhase) bool {
+	for _, allowed := range AllowedPhaseTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	InseminationTypeNatural    = "Natural"
+	InseminationTypeArtificial = "Artificial"
+)
+
 type Reproduction struct {
 	ID                     uint              `gorm:"primaryKey"`
 	AnimalID               uint              `gorm:"not null"`
@@ -0,0 +1,228 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	ReproductionEventHeat           = "heat"
+	ReproductionEventInsemination   = "insemination"
+	ReproductionEventPregnancyCheck = "pregnancy_check"
+	ReproductionEventCalving        = "calving"
+	ReproductionEventAbortion       = "abortion"
+	ReproductionEventDryOff         = "dry_off"
+)
+
+var ReproductionEventTypes = []string{
+	ReproductionEventHeat,
+	ReproductionEventInsemination,
+	ReproductionEventPregnancyCheck,
+	ReproductionEventCalving,
+	ReproductionEventAbortion,
+	ReproductionEventDryOff,
+}
+
+func IsValidReproductionEventType(eventType string) bool {
+	for _, valid := range ReproductionEventTypes {
+		if eventType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+type ReproductionEvent struct {
+	ID                     uint      `gorm:"primaryKey"`
+	AnimalID               uint      `gorm:"not null;index"`
+	Animal                 Animal    `gorm:"foreignKey:AnimalID"`
+	Type                   string    `gorm:"not null"`
+	Date                   time.Time `gorm:"not null"`
+	InseminationType       string
+	SireID                 *uint
+	Sire                   *Animal `gorm:"foreignKey:SireID"`
+	PregnancyPositive      *bool
+	VeterinaryConfirmation bool `gorm:"default:false"`
+	CalfID                 *uint
+	Calf                   *Animal `gorm:"foreignKey:CalfID"`
+	Observations           string
+	CreatedAt              time.Time
+}
+
+func SortReproductionEvents(events []ReproductionEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return eventOrder(events[i].ID) < eventOrder(events[j].ID)
+	})
+}
+
+func eventOrder(id uint) uint {
+	if id == 0 {
+		return ^uint(0)
+	}
+	return id
+}
+
+func ApplyReproductionEvents(reproduction *Reproduction, events []ReproductionEvent, gestationDays int) {
+	ordered := make([]ReproductionEvent, len(events))
+	copy(ordered, events)
+	SortReproductionEvents(ordered)
+
+	reproduction.CurrentPhase = PhaseVazias
+	reproduction.InseminationDate = nil
+	reproduction.InseminationType = ""
+	reproduction.VeterinaryConfirmation = false
+	reproduction.ActualBirthDate = nil
+	reproduction.LactationStartDate = nil
+	reproduction.LactationEndDate = nil
+	reproduction.DryPeriodStartDate = nil
+	clearPregnancy(reproduction)
+
+	var cycleInsemination *time.Time
+	for _, event := range ordered {
+		date := event.Date
+
+		switch event.Type {
+		case ReproductionEventInsemination:
+			reproduction.InseminationDate = &date
+			reproduction.InseminationType = event.InseminationType
+			cycleInsemination = &date
+		case ReproductionEventPregnancyCheck:
+			if event.PregnancyPositive != nil && *event.PregnancyPositive {
+				conception := date
+				if cycleInsemination != nil {
+					conception = *cycleInsemination
+				}
+				expectedBirth := conception.AddDate(0, 0, gestationDays)
+				reproduction.CurrentPhase = PhasePrenhas
+				reproduction.PregnancyDate = &conception
+				reproduction.ExpectedBirthDate = &expectedBirth
+				reproduction.VeterinaryConfirmation = event.VeterinaryConfirmation
+			} else {
+				reproduction.CurrentPhase = PhaseVazias
+				clearPregnancy(reproduction)
+				cycleInsemination = nil
+			}
+		case ReproductionEventCalving:
+			reproduction.CurrentPhase = PhaseLactacao
+			reproduction.ActualBirthDate = &date
+			reproduction.LactationStartDate = &date
+			reproduction.LactationEndDate = nil
+			reproduction.DryPeriodStartDate = nil
+			clearPregnancy(reproduction)
+			cycleInsemination = nil
+		case ReproductionEventAbortion:
+			reproduction.CurrentPhase = PhaseVazias
+			clearPregnancy(reproduction)
+			cycleInsemination = nil
+		case ReproductionEventDryOff:
+			reproduction.CurrentPhase = PhaseSecando
+			reproduction.LactationEndDate = &date
+			reproduction.DryPeriodStartDate = &date
+		}
+	}
+}
+
+func clearPregnancy(reproduction *Reproduction) {
+	reproduction.PregnancyDate = nil
+	reproduction.ExpectedBirthDate = nil
+	reproduction.VeterinaryConfirmation = false
+}
+
+func PhaseChangeEvent(animalID uint, phase ReproductionPhase, date time.Time) ReproductionEvent {
+	event := ReproductionEvent{AnimalID: animalID, Date: date}
+	positive := phase == PhasePrenhas
+
+	switch phase {
+	case PhasePrenhas, PhaseVazias:
+		event.Type = ReproductionEventPregnancyCheck
+		event.PregnancyPositive = &positive
+	case PhaseLactacao:
+		event.Type = ReproductionEventCalving
+	case PhaseSecando:
+		event.Type = ReproductionEventDryOff
+	}
+
+	return event
+}
+
+func EventTargetPhase(event ReproductionEvent) (ReproductionPhase, bool) {
+	switch event.Type {
+	case ReproductionEventPregnancyCheck:
+		if event.PregnancyPositive != nil && *event.PregnancyPositive {
+			return PhasePrenhas, true
+		}
+		return PhaseVazias, true
+	case ReproductionEventCalving:
+		return PhaseLactacao, true
+	case ReproductionEventAbortion:
+		return PhaseVazias, true
+	case ReproductionEventDryOff:
+		return PhaseSecando, true
+	}
+	return PhaseVazias, false
+}
+
+func ReproductionEventsFromSnapshot(reproduction *Reproduction, fallbackDate time.Time) []ReproductionEvent {
+	var events []ReproductionEvent
+	positive := true
+
+	if reproduction.InseminationDate != nil {
+		events = append(events, ReproductionEvent{
+			AnimalID:         reproduction.AnimalID,
+			Type:             ReproductionEventInsemination,
+			Date:             *reproduction.InseminationDate,
+			InseminationType: reproduction.InseminationType,
+		})
+	}
+
+	birthDate := reproduction.ActualBirthDate
+	if birthDate == nil {
+		birthDate = reproduction.LactationStartDate
+	}
+	if birthDate != nil {
+		events = append(events, ReproductionEvent{
+			AnimalID: reproduction.AnimalID,
+			Type:     ReproductionEventCalving,
+			Date:     *birthDate,
+		})
+	}
+
+	if reproduction.DryPeriodStartDate != nil {
+		events = append(events, ReproductionEvent{
+			AnimalID: reproduction.AnimalID,
+			Type:     ReproductionEventDryOff,
+			Date:     *reproduction.DryPeriodStartDate,
+		})
+	}
+
+	if reproduction.PregnancyDate != nil && reproduction.CurrentPhase != PhaseVazias {
+		checkDate := *reproduction.PregnancyDate
+		if birthDate != nil && !checkDate.After(*birthDate) {
+			checkDate = fallbackDate
+		}
+		events = append(events, ReproductionEvent{
+			AnimalID:               reproduction.AnimalID,
+			Type:                   ReproductionEventPregnancyCheck,
+			Date:                   checkDate,
+			PregnancyPositive:      &positive,
+			VeterinaryConfirmation: reproduction.VeterinaryConfirmation,
+		})
+	}
+
+	derived := Reproduction{}
+	ApplyReproductionEvents(&derived, events, DefaultGestationDays)
+	if derived.CurrentPhase != reproduction.CurrentPhase {
+		latest := fallbackDate
+		for _, event := range events {
+			if event.Date.After(latest) {
+				latest = event.Date
+			}
+		}
+		events = append(events, PhaseChangeEvent(reproduction.AnimalID, reproduction.CurrentPhase, latest))
+	}
+
+	return events
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+type SemenCatalogEntry struct {
+	ID             uint   `gorm:"primaryKey"`
+	FarmID         uint   `gorm:"not null;index"`
+	Farm           Farm   `gorm:"foreignKey:FarmID"`
+	BullName       string `gorm:"not null"`
+	Code           string `gorm:"not null"`
+	Breed          string `gorm:"not null"`
+	Supplier       string
+	FatherID       *uint
+	Father         *Animal `gorm:"foreignKey:FatherID"`
+	MotherID       *uint
+	Mother         *Animal `gorm:"foreignKey:MotherID"`
+	PTAMilk        *float64
+	DosesAvailable int  `gorm:"not null;default:0"`
+	Active         bool `gorm:"not null;default:true"`
+	Notes          string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
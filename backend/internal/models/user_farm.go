@@ -2,6 +2,23 @@ package models
 
 import "time"
 
+const (
+	FarmRoleOwner        = "owner"
+	FarmRoleManager      = "manager"
+	FarmRoleMilker       = "milker"
+	FarmRoleVeterinarian = "veterinarian"
+	FarmRoleReadOnly     = "read_only"
+)
+
+func IsValidFarmRole(role string) bool {
+	switch role {
+	case FarmRoleOwner, FarmRoleManager, FarmRoleMilker, FarmRoleVeterinarian, FarmRoleReadOnly:
+		return true
+	default:
+		return false
+	}
+}
+
 type UserFarm struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	UserID    uint      `gorm:"not null" json:"user_id"`
@@ -9,6 +26,7 @@ type UserFarm struct {
 	FarmID    uint      `gorm:"not null" json:"farm_id"`
 	Farm      Farm      `gorm:"foreignKey:FarmID" json:"farm"`
 	IsPrimary bool      `gorm:"default:false" json:"is_primary"`
+	Role      string    `gorm:"not null;default:read_only" json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
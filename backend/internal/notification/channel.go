@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"context"
+	"time"
+)
+
+type Item struct {
+	ID        uint       `json:"id"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Message   string     `json:"message"`
+	AnimalID  *uint      `json:"animal_id,omitempty"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type Message struct {
+	FarmID     uint
+	Recipients []string
+	Items      []Item
+}
+
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, message Message) error
+}
@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type SMTPChannel struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPChannel(host, port, username, password, from string) *SMTPChannel {
+	return &SMTPChannel{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (c *SMTPChannel) Name() string {
+	return "email"
+}
+
+func (c *SMTPChannel) Send(ctx context.Context, message Message) error {
+	if len(message.Recipients) == 0 || len(message.Items) == 0 {
+		return nil
+	}
+
+	var auth smtp.Auth
+	if c.username != "" {
+		auth = smtp.PlainAuth("", c.username, c.password, c.host)
+	}
+
+	addr := net.JoinHostPort(c.host, c.port)
+	if err := smtp.SendMail(addr, auth, c.from, message.Recipients, c.buildEmail(message)); err != nil {
+		return fmt.Errorf("error sending email to %d recipients: %w", len(message.Recipients), err)
+	}
+	return nil
+}
+
+func (c *SMTPChannel) buildEmail(message Message) []byte {
+	subject := fmt.Sprintf("FazendaPro - %d novos alertas", len(message.Items))
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", c.from)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(message.Recipients, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", subject)
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+
+	for _, item := range message.Items {
+		fmt.Fprintf(&body, "- %s\r\n  %s\r\n", item.Title, item.Message)
+		if item.DueDate != nil {
+			fmt.Fprintf(&body, "  Data: %s\r\n", item.DueDate.Format("02/01/2006"))
+		}
+	}
+
+	return []byte(body.String())
+}
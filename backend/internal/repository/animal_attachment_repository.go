@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnimalAttachmentRepository interface {
+	Create(ctx context.Context, attachment *models.AnimalAttachment) error
+	GetByID(ctx context.Context, id uint, animalID uint, farmID uint) (*models.AnimalAttachment, error)
+	GetByAnimalID(ctx context.Context, animalID uint, farmID uint, kind string) ([]*models.AnimalAttachment, error)
+	Delete(ctx context.Context, id uint, animalID uint, farmID uint) error
+}
+
+type animalAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAnimalAttachmentRepository(db *gorm.DB) AnimalAttachmentRepository {
+	return &animalAttachmentRepository{db: db}
+}
+
+func (r *animalAttachmentRepository) Create(ctx context.Context, attachment *models.AnimalAttachment) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(attachment).Error; err != nil {
+		return fmt.Errorf(ErrCreatingAnimalAttachment, err)
+	}
+	return nil
+}
+
+func (r *animalAttachmentRepository) GetByID(ctx context.Context, id uint, animalID uint, farmID uint) (*models.AnimalAttachment, error) {
+	var attachment models.AnimalAttachment
+	err := r.db.WithContext(ctx).
+		Where(SQLWhereIDAndFarmID, id, farmID).
+		Where(SQLWhereAnimalID, animalID).
+		First(&attachment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s", ErrAnimalAttachmentNotFoundOrNotBelongsToFarm)
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *animalAttachmentRepository) GetByAnimalID(ctx context.Context, animalID uint, farmID uint, kind string) ([]*models.AnimalAttachment, error) {
+	query := r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID).Where(SQLWhereAnimalID, animalID)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	var attachments []*models.AnimalAttachment
+	if err := query.Order("COALESCE(document_date, created_at) DESC, id DESC").Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf(ErrFindingAnimalAttachments, err)
+	}
+	return attachments, nil
+}
+
+func (r *animalAttachmentRepository) Delete(ctx context.Context, id uint, animalID uint, farmID uint) error {
+	result := r.db.WithContext(ctx).
+		Where(SQLWhereIDAndFarmID, id, farmID).
+		Where(SQLWhereAnimalID, animalID).
+		Delete(&models.AnimalAttachment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrAnimalAttachmentNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AnimalRepository struct {
@@ -22,6 +24,29 @@ func (r *AnimalRepository) Create(animal *models.Animal) error {
 	return nil
 }
 
+func (r *AnimalRepository) CreateWithParents(animals []*models.Animal, fatherRefs, motherRefs map[int]int) error {
+	if len(animals) == 0 {
+		return nil
+	}
+
+	return r.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).CreateInBatches(animals, 500).Error; err != nil {
+			return fmt.Errorf("erro ao criar animais: %w", err)
+		}
+
+		for column, refs := range map[string]map[int]int{"father_id": fatherRefs, "mother_id": motherRefs} {
+			for child, parent := range refs {
+				if err := tx.Model(&models.Animal{}).
+					Where(SQLWhereID, animals[child].ID).
+					Update(column, animals[parent].ID).Error; err != nil {
+					return fmt.Errorf("erro ao vincular pais do animal %d: %w", animals[child].EarTagNumberLocal, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
 func (r *AnimalRepository) FindByID(id uint) (*models.Animal, error) {
 	var animal models.Animal
 	if err := r.db.DB.Preload("Father").Preload("Mother").Where(SQLWhereID, id).First(&animal).Error; err != nil {
@@ -33,6 +58,17 @@ func (r *AnimalRepository) FindByID(id uint) (*models.Animal, error) {
 	return &animal, nil
 }
 
+func (r *AnimalRepository) FindByIDAndFarmID(id, farmID uint) (*models.Animal, error) {
+	var animal models.Animal
+	if err := r.db.DB.Preload("Father").Preload("Mother").Where(SQLWhereIDAndFarmID, id, farmID).First(&animal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar animal: %w", err)
+	}
+	return &animal, nil
+}
+
 func (r *AnimalRepository) FindByFarmID(farmID uint) ([]models.Animal, error) {
 	var animals []models.Animal
 	if err := r.db.DB.Where(SQLWhereFarmID, farmID).Find(&animals).Error; err != nil {
@@ -53,15 +89,64 @@ func (r *AnimalRepository) FindByEarTagNumber(farmID uint, earTagNumber int) (*m
 }
 
 func (r *AnimalRepository) Update(animal *models.Animal) error {
-	if err := r.db.DB.Save(animal).Error; err != nil {
-		return fmt.Errorf("erro ao atualizar animal: %w", err)
+	result := r.db.DB.Model(animal).
+		Where(SQLWhereFarmID, animal.FarmID).
+		Select("*").
+		Omit("created_at", "photo", "photo_key", "photo_thumbnail_key", clause.Associations).
+		Updates(animal)
+	if result.Error != nil {
+		return fmt.Errorf("erro ao atualizar animal: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrAnimalNotFoundOrNotBelongsToFarm)
 	}
 	return nil
 }
 
-func (r *AnimalRepository) Delete(id uint) error {
-	if err := r.db.DB.Delete(&models.Animal{}, id).Error; err != nil {
-		return fmt.Errorf("erro ao deletar animal: %w", err)
+func (r *AnimalRepository) UpdatePhoto(id, farmID uint, photoKey, thumbnailKey string) error {
+	result := r.db.DB.Model(&models.Animal{}).
+		Where(SQLWhereIDAndFarmID, id, farmID).
+		Updates(map[string]interface{}{
+			"photo":               "",
+			"photo_key":           photoKey,
+			"photo_thumbnail_key": thumbnailKey,
+			"updated_at":          time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("erro ao atualizar foto do animal: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *AnimalRepository) ExistsWithPhotoKey(farmID uint, key string) (bool, error) {
+	var count int64
+	err := r.db.DB.Model(&models.Animal{}).
+		Where("farm_id = ? AND (photo_key = ? OR photo_thumbnail_key = ?)", farmID, key, key).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("erro ao buscar foto do animal: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *AnimalRepository) FindWithLegacyPhoto(afterID uint, limit int) ([]models.Animal, error) {
+	var animals []models.Animal
+	if err := r.db.DB.Where(SQLWhereLegacyPhotoAfterID, afterID).Order("id ASC").Limit(limit).Find(&animals).Error; err != nil {
+		return nil, fmt.Errorf("erro ao buscar animais com foto no banco: %w", err)
+	}
+	return animals, nil
+}
+
+func (r *AnimalRepository) Delete(id, farmID uint) error {
+	result := r.db.DB.Where(SQLWhereIDAndFarmID, id, farmID).Delete(&models.Animal{})
+	if result.Error != nil {
+		return fmt.Errorf("erro ao deletar animal: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrAnimalNotFoundOrNotBelongsToFarm)
 	}
 	return nil
 }
@@ -81,3 +166,7 @@ func (r *AnimalRepository) CountBySex(farmID uint, sex int) (int64, error) {
 	}
 	return count, nil
 }
+
+func farmAnimalIDs(db *gorm.DB, farmID uint) *gorm.DB {
+	return db.Model(&models.Animal{}).Select("id").Where(SQLWhereFarmID, farmID)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BatchRepository struct {
+	db *gorm.DB
+}
+
+func NewBatchRepository(db *gorm.DB) *BatchRepository {
+	return &BatchRepository{db: db}
+}
+
+func (r *BatchRepository) FindRulesByFarmID(farmID uint) ([]models.BatchRule, error) {
+	var rules []models.BatchRule
+	err := r.db.
+		Where(SQLWhereFarmID, farmID).
+		Order("priority ASC, id ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFindingBatchRules, err)
+	}
+	return rules, nil
+}
+
+func (r *BatchRepository) ReplaceRules(farmID uint, rules []models.BatchRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.BatchMove{}).
+			Where(SQLWhereFarmID+" AND rule_id IS NOT NULL", farmID).
+			Update("rule_id", nil).Error
+		if err != nil {
+			return fmt.Errorf(ErrSavingBatchRules, err)
+		}
+
+		if err := tx.Where(SQLWhereFarmID, farmID).Delete(&models.BatchRule{}).Error; err != nil {
+			return fmt.Errorf(ErrSavingBatchRules, err)
+		}
+
+		if len(rules) == 0 {
+			return nil
+		}
+
+		for i := range rules {
+			rules[i].ID = 0
+			rules[i].FarmID = farmID
+		}
+		if err := tx.Omit(clause.Associations).Create(&rules).Error; err != nil {
+			return fmt.Errorf(ErrSavingBatchRules, err)
+		}
+		return nil
+	})
+}
+
+func (r *BatchRepository) ApplyMoves(moves []models.BatchMove) error {
+	if len(moves) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, move := range moves {
+			result := tx.Model(&models.Animal{}).
+				Where(SQLWhereIDAndFarmID, move.AnimalID, move.FarmID).
+				Update("current_batch", move.ToBatch)
+			if result.Error != nil {
+				return fmt.Errorf(ErrApplyingBatchMoves, result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%s", ErrAnimalNotFoundOrNotBelongsToFarm)
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&moves).Error; err != nil {
+			return fmt.Errorf(ErrApplyingBatchMoves, err)
+		}
+		return nil
+	})
+}
+
+func (r *BatchRepository) FindMoves(farmID uint, animalID *uint, limit int) ([]models.BatchMove, error) {
+	var moves []models.BatchMove
+	query := r.db.Preload("Animal").Where(SQLWhereFarmID, farmID)
+	if animalID != nil {
+		query = query.Where(SQLWhereAnimalID, *animalID)
+	}
+
+	err := query.Order("moved_at DESC, id DESC").Limit(limit).Find(&moves).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFindingBatchMoves, err)
+	}
+	return moves, nil
+}
@@ -1,34 +1,105 @@
 package repository
 
+var MonthNames = []string{"Jan", "Fev", "Mar", "Abr", "Mai", "Jun", "Jul", "Ago", "Set", "Out", "Nov", "Dez"}
+
+const (
+	SQLWhereID                         = "id = ?"
+	SQLWhereFarmID                     = "farm_id = ?"
+	SQLWhereAnimalID                   = "animal_id = ?"
+	SQLWhereUserID                     = "user_id = ?"
+	SQLWhereCreatedAtRange             = "created_at >= ? AND created_at < ?"
+	SQLOrderSaleDateDESC               = "sale_date DESC"
+	SQLWhereFarmIDAndSex               = "farm_id = ? AND sex = ?"
+	SQLWhereUserIDAndFarmID            = "user_id = ? AND farm_id = ?"
+	SQLWhereFarmIDAndEarTag            = "farm_id = ? AND ear_tag_number_local = ?"
+	SQLWhereAnimalsFarmID              = "animals.farm_id = ?"
+	SQLJoinAnimalsOnWeights            = "JOIN animals ON weights.animal_id = animals.id"
+	SQLOrderWeightDateDESC             = "weights.date DESC"
+	SQLOrderDateDESC                   = "date DESC"
+	SQLWhereStatus                     = "status = ?"
+	SQLOrderDueDateASC                 = "due_date ASC"
+	SQLWhereIDAndFarmID                = "id = ? AND farm_id = ?"
+	SQLWhereLegacyPhotoAfterID         = "id > ? AND photo IS NOT NULL AND photo <> ''"
+	SQLWhereIDInFarm                   = "id = ? AND animal_id IN (?)"
+	SQLJoinAnimalsOnMilkCollections    = "JOIN animals ON milk_collections.animal_id = animals.id"
+	SQLJoinAnimalsOnReproductions      = "JOIN animals ON reproductions.animal_id = animals.id"
+	SQLJoinAnimalsOnReproductionEvents = "JOIN animals ON reproduction_events.animal_id = animals.id"
+	SQLWhereAnimalIDInFarm             = "animal_id = ? AND animal_id IN (?)"
+	SQLWhereFarmIDOrGlobal             = "farm_id = ? OR farm_id IS NULL"
+)
+
+const (
+	ErrFindingUser                               = "error finding user: %w"
+	ErrFindingUserFarms                          = "error finding user farms: %w"
+	ErrFindingUserFarm                           = "error finding user farm: %w"
+	ErrCountingUserFarms                         = "error counting user farms: %w"
+	ErrUpdatingUserFarmRole                      = "error updating user farm role: %w"
+	ErrCreatingPerson                            = "error creating person: %w"
+	ErrCreatingUser                              = "error creating user: %w"
+	ErrCreatingCompany                           = "error creating company: %w"
+	ErrCreatingFarm                              = "error creating farm: %w"
+	ErrUpdatingPersonData                        = "error updating person data: %w"
+	ErrCountingDebts                             = "error counting debts: %w"
+	ErrFindingDebts                              = "error finding debts: %w"
+	ErrCalculatingTotal                          = "error calculating total by person: %w"
+	ErrCountingMales                             = "error counting males: %w"
+	ErrCountingFemales                           = "error counting females: %w"
+	ErrCountingTotalSold                         = "error counting total sold: %w"
+	ErrCalculatingRevenue                        = "error calculating total revenue: %w"
+	ErrSaleNotFoundOrNotBelongsToFarm            = "sale not found or does not belong to farm"
+	ErrWeightNotFoundOrNotBelongsToFarm          = "weight not found or does not belong to farm"
+	ErrFetchingMonthlyExpenses                   = "error fetching monthly expenses data: %w"
+	ErrCalculatingCategoryTotals                 = "error calculating expense totals by category: %w"
+	ErrExpenseNotFoundOrNotBelongsToFarm         = "expense not found or does not belong to farm"
+	ErrFetchingMonthlyRevenue                    = "error fetching monthly revenue: %w"
+	ErrFetchingMonthlyCosts                      = "error fetching monthly costs by category: %w"
+	ErrFetchingMonthlyMilkRevenue                = "error fetching monthly milk revenue: %w"
+	ErrFetchingMonthlyDebts                      = "error fetching monthly debts: %w"
+	ErrCreatingDebtPayment                       = "error creating debt payment: %w"
+	ErrDebtNotFoundOrNotBelongsToFarm            = "debt not found or does not belong to farm"
+	ErrAnimalNotFoundOrNotBelongsToFarm          = "animal not found or does not belong to farm"
+	ErrMilkCollectionNotFoundOrNotBelongsToFarm  = "milk collection not found or does not belong to farm"
+	ErrReproductionNotFoundOrNotBelongsToFarm    = "reproduction not found or does not belong to farm"
+	ErrSemenCatalogNotFoundOrNotBelongsToFarm    = "semen catalog entry not found or does not belong to farm"
+	ErrFindingReproductionEvents                 = "error finding reproduction events: %w"
+	ErrCreatingReproductionEvent                 = "error creating reproduction event: %w"
+	ErrFindingGestationPeriods                   = "error finding gestation periods: %w"
+	ErrGestationPeriodNotFoundOrNotBelongsToFarm = "gestation period not found or does not belong to farm"
+	ErrCreatingNotification                      = "error creating notification: %w"
+	ErrNotificationNotFoundOrNotBelongsToFarm    = "notification not found or does not belong to farm"
+	ErrFindingBatchRules                         = "error finding batch rules: %w"
+	ErrSavingBatchRules                          = "error saving batch rules: %w"
+	ErrApplyingBatchMoves                        = "error applying batch moves: %w"
+	ErrFindingBatchMoves                         = "error finding batch moves: %w"
+	ErrCreatingMilkQualityTest                   = "error creating milk quality test: %w"
+	ErrFindingMilkQualityTests                   = "error finding milk quality tests: %w"
+	ErrMilkQualityTestNotFoundOrNotBelongsToFarm = "milk quality test not found or does not belong to farm"
+	ErrCreatingMilkPriceTable                    = "error creating milk price table: %w"
+	ErrUpdatingMilkPriceTable                    = "error updating milk price table: %w"
+	ErrFindingMilkPriceTables                    = "error finding milk price tables: %w"
+	ErrMilkPriceTableNotFoundOrNotBelongsToFarm  = "milk price table not found or does not belong to farm"
+	ErrCreatingMilkDelivery                      = "error creating milk delivery: %w"
+	ErrFindingMilkDeliveries                     = "error finding milk deliveries: %w"
+	ErrMilkDeliveryNotFoundOrNotBelongsToFarm    = "milk delivery not found or does not belong to farm"
+	ErrCreatingMilkPayment                       = "error creating milk payment: %w"
+	ErrFindingMilkPayments                       = "error finding milk payments: %w"
+	ErrMilkPaymentNotFoundOrNotBelongsToFarm     = "milk payment not found or does not belong to farm"
+	ErrFarmNotFound                              = "farm not found"
+	ErrRestoringFarm                             = "error restoring farm: %w"
+	ErrFetchingExportData                        = "error fetching export data: %w"
+)
+
+const (
+	ErrCreatingAnimalAttachment                   = "error creating animal attachment: %w"
+	ErrFindingAnimalAttachments                   = "error finding animal attachments: %w"
+	ErrAnimalAttachmentNotFoundOrNotBelongsToFarm = "animal attachment not found or does not belong to farm"
+)
+
 const (
-	SQLWhereID              = "id = ?"
-	SQLWhereFarmID          = "farm_id = ?"
-	SQLWhereAnimalID        = "animal_id = ?"
-	SQLWhereUserID          = "user_id = ?"
-	SQLWhereCreatedAtRange  = "created_at >= ? AND created_at < ?"
-	SQLOrderSaleDateDESC    = "sale_date DESC"
-	SQLWhereFarmIDAndSex    = "farm_id = ? AND sex = ?"
-	SQLWhereUserIDAndFarmID = "user_id = ? AND farm_id = ?"
-	SQLWhereFarmIDAndEarTag = "farm_id = ? AND ear_tag_number_local = ?"
-	SQLWhereAnimalsFarmID   = "animals.farm_id = ?"
+	ErrDebtAlreadyPaid           = "dívida já está quitada"
+	ErrDebtPaymentExceedsBalance = "valor do pagamento excede o saldo devedor"
 )
 
 const (
-	ErrFindingUser                    = "error finding user: %w"
-	ErrFindingUserFarms               = "error finding user farms: %w"
-	ErrFindingUserFarm                = "error finding user farm: %w"
-	ErrCountingUserFarms              = "error counting user farms: %w"
-	ErrCreatingPerson                 = "error creating person: %w"
-	ErrCreatingUser                   = "error creating user: %w"
-	ErrCreatingCompany                = "error creating company: %w"
-	ErrCreatingFarm                   = "error creating farm: %w"
-	ErrUpdatingPersonData             = "error updating person data: %w"
-	ErrCountingDebts                  = "error counting debts: %w"
-	ErrFindingDebts                   = "error finding debts: %w"
-	ErrCalculatingTotal               = "error calculating total by person: %w"
-	ErrCountingMales                  = "error counting males: %w"
-	ErrCountingFemales                = "error counting females: %w"
-	ErrCountingTotalSold              = "error counting total sold: %w"
-	ErrCalculatingRevenue             = "error calculating total revenue: %w"
-	ErrSaleNotFoundOrNotBelongsToFarm = "sale not found or does not belong to farm"
+	ErrMilkCollectionAlreadyExists = "animal já possui coleta neste dia e turno"
 )
@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PersonTotal struct {
@@ -13,6 +16,12 @@ type PersonTotal struct {
 	Total  float64 `json:"total"`
 }
 
+type DebtFilter struct {
+	Year   *int
+	Month  *int
+	Status string
+}
+
 type DebtRepository struct {
 	db *gorm.DB
 }
@@ -25,38 +34,39 @@ func (r *DebtRepository) Create(debt *models.Debt) error {
 	return r.db.Create(debt).Error
 }
 
-func (r *DebtRepository) FindByID(id uint) (*models.Debt, error) {
+func (r *DebtRepository) FindByID(id, farmID uint) (*models.Debt, error) {
 	var debt models.Debt
-	err := r.db.First(&debt, id).Error
+	err := r.db.Preload("Payments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("payment_date ASC")
+	}).Where(SQLWhereID+" AND "+SQLWhereFarmID, id, farmID).First(&debt).Error
 	if err != nil {
 		return nil, err
 	}
 	return &debt, nil
 }
 
-func (r *DebtRepository) FindAllWithPagination(page, limit int, year, month *int) ([]models.Debt, int64, error) {
+func (r *DebtRepository) FindAllWithPagination(farmID uint, page, limit int, filter DebtFilter) ([]models.Debt, int64, error) {
 	var debts []models.Debt
 	var total int64
 
-	query := r.db.Model(&models.Debt{})
+	query := r.db.Model(&models.Debt{}).Where(SQLWhereFarmID, farmID)
 
-	if year != nil {
-		startOfYear := time.Date(*year, 1, 1, 0, 0, 0, 0, time.UTC)
-		endOfYear := time.Date(*year+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	if filter.Year != nil {
+		startOfYear := time.Date(*filter.Year, 1, 1, 0, 0, 0, 0, time.UTC)
+		endOfYear := time.Date(*filter.Year+1, 1, 1, 0, 0, 0, 0, time.UTC)
 		query = query.Where(SQLWhereCreatedAtRange, startOfYear, endOfYear)
 	}
 
-	if month != nil && year != nil {
-		startOfMonth := time.Date(*year, time.Month(*month), 1, 0, 0, 0, 0, time.UTC)
-		var endOfMonth time.Time
-		if *month == 12 {
-			endOfMonth = time.Date(*year+1, 1, 1, 0, 0, 0, 0, time.UTC)
-		} else {
-			endOfMonth = time.Date(*year, time.Month(*month+1), 1, 0, 0, 0, 0, time.UTC)
-		}
+	if filter.Month != nil && filter.Year != nil {
+		startOfMonth := time.Date(*filter.Year, time.Month(*filter.Month), 1, 0, 0, 0, 0, time.UTC)
+		endOfMonth := startOfMonth.AddDate(0, 1, 0)
 		query = query.Where(SQLWhereCreatedAtRange, startOfMonth, endOfMonth)
 	}
 
+	if filter.Status != "" {
+		query = query.Where(SQLWhereStatus, filter.Status)
+	}
+
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf(ErrCountingDebts, err)
 	}
@@ -69,24 +79,95 @@ func (r *DebtRepository) FindAllWithPagination(page, limit int, year, month *int
 	return debts, total, nil
 }
 
-func (r *DebtRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Debt{}, id).Error
+func (r *DebtRepository) FindOverdue(farmID uint, reference time.Time) ([]models.Debt, error) {
+	var debts []models.Debt
+	err := r.db.Where(SQLWhereFarmID+" AND "+SQLWhereStatus+" AND due_date < ?", farmID, models.DebtStatusOpen, reference).
+		Order(SQLOrderDueDateASC).
+		Find(&debts).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFindingDebts, err)
+	}
+	return debts, nil
+}
+
+func (r *DebtRepository) FindUpcoming(farmID uint, from, until time.Time) ([]models.Debt, error) {
+	var debts []models.Debt
+	err := r.db.Where(SQLWhereFarmID+" AND "+SQLWhereStatus+" AND due_date >= ? AND due_date <= ?", farmID, models.DebtStatusOpen, from, until).
+		Order(SQLOrderDueDateASC).
+		Find(&debts).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFindingDebts, err)
+	}
+	return debts, nil
 }
 
-func (r *DebtRepository) GetTotalByPersonInMonth(year, month int) ([]PersonTotal, error) {
+func (r *DebtRepository) AddPayment(debtID, farmID uint, payment *models.DebtPayment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var debt models.Debt
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(SQLWhereID+" AND "+SQLWhereFarmID, debtID, farmID).
+			First(&debt).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%s", ErrDebtNotFoundOrNotBelongsToFarm)
+			}
+			return err
+		}
+
+		if debt.Status == models.DebtStatusPaid {
+			return fmt.Errorf("%s", ErrDebtAlreadyPaid)
+		}
+		if payment.Amount-debt.RemainingAmount() > 0.005 {
+			return fmt.Errorf("%s", ErrDebtPaymentExceedsBalance)
+		}
+
+		payment.DebtID = debt.ID
+		if err := tx.Create(payment).Error; err != nil {
+			return fmt.Errorf(ErrCreatingDebtPayment, err)
+		}
+
+		debt.PaidAmount = math.Round((debt.PaidAmount+payment.Amount)*100) / 100
+		if debt.RemainingAmount() < 0.005 {
+			paidAt := payment.PaymentDate
+			debt.Status = models.DebtStatusPaid
+			debt.PaidAt = &paidAt
+		}
+
+		return tx.Model(&debt).Updates(map[string]interface{}{
+			"paid_amount": debt.PaidAmount,
+			"status":      debt.Status,
+			"paid_at":     debt.PaidAt,
+		}).Error
+	})
+}
+
+func (r *DebtRepository) Delete(id, farmID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		farmDebt := tx.Model(&models.Debt{}).Select("id").Where(SQLWhereID+" AND "+SQLWhereFarmID, id, farmID)
+		if err := tx.Where("debt_id IN (?)", farmDebt).Delete(&models.DebtPayment{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where(SQLWhereID+" AND "+SQLWhereFarmID, id, farmID).Delete(&models.Debt{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%s", ErrDebtNotFoundOrNotBelongsToFarm)
+		}
+		return nil
+	})
+}
+
+func (r *DebtRepository) GetTotalByPersonInMonth(farmID uint, year, month int) ([]PersonTotal, error) {
 	var results []PersonTotal
 
 	startOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	var endOfMonth time.Time
-	if month == 12 {
-		endOfMonth = time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC)
-	} else {
-		endOfMonth = time.Date(year, time.Month(month+1), 1, 0, 0, 0, 0, time.UTC)
-	}
+	endOfMonth := startOfMonth.AddDate(0, 1, 0)
 
 	err := r.db.Model(&models.Debt{}).
 		Select("person, SUM(value) as total").
-		Where(SQLWhereCreatedAtRange, startOfMonth, endOfMonth).
+		Where(SQLWhereFarmID+" AND "+SQLWhereCreatedAtRange, farmID, startOfMonth, endOfMonth).
 		Group("person").
 		Order("total DESC").
 		Scan(&results).Error
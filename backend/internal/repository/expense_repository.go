@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ExpenseFilter struct {
+	Category  string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+type MonthlyExpensesData struct {
+	Month string  `json:"month"`
+	Year  int     `json:"year"`
+	Total float64 `json:"total"`
+	Count int64   `json:"count"`
+}
+
+type CategoryTotal struct {
+	Category string  `json:"category"`
+	Total    float64 `json:"total"`
+	Count    int64   `json:"count"`
+}
+
+type ExpenseRepository interface {
+	Create(ctx context.Context, expense *models.Expense) error
+	GetByID(ctx context.Context, id uint, farmID uint) (*models.Expense, error)
+	GetByFarmID(ctx context.Context, farmID uint, filter ExpenseFilter) ([]*models.Expense, error)
+	GetMonthlyExpensesData(ctx context.Context, farmID uint, months int) ([]MonthlyExpensesData, error)
+	GetTotalsByCategory(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]CategoryTotal, error)
+	Update(ctx context.Context, expense *models.Expense) error
+	Delete(ctx context.Context, id uint, farmID uint) error
+}
+
+type expenseRepository struct {
+	db *gorm.DB
+}
+
+func NewExpenseRepository(db *gorm.DB) ExpenseRepository {
+	return &expenseRepository{db: db}
+}
+
+func (r *expenseRepository) Create(ctx context.Context, expense *models.Expense) error {
+	return r.db.WithContext(ctx).Create(expense).Error
+}
+
+func (r *expenseRepository) GetByID(ctx context.Context, id uint, farmID uint) (*models.Expense, error) {
+	var expense models.Expense
+	err := r.db.WithContext(ctx).Where(SQLWhereID+" AND "+SQLWhereFarmID, id, farmID).First(&expense).Error
+	if err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+func (r *expenseRepository) GetByFarmID(ctx context.Context, farmID uint, filter ExpenseFilter) ([]*models.Expense, error) {
+	var expenses []*models.Expense
+	query := r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID)
+
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("date <= ?", *filter.EndDate)
+	}
+
+	err := query.Order(SQLOrderDateDESC).Find(&expenses).Error
+	if err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (r *expenseRepository) GetMonthlyExpensesData(ctx context.Context, farmID uint, months int) ([]MonthlyExpensesData, error) {
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -months+1, 0)
+	endDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, 1, 0).Add(-time.Nanosecond)
+
+	type Result struct {
+		Year  int     `gorm:"column:year"`
+		Month int     `gorm:"column:month"`
+		Total float64 `gorm:"column:total"`
+		Count int64   `gorm:"column:count"`
+	}
+
+	var results []Result
+	err := r.db.WithContext(ctx).
+		Table("expenses").
+		Select("EXTRACT(YEAR FROM date)::int as year, EXTRACT(MONTH FROM date)::int as month, COALESCE(SUM(amount), 0) as total, COUNT(*)::bigint as count").
+		Where(SQLWhereFarmID+" AND date >= ? AND date <= ?", farmID, startDate, endDate).
+		Group("EXTRACT(YEAR FROM date), EXTRACT(MONTH FROM date)").
+		Order("year ASC, month ASC").
+		Find(&results).Error
+
+	if err != nil {
+		return nil, fmt.Errorf(ErrFetchingMonthlyExpenses, err)
+	}
+
+	resultMap := make(map[string]Result)
+	for _, result := range results {
+		key := fmt.Sprintf("%d-%d", result.Year, result.Month)
+		resultMap[key] = result
+	}
+
+	monthlyData := make([]MonthlyExpensesData, 0, months)
+	for i := 0; i < months; i++ {
+		currentDate := startDate.AddDate(0, i, 0)
+		year := currentDate.Year()
+		month := int(currentDate.Month())
+		key := fmt.Sprintf("%d-%d", year, month)
+
+		data := MonthlyExpensesData{
+			Month: MonthNames[month-1],
+			Year:  year,
+		}
+		if result, ok := resultMap[key]; ok {
+			data.Total = result.Total
+			data.Count = result.Count
+		}
+		monthlyData = append(monthlyData, data)
+	}
+
+	return monthlyData, nil
+}
+
+func (r *expenseRepository) GetTotalsByCategory(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]CategoryTotal, error) {
+	var totals []CategoryTotal
+	err := r.db.WithContext(ctx).Model(&models.Expense{}).
+		Select("category, COALESCE(SUM(amount), 0) as total, COUNT(*) as count").
+		Where(SQLWhereFarmID+" AND date BETWEEN ? AND ?", farmID, startDate, endDate).
+		Group("category").
+		Order("total DESC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrCalculatingCategoryTotals, err)
+	}
+	return totals, nil
+}
+
+func (r *expenseRepository) Update(ctx context.Context, expense *models.Expense) error {
+	result := r.db.WithContext(ctx).Model(&models.Expense{}).
+		Where(SQLWhereID+" AND "+SQLWhereFarmID, expense.ID, expense.FarmID).
+		Updates(map[string]interface{}{
+			"description": expense.Description,
+			"amount":      expense.Amount,
+			"category":    expense.Category,
+			"date":        expense.Date,
+			"notes":       expense.Notes,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrExpenseNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *expenseRepository) Delete(ctx context.Context, id uint, farmID uint) error {
+	result := r.db.WithContext(ctx).Where(SQLWhereID+" AND "+SQLWhereFarmID, id, farmID).Delete(&models.Expense{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrExpenseNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
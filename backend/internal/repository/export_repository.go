@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const ExportBatchSize = 500
+
+type ExportRepository interface {
+	GetFarm(ctx context.Context, farmID uint) (*models.Farm, error)
+	EachAnimal(ctx context.Context, farmID uint, fn func([]models.Animal) error) error
+	EachMilkCollection(ctx context.Context, farmID uint, fn func([]models.MilkCollection) error) error
+	EachReproduction(ctx context.Context, farmID uint, fn func([]models.Reproduction) error) error
+	EachReproductionEvent(ctx context.Context, farmID uint, fn func([]models.ReproductionEvent) error) error
+	EachSale(ctx context.Context, farmID uint, fn func([]models.Sale) error) error
+	EachExpense(ctx context.Context, farmID uint, fn func([]models.Expense) error) error
+	EachDebt(ctx context.Context, farmID uint, fn func([]models.Debt) error) error
+	EachDebtPayment(ctx context.Context, farmID uint, fn func([]models.DebtPayment) error) error
+}
+
+type exportRepository struct {
+	db *gorm.DB
+}
+
+func NewExportRepository(db *gorm.DB) ExportRepository {
+	return &exportRepository{db: db}
+}
+
+func (r *exportRepository) GetFarm(ctx context.Context, farmID uint) (*models.Farm, error) {
+	var farm models.Farm
+	err := r.db.WithContext(ctx).Preload("Company").Where(SQLWhereID, farmID).First(&farm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s", ErrFarmNotFound)
+		}
+		return nil, err
+	}
+	return &farm, nil
+}
+
+func (r *exportRepository) EachAnimal(ctx context.Context, farmID uint, fn func([]models.Animal) error) error {
+	var batch []models.Animal
+	return r.each(r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID), &batch, func() error { return fn(batch) })
+}
+
+func (r *exportRepository) EachMilkCollection(ctx context.Context, farmID uint, fn func([]models.MilkCollection) error) error {
+	var batch []models.MilkCollection
+	query := r.db.WithContext(ctx).Where("animal_id IN (?)", farmAnimalIDs(r.db, farmID))
+	return r.each(query, &batch, func() error { return fn(batch) })
+}
+
+func (r *exportRepository) EachReproduction(ctx context.Context, farmID uint, fn func([]models.Reproduction) error) error {
+	var batch []models.Reproduction
+	query := r.db.WithContext(ctx).Where("animal_id IN (?)", farmAnimalIDs(r.db, farmID))
+	return r.each(query, &batch, func() error { return fn(batch) })
+}
+
+func (r *exportRepository) EachReproductionEvent(ctx context.Context, farmID uint, fn func([]models.ReproductionEvent) error) error {
+	var batch []models.ReproductionEvent
+	query := r.db.WithContext(ctx).Where("animal_id IN (?)", farmAnimalIDs(r.db, farmID))
+	return r.each(query, &batch, func() error { return fn(batch) })
+}
+
+func (r *exportRepository) EachSale(ctx context.Context, farmID uint, fn func([]models.Sale) error) error {
+	var batch []models.Sale
+	return r.each(r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID), &batch, func() error { return fn(batch) })
+}
+
+func (r *exportRepository) EachExpense(ctx context.Context, farmID uint, fn func([]models.Expense) error) error {
+	var batch []models.Expense
+	return r.each(r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID), &batch, func() error { return fn(batch) })
+}
+
+func (r *exportRepository) EachDebt(ctx context.Context, farmID uint, fn func([]models.Debt) error) error {
+	var batch []models.Debt
+	return r.each(r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID), &batch, func() error { return fn(batch) })
+}
+
+func (r *exportRepository) EachDebtPayment(ctx context.Context, farmID uint, fn func([]models.DebtPayment) error) error {
+	var batch []models.DebtPayment
+	debtIDs := r.db.Model(&models.Debt{}).Select("id").Where(SQLWhereFarmID, farmID)
+	query := r.db.WithContext(ctx).Where("debt_id IN (?)", debtIDs)
+	return r.each(query, &batch, func() error { return fn(batch) })
+}
+
+func (r *exportRepository) each(query *gorm.DB, dest interface{}, fn func() error) error {
+	err := query.FindInBatches(dest, ExportBatchSize, func(tx *gorm.DB, batch int) error {
+		return fn()
+	}).Error
+	if err != nil {
+		return fmt.Errorf(ErrFetchingExportData, err)
+	}
+	return nil
+}
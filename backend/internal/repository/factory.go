@@ -32,6 +32,38 @@ func (f *RepositoryFactory) CreateReproductionRepository() ReproductionRepositor
 	return NewReproductionRepository(f.db.DB)
 }
 
+func (f *RepositoryFactory) CreateReproductionEventRepository() ReproductionEventRepositoryInterface {
+	return NewReproductionEventRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateGestationPeriodRepository() GestationPeriodRepositoryInterface {
+	return NewGestationPeriodRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateBatchRepository() BatchRepositoryInterface {
+	return NewBatchRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateMilkQualityRepository() MilkQualityRepository {
+	return NewMilkQualityRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateMilkPriceTableRepository() MilkPriceTableRepository {
+	return NewMilkPriceTableRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateMilkDeliveryRepository() MilkDeliveryRepository {
+	return NewMilkDeliveryRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateExportRepository() ExportRepository {
+	return NewExportRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateRestoreRepository() RestoreRepository {
+	return NewRestoreRepository(f.db.DB)
+}
+
 func (f *RepositoryFactory) CreateRefreshTokenRepository() RefreshTokenRepositoryInterface {
 	return NewRefreshTokenRepository(f.db)
 }
@@ -44,10 +76,34 @@ func (f *RepositoryFactory) CreateSaleRepository() SaleRepository {
 	return NewSaleRepository(f.db.DB)
 }
 
+func (f *RepositoryFactory) CreateWeightRepository() WeightRepository {
+	return NewWeightRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateExpenseRepository() ExpenseRepository {
+	return NewExpenseRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateReportRepository() ReportRepository {
+	return NewReportRepository(f.db.DB)
+}
+
 func (f *RepositoryFactory) CreateDebtRepository() DebtRepositoryInterface {
 	return NewDebtRepository(f.db.DB)
 }
 
+func (f *RepositoryFactory) CreateSemenCatalogRepository() SemenCatalogRepository {
+	return NewSemenCatalogRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateNotificationRepository() NotificationRepository {
+	return NewNotificationRepository(f.db.DB)
+}
+
+func (f *RepositoryFactory) CreateAnimalAttachmentRepository() AnimalAttachmentRepository {
+	return NewAnimalAttachmentRepository(f.db.DB)
+}
+
 func (f *RepositoryFactory) GetCache() cache.CacheInterface {
 	return f.cache
 }
@@ -21,6 +21,12 @@ func (r *FarmRepository) FindByID(id uint) (*models.Farm, error) {
 	return &farm, nil
 }
 
+func (r *FarmRepository) FindAllIDs() ([]uint, error) {
+	var ids []uint
+	err := r.db.DB.Model(&models.Farm{}).Order("id ASC").Pluck("id", &ids).Error
+	return ids, err
+}
+
 func (r *FarmRepository) Update(farm *models.Farm) error {
 	return r.db.DB.Model(farm).Update("logo", farm.Logo).Error
 }
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GestationPeriodRepository struct {
+	db *gorm.DB
+}
+
+func NewGestationPeriodRepository(db *gorm.DB) *GestationPeriodRepository {
+	return &GestationPeriodRepository{db: db}
+}
+
+func (r *GestationPeriodRepository) FindByFarmID(farmID uint) ([]models.GestationPeriod, error) {
+	var periods []models.GestationPeriod
+	err := r.db.
+		Where(SQLWhereFarmIDOrGlobal, farmID).
+		Order("animal_type ASC, breed ASC, farm_id ASC NULLS FIRST").
+		Find(&periods).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFindingGestationPeriods, err)
+	}
+	return periods, nil
+}
+
+func (r *GestationPeriodRepository) FindOverrideByID(id, farmID uint) (*models.GestationPeriod, error) {
+	var period models.GestationPeriod
+	err := r.db.Where(SQLWhereIDAndFarmID, id, farmID).First(&period).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (r *GestationPeriodRepository) FindOverride(farmID uint, animalType int, breed string) (*models.GestationPeriod, error) {
+	var period models.GestationPeriod
+	err := r.db.
+		Where("farm_id = ? AND animal_type = ? AND LOWER(breed) = LOWER(?)", farmID, animalType, breed).
+		First(&period).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (r *GestationPeriodRepository) Create(period *models.GestationPeriod) error {
+	return r.db.Omit(clause.Associations).Create(period).Error
+}
+
+func (r *GestationPeriodRepository) Update(period *models.GestationPeriod) error {
+	result := r.db.Model(period).
+		Where(SQLWhereFarmID, period.FarmID).
+		Select("days", "updated_at").
+		Updates(period)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrGestationPeriodNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *GestationPeriodRepository) Delete(id, farmID uint) error {
+	result := r.db.Where(SQLWhereIDAndFarmID, id, farmID).Delete(&models.GestationPeriod{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrGestationPeriodNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
@@ -8,13 +8,18 @@ import (
 
 type AnimalRepositoryInterface interface {
 	Create(animal *models.Animal) error
+	CreateWithParents(animals []*models.Animal, fatherRefs, motherRefs map[int]int) error
 	FindByID(id uint) (*models.Animal, error)
+	FindByIDAndFarmID(id, farmID uint) (*models.Animal, error)
 	FindByFarmID(farmID uint) ([]models.Animal, error)
 	FindByEarTagNumber(farmID uint, earTagNumber int) (*models.Animal, error)
 	FindByFarmIDAndSex(farmID uint, sex int) ([]models.Animal, error)
 	CountBySex(farmID uint, sex int) (int64, error)
 	Update(animal *models.Animal) error
-	Delete(id uint) error
+	UpdatePhoto(id, farmID uint, photoKey, thumbnailKey string) error
+	ExistsWithPhotoKey(farmID uint, key string) (bool, error)
+	FindWithLegacyPhoto(afterID uint, limit int) ([]models.Animal, error)
+	Delete(id, farmID uint) error
 }
 
 type UserRepositoryInterface interface {
@@ -31,38 +36,73 @@ type UserRepositoryInterface interface {
 	GetUserFarmCount(userID uint) (int64, error)
 	GetUserFarmByID(userID, farmID uint) (*models.Farm, error)
 	CreateUserFarm(userFarm *models.UserFarm) error
+	GetUserFarmRole(userID, farmID uint) (string, error)
+	UpdateUserFarmRole(userID, farmID uint, role string) error
+	CountFarmUsersByRole(farmID uint, role string) (int64, error)
+	FindFarmUserEmails(farmID uint, roles []string) ([]string, error)
 }
 
 type MilkCollectionRepositoryInterface interface {
 	Create(milkCollection *models.MilkCollection) error
-	FindByID(id uint) (*models.MilkCollection, error)
+	CreateSession(farmID uint, day time.Time, shift string, build MilkSessionBuilder) ([]models.MilkCollection, error)
+	FindByID(id, farmID uint) (*models.MilkCollection, error)
 	FindByFarmID(farmID uint) ([]models.MilkCollection, error)
 	FindByFarmIDWithDateRange(farmID uint, startDate, endDate *time.Time) ([]models.MilkCollection, error)
-	FindByAnimalID(animalID uint) ([]models.MilkCollection, error)
-	Update(milkCollection *models.MilkCollection) error
-	Delete(id uint) error
+	FindByAnimalID(animalID, farmID uint) ([]models.MilkCollection, error)
+	ExistsForAnimalShift(animalID uint, day time.Time, shift string, excludeID uint) (bool, error)
+	Update(milkCollection *models.MilkCollection, farmID uint) error
+	Delete(id, farmID uint) error
 }
 
 type ReproductionRepositoryInterface interface {
 	Create(reproduction *models.Reproduction) error
-	FindByID(id uint) (*models.Reproduction, error)
-	FindByAnimalID(animalID uint) (*models.Reproduction, error)
+	FindByID(id, farmID uint) (*models.Reproduction, error)
+	FindByAnimalID(animalID, farmID uint) (*models.Reproduction, error)
 	FindByFarmID(farmID uint) ([]models.Reproduction, error)
-	FindByPhase(phase models.ReproductionPhase) ([]models.Reproduction, error)
-	Update(reproduction *models.Reproduction) error
-	Delete(id uint) error
+	FindByPhase(farmID uint, phase models.ReproductionPhase) ([]models.Reproduction, error)
+	Update(reproduction *models.Reproduction, farmID uint) error
+	UpdateExpectedBirthDates(expectedBirthDates map[uint]time.Time) error
+	FindActiveByPhases(phases []models.ReproductionPhase) ([]models.Reproduction, error)
+	Delete(id, farmID uint) error
+}
+
+type ReproductionEventRepositoryInterface interface {
+	FindByAnimalID(animalID, farmID uint) ([]models.ReproductionEvent, error)
+	FindByFarmID(farmID uint) ([]models.ReproductionEvent, error)
+	AppendEvents(events []models.ReproductionEvent, reproduction *models.Reproduction) error
+	DeleteAnimalHistory(animalID, farmID uint) error
+}
+
+type GestationPeriodRepositoryInterface interface {
+	FindByFarmID(farmID uint) ([]models.GestationPeriod, error)
+	FindOverrideByID(id, farmID uint) (*models.GestationPeriod, error)
+	FindOverride(farmID uint, animalType int, breed string) (*models.GestationPeriod, error)
+	Create(period *models.GestationPeriod) error
+	Update(period *models.GestationPeriod) error
+	Delete(id, farmID uint) error
+}
+
+type BatchRepositoryInterface interface {
+	FindRulesByFarmID(farmID uint) ([]models.BatchRule, error)
+	ReplaceRules(farmID uint, rules []models.BatchRule) error
+	ApplyMoves(moves []models.BatchMove) error
+	FindMoves(farmID uint, animalID *uint, limit int) ([]models.BatchMove, error)
 }
 
 type FarmRepositoryInterface interface {
 	FindByID(id uint) (*models.Farm, error)
+	FindAllIDs() ([]uint, error)
 	Update(farm *models.Farm) error
 	LoadCompanyData(farm *models.Farm) error
 }
 
 type DebtRepositoryInterface interface {
 	Create(debt *models.Debt) error
-	FindByID(id uint) (*models.Debt, error)
-	FindAllWithPagination(page, limit int, year, month *int) ([]models.Debt, int64, error)
-	Delete(id uint) error
-	GetTotalByPersonInMonth(year, month int) ([]PersonTotal, error)
+	FindByID(id, farmID uint) (*models.Debt, error)
+	FindAllWithPagination(farmID uint, page, limit int, filter DebtFilter) ([]models.Debt, int64, error)
+	FindOverdue(farmID uint, reference time.Time) ([]models.Debt, error)
+	FindUpcoming(farmID uint, from, until time.Time) ([]models.Debt, error)
+	AddPayment(debtID, farmID uint, payment *models.DebtPayment) error
+	Delete(id, farmID uint) error
+	GetTotalByPersonInMonth(farmID uint, year, month int) ([]PersonTotal, error)
 }
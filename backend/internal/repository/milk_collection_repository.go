@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MilkCollectionRepository struct {
@@ -17,28 +19,68 @@ func NewMilkCollectionRepository(db *gorm.DB) *MilkCollectionRepository {
 }
 
 func (r *MilkCollectionRepository) Create(milkCollection *models.MilkCollection) error {
-	return r.db.Create(milkCollection).Error
+	return r.shiftTakenError(r.db.Create(milkCollection).Error)
 }
 
-func (r *MilkCollectionRepository) FindByID(id uint) (*models.MilkCollection, error) {
-	var milkCollection models.MilkCollection
-	fmt.Printf("DEBUG: Repository FindByID - Looking for ID: %d\n", id)
+func (r *MilkCollectionRepository) shiftTakenError(err error) error {
+	if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok && errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%s", ErrMilkCollectionAlreadyExists)
+	}
+	return err
+}
+
+type MilkSessionBuilder func(animals []models.Animal, collected map[uint]bool) []models.MilkCollection
 
-	err := r.db.Preload("Animal").Where(SQLWhereID, id).First(&milkCollection).Error
+func (r *MilkCollectionRepository) CreateSession(farmID uint, day time.Time, shift string, build MilkSessionBuilder) ([]models.MilkCollection, error) {
+	var milkCollections []models.MilkCollection
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var animals []models.Animal
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(SQLWhereFarmIDAndSex, farmID, models.AnimalSexFemale).
+			Find(&animals).Error; err != nil {
+			return err
+		}
+
+		var collectedIDs []uint
+		if err := tx.Model(&models.MilkCollection{}).
+			Joins(SQLJoinAnimalsOnMilkCollections).
+			Where(SQLWhereAnimalsFarmID+" AND milk_collections.shift = ? AND milk_collections.date >= ? AND milk_collections.date < ?", farmID, shift, day, day.AddDate(0, 0, 1)).
+			Pluck("milk_collections.animal_id", &collectedIDs).Error; err != nil {
+			return err
+		}
+		collected := make(map[uint]bool, len(collectedIDs))
+		for _, id := range collectedIDs {
+			collected[id] = true
+		}
+
+		milkCollections = build(animals, collected)
+		if len(milkCollections) == 0 {
+			return nil
+		}
+		return r.shiftTakenError(tx.Omit(clause.Associations).Create(&milkCollections).Error)
+	})
 	if err != nil {
-		fmt.Printf("DEBUG: Repository FindByID Error: %v\n", err)
 		return nil, err
 	}
+	return milkCollections, nil
+}
 
-	fmt.Printf("DEBUG: Repository FindByID - Found: ID=%d, Liters=%.2f\n",
-		milkCollection.ID, milkCollection.Liters)
+func (r *MilkCollectionRepository) FindByID(id, farmID uint) (*models.MilkCollection, error) {
+	var milkCollection models.MilkCollection
+	err := r.db.Preload("Animal").
+		Joins(SQLJoinAnimalsOnMilkCollections).
+		Where("milk_collections.id = ? AND "+SQLWhereAnimalsFarmID, id, farmID).
+		First(&milkCollection).Error
+	if err != nil {
+		return nil, err
+	}
 	return &milkCollection, nil
 }
 
 func (r *MilkCollectionRepository) FindByFarmID(farmID uint) ([]models.MilkCollection, error) {
 	var milkCollections []models.MilkCollection
 	err := r.db.Preload("Animal", SQLWhereFarmID, farmID).
-		Joins("JOIN animals ON milk_collections.animal_id = animals.id").
+		Joins(SQLJoinAnimalsOnMilkCollections).
 		Where(SQLWhereAnimalsFarmID, farmID).
 		Order("milk_collections.date DESC").
 		Find(&milkCollections).Error
@@ -48,7 +90,7 @@ func (r *MilkCollectionRepository) FindByFarmID(farmID uint) ([]models.MilkColle
 func (r *MilkCollectionRepository) FindByFarmIDWithDateRange(farmID uint, startDate, endDate *time.Time) ([]models.MilkCollection, error) {
 	var milkCollections []models.MilkCollection
 	query := r.db.Preload("Animal", SQLWhereFarmID, farmID).
-		Joins("JOIN animals ON milk_collections.animal_id = animals.id").
+		Joins(SQLJoinAnimalsOnMilkCollections).
 		Where(SQLWhereAnimalsFarmID, farmID)
 
 	if startDate != nil {
@@ -62,44 +104,50 @@ func (r *MilkCollectionRepository) FindByFarmIDWithDateRange(farmID uint, startD
 	return milkCollections, err
 }
 
-func (r *MilkCollectionRepository) FindByAnimalID(animalID uint) ([]models.MilkCollection, error) {
+func (r *MilkCollectionRepository) FindByAnimalID(animalID, farmID uint) ([]models.MilkCollection, error) {
 	var milkCollections []models.MilkCollection
 	err := r.db.Preload("Animal").
-		Where(SQLWhereAnimalID, animalID).
-		Order("date DESC").
+		Joins(SQLJoinAnimalsOnMilkCollections).
+		Where("milk_collections.animal_id = ? AND "+SQLWhereAnimalsFarmID, animalID, farmID).
+		Order("milk_collections.date DESC").
 		Find(&milkCollections).Error
 	return milkCollections, err
 }
 
-func (r *MilkCollectionRepository) Update(milkCollection *models.MilkCollection) error {
-	fmt.Printf("DEBUG: Repository Update - ID: %d, AnimalID: %d, Liters: %.2f\n",
-		milkCollection.ID, milkCollection.AnimalID, milkCollection.Liters)
-
-	var existingMilkCollection models.MilkCollection
-	err := r.db.Where(SQLWhereID, milkCollection.ID).First(&existingMilkCollection).Error
-	if err != nil {
-		fmt.Printf("DEBUG: Record not found with ID %d: %v\n", milkCollection.ID, err)
-		return err
-	}
+func (r *MilkCollectionRepository) ExistsForAnimalShift(animalID uint, day time.Time, shift string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.MilkCollection{}).
+		Where("animal_id = ? AND shift = ? AND date >= ? AND date < ? AND id <> ?", animalID, shift, day, day.AddDate(0, 0, 1), excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
 
-	fmt.Printf("DEBUG: Found existing record - ID: %d, Liters: %.2f\n",
-		existingMilkCollection.ID, existingMilkCollection.Liters)
-
-	result := r.db.Model(&models.MilkCollection{}).Where(SQLWhereID, milkCollection.ID).Updates(map[string]interface{}{
-		"animal_id": milkCollection.AnimalID,
-		"liters":    milkCollection.Liters,
-		"date":      milkCollection.Date,
-	})
+func (r *MilkCollectionRepository) Update(milkCollection *models.MilkCollection, farmID uint) error {
+	result := r.db.Model(&models.MilkCollection{}).
+		Where(SQLWhereIDInFarm, milkCollection.ID, farmAnimalIDs(r.db, farmID)).
+		Updates(map[string]interface{}{
+			"animal_id": milkCollection.AnimalID,
+			"liters":    milkCollection.Liters,
+			"date":      milkCollection.Date,
+			"shift":     milkCollection.Shift,
+		})
 
 	if result.Error != nil {
-		fmt.Printf("DEBUG: Repository Update Error: %v\n", result.Error)
-		return result.Error
+		return r.shiftTakenError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrMilkCollectionNotFoundOrNotBelongsToFarm)
 	}
-
-	fmt.Printf("DEBUG: Repository Update - Rows affected: %d\n", result.RowsAffected)
 	return nil
 }
 
-func (r *MilkCollectionRepository) Delete(id uint) error {
-	return r.db.Delete(&models.MilkCollection{}, id).Error
+func (r *MilkCollectionRepository) Delete(id, farmID uint) error {
+	result := r.db.Where(SQLWhereIDInFarm, id, farmAnimalIDs(r.db, farmID)).Delete(&models.MilkCollection{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrMilkCollectionNotFoundOrNotBelongsToFarm)
+	}
+	return nil
 }
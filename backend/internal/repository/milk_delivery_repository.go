@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MilkDeliveryFilter struct {
+	Buyer     string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+type MilkDeliveryRepository interface {
+	Create(ctx context.Context, delivery *models.MilkDelivery) error
+	GetByID(ctx context.Context, id uint, farmID uint) (*models.MilkDelivery, error)
+	GetByFarmID(ctx context.Context, farmID uint, filter MilkDeliveryFilter) ([]*models.MilkDelivery, error)
+	Update(ctx context.Context, delivery *models.MilkDelivery) error
+	Delete(ctx context.Context, id uint, farmID uint) error
+	CreatePayment(ctx context.Context, payment *models.MilkPayment) error
+	GetPayments(ctx context.Context, farmID uint, buyer string, month *time.Time) ([]*models.MilkPayment, error)
+	DeletePayment(ctx context.Context, id uint, farmID uint) error
+}
+
+type milkDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewMilkDeliveryRepository(db *gorm.DB) MilkDeliveryRepository {
+	return &milkDeliveryRepository{db: db}
+}
+
+func (r *milkDeliveryRepository) Create(ctx context.Context, delivery *models.MilkDelivery) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(delivery).Error; err != nil {
+		return fmt.Errorf(ErrCreatingMilkDelivery, err)
+	}
+	return nil
+}
+
+func (r *milkDeliveryRepository) GetByID(ctx context.Context, id uint, farmID uint) (*models.MilkDelivery, error) {
+	var delivery models.MilkDelivery
+	err := r.db.WithContext(ctx).Where(SQLWhereIDAndFarmID, id, farmID).First(&delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s", ErrMilkDeliveryNotFoundOrNotBelongsToFarm)
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *milkDeliveryRepository) GetByFarmID(ctx context.Context, farmID uint, filter MilkDeliveryFilter) ([]*models.MilkDelivery, error) {
+	query := r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID)
+	if filter.Buyer != "" {
+		query = query.Where("LOWER(buyer) = LOWER(?)", filter.Buyer)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("delivery_date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("delivery_date <= ?", *filter.EndDate)
+	}
+
+	var deliveries []*models.MilkDelivery
+	if err := query.Order("delivery_date DESC, id DESC").Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf(ErrFindingMilkDeliveries, err)
+	}
+	return deliveries, nil
+}
+
+func (r *milkDeliveryRepository) Update(ctx context.Context, delivery *models.MilkDelivery) error {
+	result := r.db.WithContext(ctx).Model(&models.MilkDelivery{}).
+		Where(SQLWhereIDAndFarmID, delivery.ID, delivery.FarmID).
+		Updates(map[string]interface{}{
+			"buyer":         delivery.Buyer,
+			"delivery_date": delivery.DeliveryDate,
+			"liters":        delivery.Liters,
+			"tank":          delivery.Tank,
+			"notes":         delivery.Notes,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrMilkDeliveryNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *milkDeliveryRepository) Delete(ctx context.Context, id uint, farmID uint) error {
+	result := r.db.WithContext(ctx).Where(SQLWhereIDAndFarmID, id, farmID).Delete(&models.MilkDelivery{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrMilkDeliveryNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *milkDeliveryRepository) CreatePayment(ctx context.Context, payment *models.MilkPayment) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(payment).Error; err != nil {
+		return fmt.Errorf(ErrCreatingMilkPayment, err)
+	}
+	return nil
+}
+
+func (r *milkDeliveryRepository) GetPayments(ctx context.Context, farmID uint, buyer string, month *time.Time) ([]*models.MilkPayment, error) {
+	query := r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID)
+	if buyer != "" {
+		query = query.Where("LOWER(buyer) = LOWER(?)", buyer)
+	}
+	if month != nil {
+		query = query.Where("month = ?", *month)
+	}
+
+	var payments []*models.MilkPayment
+	if err := query.Order("month DESC, payment_date DESC, id DESC").Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf(ErrFindingMilkPayments, err)
+	}
+	return payments, nil
+}
+
+func (r *milkDeliveryRepository) DeletePayment(ctx context.Context, id uint, farmID uint) error {
+	result := r.db.WithContext(ctx).Where(SQLWhereIDAndFarmID, id, farmID).Delete(&models.MilkPayment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrMilkPaymentNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
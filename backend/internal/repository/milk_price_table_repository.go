@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MilkPriceTableRepository interface {
+	Create(ctx context.Context, table *models.MilkPriceTable) error
+	GetByID(ctx context.Context, id uint, farmID uint) (*models.MilkPriceTable, error)
+	GetByFarmID(ctx context.Context, farmID uint, buyer string) ([]*models.MilkPriceTable, error)
+	FindValid(ctx context.Context, farmID uint, buyer string, date time.Time) (*models.MilkPriceTable, error)
+	Update(ctx context.Context, table *models.MilkPriceTable) error
+	Delete(ctx context.Context, id uint, farmID uint) error
+}
+
+type milkPriceTableRepository struct {
+	db *gorm.DB
+}
+
+func NewMilkPriceTableRepository(db *gorm.DB) MilkPriceTableRepository {
+	return &milkPriceTableRepository{db: db}
+}
+
+func preloadMilkPriceTiers(db *gorm.DB) *gorm.DB {
+	return db.Preload("VolumeTiers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("min_liters ASC")
+	}).Preload("QualityTiers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("parameter ASC, position ASC")
+	})
+}
+
+func (r *milkPriceTableRepository) Create(ctx context.Context, table *models.MilkPriceTable) error {
+	if err := r.db.WithContext(ctx).Omit("Farm").Create(table).Error; err != nil {
+		return fmt.Errorf(ErrCreatingMilkPriceTable, err)
+	}
+	return nil
+}
+
+func (r *milkPriceTableRepository) GetByID(ctx context.Context, id uint, farmID uint) (*models.MilkPriceTable, error) {
+	var table models.MilkPriceTable
+	err := preloadMilkPriceTiers(r.db.WithContext(ctx)).
+		Where(SQLWhereIDAndFarmID, id, farmID).
+		First(&table).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s", ErrMilkPriceTableNotFoundOrNotBelongsToFarm)
+		}
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *milkPriceTableRepository) GetByFarmID(ctx context.Context, farmID uint, buyer string) ([]*models.MilkPriceTable, error) {
+	query := preloadMilkPriceTiers(r.db.WithContext(ctx)).Where(SQLWhereFarmID, farmID)
+	if buyer != "" {
+		query = query.Where("LOWER(buyer) = LOWER(?)", buyer)
+	}
+
+	var tables []*models.MilkPriceTable
+	if err := query.Order("buyer ASC, valid_from DESC").Find(&tables).Error; err != nil {
+		return nil, fmt.Errorf(ErrFindingMilkPriceTables, err)
+	}
+	return tables, nil
+}
+
+func (r *milkPriceTableRepository) FindValid(ctx context.Context, farmID uint, buyer string, date time.Time) (*models.MilkPriceTable, error) {
+	var table models.MilkPriceTable
+	err := preloadMilkPriceTiers(r.db.WithContext(ctx)).
+		Where(SQLWhereFarmID, farmID).
+		Where("LOWER(buyer) = LOWER(?)", buyer).
+		Where("valid_from <= ? AND (valid_to IS NULL OR valid_to >= ?)", date, date).
+		Order("valid_from DESC, id DESC").
+		First(&table).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(ErrFindingMilkPriceTables, err)
+	}
+	return &table, nil
+}
+
+func (r *milkPriceTableRepository) Update(ctx context.Context, table *models.MilkPriceTable) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.MilkPriceTable{}).
+			Where(SQLWhereIDAndFarmID, table.ID, table.FarmID).
+			Updates(map[string]interface{}{
+				"buyer":      table.Buyer,
+				"name":       table.Name,
+				"base_price": table.BasePrice,
+				"valid_from": table.ValidFrom,
+				"valid_to":   table.ValidTo,
+			})
+		if result.Error != nil {
+			return fmt.Errorf(ErrUpdatingMilkPriceTable, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%s", ErrMilkPriceTableNotFoundOrNotBelongsToFarm)
+		}
+
+		if err := deleteMilkPriceTiers(tx, table.ID); err != nil {
+			return err
+		}
+
+		for i := range table.VolumeTiers {
+			table.VolumeTiers[i].ID = 0
+			table.VolumeTiers[i].PriceTableID = table.ID
+		}
+		for i := range table.QualityTiers {
+			table.QualityTiers[i].ID = 0
+			table.QualityTiers[i].PriceTableID = table.ID
+		}
+		if len(table.VolumeTiers) > 0 {
+			if err := tx.Create(&table.VolumeTiers).Error; err != nil {
+				return fmt.Errorf(ErrUpdatingMilkPriceTable, err)
+			}
+		}
+		if len(table.QualityTiers) > 0 {
+			if err := tx.Create(&table.QualityTiers).Error; err != nil {
+				return fmt.Errorf(ErrUpdatingMilkPriceTable, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *milkPriceTableRepository) Delete(ctx context.Context, id uint, farmID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.MilkPriceTable{}).Where(SQLWhereIDAndFarmID, id, farmID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("%s", ErrMilkPriceTableNotFoundOrNotBelongsToFarm)
+		}
+
+		if err := deleteMilkPriceTiers(tx, id); err != nil {
+			return err
+		}
+		return tx.Where(SQLWhereIDAndFarmID, id, farmID).Delete(&models.MilkPriceTable{}).Error
+	})
+}
+
+func deleteMilkPriceTiers(tx *gorm.DB, tableID uint) error {
+	if err := tx.Where("price_table_id = ?", tableID).Delete(&models.MilkPriceVolumeTier{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("price_table_id = ?", tableID).Delete(&models.MilkPriceQualityTier{}).Error
+}
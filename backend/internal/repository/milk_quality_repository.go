@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MilkQualityFilter struct {
+	AnimalID   *uint
+	TankOnly   bool
+	AnimalOnly bool
+	Tank       string
+	StartDate  *time.Time
+	EndDate    *time.Time
+}
+
+type MilkQualityRepository interface {
+	Create(ctx context.Context, tests []*models.MilkQualityTest) error
+	GetByID(ctx context.Context, id uint, farmID uint) (*models.MilkQualityTest, error)
+	GetByFarmID(ctx context.Context, farmID uint, filter MilkQualityFilter) ([]*models.MilkQualityTest, error)
+	Update(ctx context.Context, test *models.MilkQualityTest) error
+	Delete(ctx context.Context, id uint, farmID uint) error
+}
+
+type milkQualityRepository struct {
+	db *gorm.DB
+}
+
+func NewMilkQualityRepository(db *gorm.DB) MilkQualityRepository {
+	return &milkQualityRepository{db: db}
+}
+
+func (r *milkQualityRepository) Create(ctx context.Context, tests []*models.MilkQualityTest) error {
+	if len(tests) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(tests).Error; err != nil {
+		return fmt.Errorf(ErrCreatingMilkQualityTest, err)
+	}
+	return nil
+}
+
+func (r *milkQualityRepository) GetByID(ctx context.Context, id uint, farmID uint) (*models.MilkQualityTest, error) {
+	var test models.MilkQualityTest
+	err := r.db.WithContext(ctx).Preload("Animal").
+		Where(SQLWhereIDAndFarmID, id, farmID).
+		First(&test).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%s", ErrMilkQualityTestNotFoundOrNotBelongsToFarm)
+		}
+		return nil, err
+	}
+	return &test, nil
+}
+
+func (r *milkQualityRepository) GetByFarmID(ctx context.Context, farmID uint, filter MilkQualityFilter) ([]*models.MilkQualityTest, error) {
+	query := r.db.WithContext(ctx).Preload("Animal").Where(SQLWhereFarmID, farmID)
+
+	if filter.AnimalID != nil {
+		query = query.Where(SQLWhereAnimalID, *filter.AnimalID)
+	}
+	if filter.TankOnly {
+		query = query.Where("animal_id IS NULL")
+	}
+	if filter.AnimalOnly {
+		query = query.Where("animal_id IS NOT NULL")
+	}
+	if filter.Tank != "" {
+		query = query.Where("LOWER(tank) = LOWER(?)", filter.Tank)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("sample_date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("sample_date <= ?", *filter.EndDate)
+	}
+
+	var tests []*models.MilkQualityTest
+	if err := query.Order("sample_date DESC, id DESC").Find(&tests).Error; err != nil {
+		return nil, fmt.Errorf(ErrFindingMilkQualityTests, err)
+	}
+	return tests, nil
+}
+
+func (r *milkQualityRepository) Update(ctx context.Context, test *models.MilkQualityTest) error {
+	result := r.db.WithContext(ctx).Model(&models.MilkQualityTest{}).
+		Where(SQLWhereIDAndFarmID, test.ID, test.FarmID).
+		Updates(map[string]interface{}{
+			"animal_id":          test.AnimalID,
+			"milk_collection_id": test.MilkCollectionID,
+			"tank":               test.Tank,
+			"sample_date":        test.SampleDate,
+			"fat_percent":        test.FatPercent,
+			"protein_percent":    test.ProteinPercent,
+			"somatic_cell_count": test.SomaticCellCount,
+			"bacterial_count":    test.BacterialCount,
+			"laboratory":         test.Laboratory,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrMilkQualityTestNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *milkQualityRepository) Delete(ctx context.Context, id uint, farmID uint) error {
+	result := r.db.WithContext(ctx).
+		Where(SQLWhereIDAndFarmID, id, farmID).
+		Delete(&models.MilkQualityTest{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrMilkQualityTestNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
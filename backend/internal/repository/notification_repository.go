@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	CreateIfNotExists(ctx context.Context, notification *models.Notification) (bool, error)
+	GetByFarmID(ctx context.Context, farmID uint, unreadOnly bool, limit int) ([]*models.Notification, error)
+	CountUnread(ctx context.Context, farmID uint) (int64, error)
+	MarkAsRead(ctx context.Context, id uint, farmID uint, readAt time.Time) error
+	MarkAllAsRead(ctx context.Context, farmID uint, readAt time.Time) (int64, error)
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateIfNotExists(ctx context.Context, notification *models.Notification) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(notification)
+	if result.Error != nil {
+		return false, fmt.Errorf(ErrCreatingNotification, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *notificationRepository) GetByFarmID(ctx context.Context, farmID uint, unreadOnly bool, limit int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	query := r.db.WithContext(ctx).Preload("Animal").Where(SQLWhereFarmID, farmID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, farmID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where(SQLWhereFarmID+" AND read_at IS NULL", farmID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *notificationRepository) MarkAsRead(ctx context.Context, id uint, farmID uint, readAt time.Time) error {
+	var notification models.Notification
+	if err := r.db.WithContext(ctx).Where(SQLWhereIDAndFarmID, id, farmID).First(&notification).Error; err != nil {
+		return fmt.Errorf("%s", ErrNotificationNotFoundOrNotBelongsToFarm)
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&notification).Update("read_at", readAt).Error
+}
+
+func (r *notificationRepository) MarkAllAsRead(ctx context.Context, farmID uint, readAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where(SQLWhereFarmID+" AND read_at IS NULL", farmID).
+		Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MonthlyAmount struct {
+	Year  int     `gorm:"column:year"`
+	Month int     `gorm:"column:month"`
+	Total float64 `gorm:"column:total"`
+}
+
+type MonthlyCategoryAmount struct {
+	Year     int     `gorm:"column:year"`
+	Month    int     `gorm:"column:month"`
+	Category string  `gorm:"column:category"`
+	Total    float64 `gorm:"column:total"`
+}
+
+type ReportRepository interface {
+	GetMonthlyRevenue(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]MonthlyAmount, error)
+	GetMonthlyMilkRevenue(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]MonthlyAmount, error)
+	GetMonthlyCostsByCategory(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]MonthlyCategoryAmount, error)
+	GetMonthlyDebts(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]MonthlyAmount, error)
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) GetMonthlyRevenue(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]MonthlyAmount, error) {
+	var results []MonthlyAmount
+	err := r.db.WithContext(ctx).
+		Table("sales").
+		Select("EXTRACT(YEAR FROM sale_date)::int as year, EXTRACT(MONTH FROM sale_date)::int as month, COALESCE(SUM(price), 0) as total").
+		Where(SQLWhereFarmID+" AND sale_date >= ? AND sale_date < ?", farmID, startDate, endDate).
+		Group("EXTRACT(YEAR FROM sale_date), EXTRACT(MONTH FROM sale_date)").
+		Order("year ASC, month ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFetchingMonthlyRevenue, err)
+	}
+	return results, nil
+}
+
+func (r *reportRepository) GetMonthlyMilkRevenue(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]MonthlyAmount, error) {
+	var results []MonthlyAmount
+	err := r.db.WithContext(ctx).
+		Table("milk_payments").
+		Select("EXTRACT(YEAR FROM month)::int as year, EXTRACT(MONTH FROM month)::int as month, COALESCE(SUM(amount), 0) as total").
+		Where(SQLWhereFarmID+" AND month >= ? AND month < ?", farmID, startDate, endDate).
+		Group("EXTRACT(YEAR FROM month), EXTRACT(MONTH FROM month)").
+		Order("year ASC, month ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFetchingMonthlyMilkRevenue, err)
+	}
+	return results, nil
+}
+
+func (r *reportRepository) GetMonthlyCostsByCategory(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]MonthlyCategoryAmount, error) {
+	var results []MonthlyCategoryAmount
+	err := r.db.WithContext(ctx).
+		Table("expenses").
+		Select("EXTRACT(YEAR FROM date)::int as year, EXTRACT(MONTH FROM date)::int as month, category, COALESCE(SUM(amount), 0) as total").
+		Where(SQLWhereFarmID+" AND date >= ? AND date < ?", farmID, startDate, endDate).
+		Group("EXTRACT(YEAR FROM date), EXTRACT(MONTH FROM date), category").
+		Order("year ASC, month ASC, category ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFetchingMonthlyCosts, err)
+	}
+	return results, nil
+}
+
+func (r *reportRepository) GetMonthlyDebts(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]MonthlyAmount, error) {
+	var results []MonthlyAmount
+	err := r.db.WithContext(ctx).
+		Table("debts").
+		Select("EXTRACT(YEAR FROM created_at)::int as year, EXTRACT(MONTH FROM created_at)::int as month, COALESCE(SUM(value), 0) as total").
+		Where(SQLWhereFarmID+" AND "+SQLWhereCreatedAtRange, farmID, startDate, endDate).
+		Group("EXTRACT(YEAR FROM created_at), EXTRACT(MONTH FROM created_at)").
+		Order("year ASC, month ASC").
+		Find(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFetchingMonthlyDebts, err)
+	}
+	return results, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReproductionEventRepository struct {
+	db *gorm.DB
+}
+
+func NewReproductionEventRepository(db *gorm.DB) *ReproductionEventRepository {
+	return &ReproductionEventRepository{db: db}
+}
+
+func (r *ReproductionEventRepository) FindByAnimalID(animalID, farmID uint) ([]models.ReproductionEvent, error) {
+	var events []models.ReproductionEvent
+	err := r.db.
+		Joins(SQLJoinAnimalsOnReproductionEvents).
+		Where("reproduction_events.animal_id = ? AND "+SQLWhereAnimalsFarmID, animalID, farmID).
+		Order("reproduction_events.date ASC, reproduction_events.id ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFindingReproductionEvents, err)
+	}
+	return events, nil
+}
+
+func (r *ReproductionEventRepository) FindByFarmID(farmID uint) ([]models.ReproductionEvent, error) {
+	var events []models.ReproductionEvent
+	err := r.db.
+		Joins(SQLJoinAnimalsOnReproductionEvents).
+		Where(SQLWhereAnimalsFarmID, farmID).
+		Order("reproduction_events.animal_id ASC, reproduction_events.date ASC, reproduction_events.id ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFindingReproductionEvents, err)
+	}
+	return events, nil
+}
+
+func (r *ReproductionEventRepository) AppendEvents(events []models.ReproductionEvent, reproduction *models.Reproduction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(events) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&events).Error; err != nil {
+				return fmt.Errorf(ErrCreatingReproductionEvent, err)
+			}
+		}
+
+		if reproduction.ID == 0 {
+			return tx.Omit(clause.Associations).Create(reproduction).Error
+		}
+
+		result := tx.Model(reproduction).
+			Select("*").
+			Omit("created_at", clause.Associations).
+			Updates(reproduction)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%s", ErrReproductionNotFoundOrNotBelongsToFarm)
+		}
+		return nil
+	})
+}
+
+func (r *ReproductionEventRepository) DeleteAnimalHistory(animalID, farmID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		farmAnimals := farmAnimalIDs(tx, farmID)
+
+		if err := tx.Where(SQLWhereAnimalIDInFarm, animalID, farmAnimals).Delete(&models.ReproductionEvent{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where(SQLWhereAnimalIDInFarm, animalID, farmAnimals).Delete(&models.Reproduction{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%s", ErrReproductionNotFoundOrNotBelongsToFarm)
+		}
+		return nil
+	})
+}
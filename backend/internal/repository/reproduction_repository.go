@@ -2,9 +2,12 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReproductionRepository struct {
@@ -19,9 +22,12 @@ func (r *ReproductionRepository) Create(reproduction *models.Reproduction) error
 	return r.db.Create(reproduction).Error
 }
 
-func (r *ReproductionRepository) FindByID(id uint) (*models.Reproduction, error) {
+func (r *ReproductionRepository) FindByID(id, farmID uint) (*models.Reproduction, error) {
 	var reproduction models.Reproduction
-	err := r.db.Preload("Animal").First(&reproduction, id).Error
+	err := r.db.Preload("Animal").
+		Joins(SQLJoinAnimalsOnReproductions).
+		Where("reproductions.id = ? AND "+SQLWhereAnimalsFarmID, id, farmID).
+		First(&reproduction).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -31,9 +37,12 @@ func (r *ReproductionRepository) FindByID(id uint) (*models.Reproduction, error)
 	return &reproduction, nil
 }
 
-func (r *ReproductionRepository) FindByAnimalID(animalID uint) (*models.Reproduction, error) {
+func (r *ReproductionRepository) FindByAnimalID(animalID, farmID uint) (*models.Reproduction, error) {
 	var reproduction models.Reproduction
-	err := r.db.Preload("Animal").Where(SQLWhereAnimalID, animalID).First(&reproduction).Error
+	err := r.db.Preload("Animal").
+		Joins(SQLJoinAnimalsOnReproductions).
+		Where("reproductions.animal_id = ? AND "+SQLWhereAnimalsFarmID, animalID, farmID).
+		First(&reproduction).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -46,22 +55,63 @@ func (r *ReproductionRepository) FindByAnimalID(animalID uint) (*models.Reproduc
 func (r *ReproductionRepository) FindByFarmID(farmID uint) ([]models.Reproduction, error) {
 	var reproductions []models.Reproduction
 	err := r.db.Preload("Animal").
-		Joins("JOIN animals ON reproductions.animal_id = animals.id").
+		Joins(SQLJoinAnimalsOnReproductions).
 		Where(SQLWhereAnimalsFarmID, farmID).
 		Find(&reproductions).Error
 	return reproductions, err
 }
 
-func (r *ReproductionRepository) FindByPhase(phase models.ReproductionPhase) ([]models.Reproduction, error) {
+func (r *ReproductionRepository) FindByPhase(farmID uint, phase models.ReproductionPhase) ([]models.Reproduction, error) {
 	var reproductions []models.Reproduction
-	err := r.db.Preload("Animal").Where("current_phase = ?", phase).Find(&reproductions).Error
+	err := r.db.Preload("Animal").
+		Joins(SQLJoinAnimalsOnReproductions).
+		Where("reproductions.current_phase = ? AND "+SQLWhereAnimalsFarmID, phase, farmID).
+		Find(&reproductions).Error
 	return reproductions, err
 }
 
-func (r *ReproductionRepository) Update(reproduction *models.Reproduction) error {
-	return r.db.Save(reproduction).Error
+func (r *ReproductionRepository) FindActiveByPhases(phases []models.ReproductionPhase) ([]models.Reproduction, error) {
+	var reproductions []models.Reproduction
+	err := r.db.Preload("Animal").
+		Joins(SQLJoinAnimalsOnReproductions).
+		Where("reproductions.current_phase IN (?) AND animals.status = ?", phases, models.AnimalStatusActive).
+		Find(&reproductions).Error
+	return reproductions, err
 }
 
-func (r *ReproductionRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Reproduction{}, id).Error
+func (r *ReproductionRepository) Update(reproduction *models.Reproduction, farmID uint) error {
+	result := r.db.Model(reproduction).
+		Where("animal_id IN (?)", farmAnimalIDs(r.db, farmID)).
+		Select("*").
+		Omit("created_at", clause.Associations).
+		Updates(reproduction)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrReproductionNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *ReproductionRepository) UpdateExpectedBirthDates(expectedBirthDates map[uint]time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for id, expectedBirthDate := range expectedBirthDates {
+			if err := tx.Model(&models.Reproduction{}).Where(SQLWhereID, id).Update("expected_birth_date", expectedBirthDate).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ReproductionRepository) Delete(id, farmID uint) error {
+	result := r.db.Where(SQLWhereIDInFarm, id, farmAnimalIDs(r.db, farmID)).Delete(&models.Reproduction{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrReproductionNotFoundOrNotBelongsToFarm)
+	}
+	return nil
 }
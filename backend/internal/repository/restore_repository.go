@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FarmRestore struct {
+	CompanyID          uint
+	Logo               string
+	OwnerUserID        uint
+	Animals            []models.Animal
+	MilkCollections    []models.MilkCollection
+	Reproductions      []models.Reproduction
+	ReproductionEvents []models.ReproductionEvent
+	Sales              []models.Sale
+	Expenses           []models.Expense
+	Debts              []models.Debt
+	DebtPayments       []models.DebtPayment
+}
+
+type RestoreRepository interface {
+	GetFarmCompanyID(ctx context.Context, farmID uint) (uint, error)
+	CompanyExists(ctx context.Context, companyID uint) (bool, error)
+	UserOwnsCompanyFarm(ctx context.Context, userID, companyID uint) (bool, error)
+	Restore(ctx context.Context, data *FarmRestore) (uint, error)
+}
+
+type restoreRepository struct {
+	db *gorm.DB
+}
+
+func NewRestoreRepository(db *gorm.DB) RestoreRepository {
+	return &restoreRepository{db: db}
+}
+
+func (r *restoreRepository) GetFarmCompanyID(ctx context.Context, farmID uint) (uint, error) {
+	var farm models.Farm
+	err := r.db.WithContext(ctx).Select("id", "company_id").Where(SQLWhereID, farmID).First(&farm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("%s", ErrFarmNotFound)
+		}
+		return 0, err
+	}
+	return farm.CompanyID, nil
+}
+
+func (r *restoreRepository) CompanyExists(ctx context.Context, companyID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Company{}).Where(SQLWhereID, companyID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *restoreRepository) UserOwnsCompanyFarm(ctx context.Context, userID, companyID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.UserFarm{}).
+		Joins("JOIN farms ON farms.id = user_farms.farm_id").
+		Where("user_farms.user_id = ? AND user_farms.role = ? AND farms.company_id = ?", userID, models.FarmRoleOwner, companyID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *restoreRepository) Restore(ctx context.Context, data *FarmRestore) (uint, error) {
+	farm := models.Farm{CompanyID: data.CompanyID, Logo: data.Logo}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&farm).Error; err != nil {
+			return fmt.Errorf(ErrCreatingFarm, err)
+		}
+
+		if data.OwnerUserID != 0 {
+			userFarm := models.UserFarm{UserID: data.OwnerUserID, FarmID: farm.ID, Role: models.FarmRoleOwner}
+			if err := tx.Omit(clause.Associations).Create(&userFarm).Error; err != nil {
+				return err
+			}
+		}
+
+		animalIDs, err := r.restoreAnimals(tx, farm.ID, data.Animals)
+		if err != nil {
+			return err
+		}
+
+		for i := range data.MilkCollections {
+			data.MilkCollections[i].ID = 0
+			data.MilkCollections[i].AnimalID = animalIDs[data.MilkCollections[i].AnimalID]
+		}
+		if err := createRestoreRows(tx, &data.MilkCollections, len(data.MilkCollections)); err != nil {
+			return err
+		}
+
+		for i := range data.Reproductions {
+			data.Reproductions[i].ID = 0
+			data.Reproductions[i].AnimalID = animalIDs[data.Reproductions[i].AnimalID]
+		}
+		if err := createRestoreRows(tx, &data.Reproductions, len(data.Reproductions)); err != nil {
+			return err
+		}
+		var lactating []uint
+		for _, reproduction := range data.Reproductions {
+			if reproduction.CurrentPhase == models.PhaseLactacao {
+				lactating = append(lactating, reproduction.ID)
+			}
+		}
+		if len(lactating) > 0 {
+			if err := tx.Model(&models.Reproduction{}).Where("id IN ?", lactating).Update("current_phase", models.PhaseLactacao).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range data.ReproductionEvents {
+			data.ReproductionEvents[i].ID = 0
+			data.ReproductionEvents[i].AnimalID = animalIDs[data.ReproductionEvents[i].AnimalID]
+			if sireID := data.ReproductionEvents[i].SireID; sireID != nil {
+				id := animalIDs[*sireID]
+				data.ReproductionEvents[i].SireID = &id
+			}
+			if calfID := data.ReproductionEvents[i].CalfID; calfID != nil {
+				id := animalIDs[*calfID]
+				data.ReproductionEvents[i].CalfID = &id
+			}
+		}
+		if err := createRestoreRows(tx, &data.ReproductionEvents, len(data.ReproductionEvents)); err != nil {
+			return err
+		}
+
+		saleIDs := make(map[uint]uint, len(data.Sales))
+		sourceSaleIDs := make([]uint, len(data.Sales))
+		for i := range data.Sales {
+			sourceSaleIDs[i] = data.Sales[i].ID
+			data.Sales[i].ID = 0
+			data.Sales[i].FarmID = farm.ID
+			data.Sales[i].AnimalID = animalIDs[data.Sales[i].AnimalID]
+		}
+		if err := createRestoreRows(tx, &data.Sales, len(data.Sales)); err != nil {
+			return err
+		}
+		for i, sale := range data.Sales {
+			saleIDs[sourceSaleIDs[i]] = sale.ID
+		}
+
+		expenseIDs := make(map[uint]uint, len(data.Expenses))
+		sourceExpenseIDs := make([]uint, len(data.Expenses))
+		for i := range data.Expenses {
+			sourceExpenseIDs[i] = data.Expenses[i].ID
+			data.Expenses[i].ID = 0
+			data.Expenses[i].FarmID = farm.ID
+		}
+		if err := createRestoreRows(tx, &data.Expenses, len(data.Expenses)); err != nil {
+			return err
+		}
+		for i, expense := range data.Expenses {
+			expenseIDs[sourceExpenseIDs[i]] = expense.ID
+		}
+
+		debtIDs := make(map[uint]uint, len(data.Debts))
+		sourceDebtIDs := make([]uint, len(data.Debts))
+		for i := range data.Debts {
+			debt := &data.Debts[i]
+			sourceDebtIDs[i] = debt.ID
+			debt.ID = 0
+			debt.FarmID = farm.ID
+			if debt.SaleID != nil {
+				id := saleIDs[*debt.SaleID]
+				debt.SaleID = &id
+			}
+			if debt.ExpenseID != nil {
+				id := expenseIDs[*debt.ExpenseID]
+				debt.ExpenseID = &id
+			}
+		}
+		if err := createRestoreRows(tx, &data.Debts, len(data.Debts)); err != nil {
+			return err
+		}
+		for i, debt := range data.Debts {
+			debtIDs[sourceDebtIDs[i]] = debt.ID
+		}
+
+		for i := range data.DebtPayments {
+			data.DebtPayments[i].ID = 0
+			data.DebtPayments[i].DebtID = debtIDs[data.DebtPayments[i].DebtID]
+		}
+		return createRestoreRows(tx, &data.DebtPayments, len(data.DebtPayments))
+	})
+	if err != nil {
+		return 0, fmt.Errorf(ErrRestoringFarm, err)
+	}
+
+	return farm.ID, nil
+}
+
+func (r *restoreRepository) restoreAnimals(tx *gorm.DB, farmID uint, animals []models.Animal) (map[uint]uint, error) {
+	sourceIDs := make([]uint, len(animals))
+	fathers := make([]*uint, len(animals))
+	mothers := make([]*uint, len(animals))
+	for i := range animals {
+		sourceIDs[i] = animals[i].ID
+		fathers[i] = animals[i].FatherID
+		mothers[i] = animals[i].MotherID
+		animals[i].ID = 0
+		animals[i].FarmID = farmID
+		animals[i].FatherID = nil
+		animals[i].MotherID = nil
+	}
+	if err := createRestoreRows(tx, &animals, len(animals)); err != nil {
+		return nil, err
+	}
+
+	animalIDs := make(map[uint]uint, len(animals))
+	for i, animal := range animals {
+		animalIDs[sourceIDs[i]] = animal.ID
+	}
+
+	for i, animal := range animals {
+		updates := map[string]interface{}{}
+		if fathers[i] != nil {
+			updates["father_id"] = animalIDs[*fathers[i]]
+		}
+		if mothers[i] != nil {
+			updates["mother_id"] = animalIDs[*mothers[i]]
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		if err := tx.Model(&models.Animal{}).Where(SQLWhereID, animal.ID).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return animalIDs, nil
+}
+
+func createRestoreRows(tx *gorm.DB, rows interface{}, count int) error {
+	if count == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).CreateInBatches(rows, ExportBatchSize).Error
+}
@@ -121,7 +121,6 @@ func (r *saleRepository) GetMonthlySalesData(ctx context.Context, farmID uint, m
 		return nil, fmt.Errorf("error fetching monthly sales data: %w", err)
 	}
 
-	monthNames := []string{"Jan", "Fev", "Mar", "Abr", "Mai", "Jun", "Jul", "Ago", "Set", "Out", "Nov", "Dez"}
 	monthlyData := make([]MonthlySalesData, 0, months)
 
 	resultMap := make(map[string]Result)
@@ -139,14 +138,14 @@ func (r *saleRepository) GetMonthlySalesData(ctx context.Context, farmID uint, m
 		var data MonthlySalesData
 		if result, ok := resultMap[key]; ok {
 			data = MonthlySalesData{
-				Month: monthNames[month-1],
+				Month: MonthNames[month-1],
 				Year:  year,
 				Sales: result.Sales,
 				Count: result.Count,
 			}
 		} else {
 			data = MonthlySalesData{
-				Month: monthNames[month-1],
+				Month: MonthNames[month-1],
 				Year:  year,
 				Sales: 0,
 				Count: 0,
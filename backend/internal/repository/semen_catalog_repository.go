@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SemenCatalogRepository interface {
+	Create(ctx context.Context, entry *models.SemenCatalogEntry) error
+	GetByID(ctx context.Context, id uint, farmID uint) (*models.SemenCatalogEntry, error)
+	GetByFarmID(ctx context.Context, farmID uint, activeOnly bool) ([]*models.SemenCatalogEntry, error)
+	Update(ctx context.Context, entry *models.SemenCatalogEntry) error
+	Delete(ctx context.Context, id uint, farmID uint) error
+}
+
+type semenCatalogRepository struct {
+	db *gorm.DB
+}
+
+func NewSemenCatalogRepository(db *gorm.DB) SemenCatalogRepository {
+	return &semenCatalogRepository{db: db}
+}
+
+func (r *semenCatalogRepository) Create(ctx context.Context, entry *models.SemenCatalogEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+func (r *semenCatalogRepository) GetByID(ctx context.Context, id uint, farmID uint) (*models.SemenCatalogEntry, error) {
+	var entry models.SemenCatalogEntry
+	err := r.db.WithContext(ctx).Where(SQLWhereIDAndFarmID, id, farmID).First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *semenCatalogRepository) GetByFarmID(ctx context.Context, farmID uint, activeOnly bool) ([]*models.SemenCatalogEntry, error) {
+	var entries []*models.SemenCatalogEntry
+	query := r.db.WithContext(ctx).Where(SQLWhereFarmID, farmID)
+
+	if activeOnly {
+		query = query.Where("active = ? AND doses_available > 0", true)
+	}
+
+	err := query.Order("bull_name ASC").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *semenCatalogRepository) Update(ctx context.Context, entry *models.SemenCatalogEntry) error {
+	result := r.db.WithContext(ctx).Model(&models.SemenCatalogEntry{}).
+		Where(SQLWhereIDAndFarmID, entry.ID, entry.FarmID).
+		Updates(map[string]interface{}{
+			"bull_name":       entry.BullName,
+			"code":            entry.Code,
+			"breed":           entry.Breed,
+			"supplier":        entry.Supplier,
+			"father_id":       entry.FatherID,
+			"mother_id":       entry.MotherID,
+			"pta_milk":        entry.PTAMilk,
+			"doses_available": entry.DosesAvailable,
+			"active":          entry.Active,
+			"notes":           entry.Notes,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrSemenCatalogNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *semenCatalogRepository) Delete(ctx context.Context, id uint, farmID uint) error {
+	result := r.db.WithContext(ctx).Where(SQLWhereIDAndFarmID, id, farmID).Delete(&models.SemenCatalogEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrSemenCatalogNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
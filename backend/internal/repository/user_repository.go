@@ -159,3 +159,48 @@ func (r *UserRepository) GetUserFarmByID(userID, farmID uint) (*models.Farm, err
 func (r *UserRepository) CreateUserFarm(userFarm *models.UserFarm) error {
 	return r.db.DB.Create(userFarm).Error
 }
+
+func (r *UserRepository) GetUserFarmRole(userID, farmID uint) (string, error) {
+	var userFarm models.UserFarm
+	if err := r.db.DB.Select("role").Where(SQLWhereUserIDAndFarmID, userID, farmID).First(&userFarm).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", gorm.ErrRecordNotFound
+		}
+		return "", fmt.Errorf(ErrFindingUserFarm, err)
+	}
+
+	return userFarm.Role, nil
+}
+
+func (r *UserRepository) UpdateUserFarmRole(userID, farmID uint, role string) error {
+	result := r.db.DB.Model(&models.UserFarm{}).Where(SQLWhereUserIDAndFarmID, userID, farmID).Update("role", role)
+	if result.Error != nil {
+		return fmt.Errorf(ErrUpdatingUserFarmRole, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *UserRepository) CountFarmUsersByRole(farmID uint, role string) (int64, error) {
+	var count int64
+	if err := r.db.DB.Model(&models.UserFarm{}).Where(SQLWhereFarmID+" AND role = ?", farmID, role).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf(ErrCountingUserFarms, err)
+	}
+	return count, nil
+}
+
+func (r *UserRepository) FindFarmUserEmails(farmID uint, roles []string) ([]string, error) {
+	var emails []string
+	err := r.db.DB.Model(&models.UserFarm{}).
+		Joins("JOIN users ON users.id = user_farms.user_id").
+		Joins("JOIN people ON people.id = users.person_id").
+		Where("user_farms.farm_id = ? AND user_farms.role IN (?)", farmID, roles).
+		Distinct().
+		Pluck("people.email", &emails).Error
+	if err != nil {
+		return nil, fmt.Errorf(ErrFindingUserFarms, err)
+	}
+	return emails, nil
+}
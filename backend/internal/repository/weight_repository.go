@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type WeightRepository interface {
+	Create(ctx context.Context, weight *models.Weight) error
+	GetByID(ctx context.Context, id uint, farmID uint) (*models.Weight, error)
+	GetByFarmID(ctx context.Context, farmID uint) ([]*models.Weight, error)
+	GetLatestByFarmID(ctx context.Context, farmID uint, perAnimal int) ([]*models.Weight, error)
+	GetByAnimalID(ctx context.Context, animalID uint, farmID uint) ([]*models.Weight, error)
+	Update(ctx context.Context, weight *models.Weight, farmID uint) error
+	Delete(ctx context.Context, id uint, farmID uint) error
+}
+
+type weightRepository struct {
+	db *gorm.DB
+}
+
+func NewWeightRepository(db *gorm.DB) WeightRepository {
+	return &weightRepository{db: db}
+}
+
+func (r *weightRepository) farmAnimalIDs(ctx context.Context, farmID uint) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&models.Animal{}).Select("id").Where(SQLWhereFarmID, farmID)
+}
+
+func (r *weightRepository) Create(ctx context.Context, weight *models.Weight) error {
+	return r.db.WithContext(ctx).Create(weight).Error
+}
+
+func (r *weightRepository) GetByID(ctx context.Context, id uint, farmID uint) (*models.Weight, error) {
+	var weight models.Weight
+	err := r.db.WithContext(ctx).Preload("Animal").
+		Joins(SQLJoinAnimalsOnWeights).
+		Where("weights.id = ? AND "+SQLWhereAnimalsFarmID, id, farmID).
+		First(&weight).Error
+	if err != nil {
+		return nil, err
+	}
+	return &weight, nil
+}
+
+func (r *weightRepository) GetByFarmID(ctx context.Context, farmID uint) ([]*models.Weight, error) {
+	var weights []*models.Weight
+	err := r.db.WithContext(ctx).Preload("Animal").
+		Joins(SQLJoinAnimalsOnWeights).
+		Where(SQLWhereAnimalsFarmID, farmID).
+		Order(SQLOrderWeightDateDESC).
+		Find(&weights).Error
+	if err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+func (r *weightRepository) GetLatestByFarmID(ctx context.Context, farmID uint, perAnimal int) ([]*models.Weight, error) {
+	ranked := r.db.WithContext(ctx).Model(&models.Weight{}).
+		Select("weights.*, ROW_NUMBER() OVER (PARTITION BY weights.animal_id ORDER BY weights.date DESC, weights.id DESC) AS weight_rank").
+		Joins(SQLJoinAnimalsOnWeights).
+		Where(SQLWhereAnimalsFarmID, farmID)
+
+	var weights []*models.Weight
+	err := r.db.WithContext(ctx).Table("(?) AS weights", ranked).
+		Where("weight_rank <= ?", perAnimal).
+		Order("weights.animal_id, " + SQLOrderWeightDateDESC).
+		Find(&weights).Error
+	if err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+func (r *weightRepository) GetByAnimalID(ctx context.Context, animalID uint, farmID uint) ([]*models.Weight, error) {
+	var weights []*models.Weight
+	err := r.db.WithContext(ctx).Preload("Animal").
+		Joins(SQLJoinAnimalsOnWeights).
+		Where("weights.animal_id = ? AND "+SQLWhereAnimalsFarmID, animalID, farmID).
+		Order("weights.date ASC").
+		Find(&weights).Error
+	if err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+func (r *weightRepository) Update(ctx context.Context, weight *models.Weight, farmID uint) error {
+	result := r.db.WithContext(ctx).Model(&models.Weight{}).
+		Where("id = ? AND animal_id IN (?)", weight.ID, r.farmAnimalIDs(ctx, farmID)).
+		Updates(map[string]interface{}{
+			"date":          weight.Date,
+			"animal_weight": weight.AnimalWeight,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrWeightNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (r *weightRepository) Delete(ctx context.Context, id uint, farmID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND animal_id IN (?)", id, r.farmAnimalIDs(ctx, farmID)).
+		Delete(&models.Weight{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s", ErrWeightNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"github.com/fazendapro/FazendaPro-api/internal/repository"
 	"github.com/fazendapro/FazendaPro-api/internal/service"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
 	"github.com/getsentry/sentry-go"
 	sentryhttp "github.com/getsentry/sentry-go/http"
 	"github.com/go-chi/chi/v5"
@@ -66,19 +67,11 @@ func SetupRoutes(app *app.Application, db *repository.Database, cfg *config.Conf
 		app.Logger.Printf("Cache Memcached inicializado em %s", memcachedServer)
 	}
 
-	if db != nil && db.DB != nil {
-		repoFactory := repository.NewRepositoryFactory(db, cacheClient)
-		serviceFactory := service.NewServiceFactory(repoFactory)
-		debtService := serviceFactory.CreateDebtService()
-		debtHandler := handlers.NewDebtHandler(debtService)
-
-		r.Route("/debts", func(r chi.Router) {
-			r.Post("/", debtHandler.CreateDebt)
-			r.Get("/", debtHandler.GetDebts)
-			r.Delete("/{id}", debtHandler.DeleteDebt)
-			r.Get("/total-by-person", debtHandler.GetTotalByPerson)
-		})
+	blobStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		app.Logger.Fatalf("Erro ao configurar armazenamento de arquivos: %v", err)
 	}
+	app.Logger.Printf("Armazenamento de arquivos: %s", blobStorage.Name())
 
 	r.Post("/init-data", func(w http.ResponseWriter, r *http.Request) {
 		if db == nil || db.DB == nil {
@@ -127,6 +120,7 @@ func SetupRoutes(app *app.Application, db *repository.Database, cfg *config.Conf
 			userService := serviceFactory.CreateUserService()
 			userHandler := handlers.NewUserHandler(userService)
 			refreshTokenRepo := repoFactory.CreateRefreshTokenRepository()
+			farmRoles := repoFactory.CreateUserRepository()
 			authHandler := handlers.NewAuthHandler(userService, refreshTokenRepo, cfg.JWTSecret)
 
 			r.Route("/auth", func(r chi.Router) {
@@ -137,23 +131,32 @@ func SetupRoutes(app *app.Application, db *repository.Database, cfg *config.Conf
 			})
 
 			r.Route("/users", func(r chi.Router) {
-				r.Use(middleware.Auth(cfg.JWTSecret))
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
 				r.Post("/", userHandler.CreateUser)
 				r.Get("/", userHandler.GetUser)
 			})
 
 			farmSelectionHandler := handlers.NewFarmSelectionHandler(userService, cfg.JWTSecret)
+			restoreHandler := handlers.NewRestoreHandler(serviceFactory.CreateRestoreService(blobStorage))
 			r.Route("/farms", func(r chi.Router) {
-				r.Use(middleware.Auth(cfg.JWTSecret))
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
 				r.Get("/user", farmSelectionHandler.GetUserFarms)
 				r.Post("/select", farmSelectionHandler.SelectFarm)
+				r.With(middleware.RequirePermission(middleware.PermissionMembersManage, middleware.PermissionMembersManage)).Put("/members/{userId}/role", farmSelectionHandler.UpdateMemberRole)
+				r.With(middleware.RequirePermission(middleware.PermissionMembersManage, middleware.PermissionMembersManage)).Post("/restore", restoreHandler.RestoreFarm)
 			})
 
 			animalService := serviceFactory.CreateAnimalService()
-			animalHandler := handlers.NewAnimalHandler(animalService)
+			animalPhotoService := serviceFactory.CreateAnimalPhotoService(blobStorage)
+			animalAttachmentService := serviceFactory.CreateAnimalAttachmentService(blobStorage)
+			animalHandler := handlers.NewAnimalHandler(animalService, animalPhotoService, animalAttachmentService)
+			animalAttachmentHandler := handlers.NewAnimalAttachmentHandler(animalAttachmentService)
+			pedigreeService := serviceFactory.CreatePedigreeService()
+			pedigreeHandler := handlers.NewPedigreeHandler(pedigreeService)
 
 			r.Route("/animals", func(r chi.Router) {
-				r.Use(middleware.Auth(cfg.JWTSecret))
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionHerdRead, middleware.PermissionHerdWrite))
 				r.Post("/", animalHandler.CreateAnimal)
 				r.Get("/", animalHandler.GetAnimal)
 				r.Get("/farm", animalHandler.GetAnimalsByFarm)
@@ -161,50 +164,167 @@ func SetupRoutes(app *app.Application, db *repository.Database, cfg *config.Conf
 				r.Put("/", animalHandler.UpdateAnimal)
 				r.Delete("/", animalHandler.DeleteAnimal)
 				r.Post("/photo", animalHandler.UploadAnimalPhoto)
+				r.Post("/import", animalHandler.ImportAnimals)
+				r.Get("/inbreeding", pedigreeHandler.GetMatingInbreeding)
+				r.Get("/{id}/pedigree", pedigreeHandler.GetPedigree)
+				r.Get("/{id}/descendants", pedigreeHandler.GetDescendants)
+				r.Post("/{id}/attachments", animalAttachmentHandler.UploadAttachment)
+				r.Get("/{id}/attachments", animalAttachmentHandler.GetAttachments)
+				r.Get("/{id}/attachments/{attachmentId}/download", animalAttachmentHandler.DownloadAttachment)
+				r.Delete("/{id}/attachments/{attachmentId}", animalAttachmentHandler.DeleteAttachment)
+			})
+
+			r.Route("/photos", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionHerdRead, middleware.PermissionHerdWrite))
+				r.Get("/*", animalHandler.GetAnimalPhoto)
 			})
 
 			milkCollectionService := serviceFactory.CreateMilkCollectionService()
 			milkCollectionHandler := handlers.NewMilkCollectionHandler(milkCollectionService)
+			lactationService := serviceFactory.CreateLactationService()
+			lactationHandler := handlers.NewLactationHandler(lactationService)
 
 			r.Route("/milk-collections", func(r chi.Router) {
-				r.Use(middleware.Auth(cfg.JWTSecret))
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionMilkRead, middleware.PermissionMilkWrite))
 				r.Post("/", milkCollectionHandler.CreateMilkCollection)
+				r.Post("/session", milkCollectionHandler.CreateMilkSession)
 				r.Put("/{id}", milkCollectionHandler.UpdateMilkCollection)
 				r.Get("/farm/{farmId}", milkCollectionHandler.GetMilkCollectionsByFarmID)
 				r.Get("/animal/{animalId}", milkCollectionHandler.GetMilkCollectionsByAnimalID)
+				r.Get("/animal/{animalId}/lactations", lactationHandler.GetLactations)
 				r.Get("/top-producers", milkCollectionHandler.GetTopMilkProducers)
+				r.Get("/daily", milkCollectionHandler.GetDailyTotals)
+			})
+
+			milkQualityService := serviceFactory.CreateMilkQualityService()
+			milkQualityHandler := handlers.NewMilkQualityHandler(milkQualityService)
+
+			r.Route("/milk-quality", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionMilkRead, middleware.PermissionMilkWrite))
+				r.Post("/", milkQualityHandler.CreateTest)
+				r.Get("/", milkQualityHandler.GetTests)
+				r.Post("/import", milkQualityHandler.ImportTests)
+				r.Get("/mastitis", milkQualityHandler.GetMastitisFlags)
+				r.Get("/trends", milkQualityHandler.GetTrends)
+				r.Get("/{id}", milkQualityHandler.GetTestByID)
+				r.Put("/{id}", milkQualityHandler.UpdateTest)
+				r.Delete("/{id}", milkQualityHandler.DeleteTest)
+			})
+
+			milkDeliveryService := serviceFactory.CreateMilkDeliveryService()
+			milkDeliveryHandler := handlers.NewMilkDeliveryHandler(milkDeliveryService)
+
+			r.Route("/milk-deliveries", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionMilkRead, middleware.PermissionMilkWrite))
+				r.Post("/", milkDeliveryHandler.CreateDelivery)
+				r.Get("/", milkDeliveryHandler.GetDeliveries)
+				r.Get("/{id}", milkDeliveryHandler.GetDeliveryByID)
+				r.Put("/{id}", milkDeliveryHandler.UpdateDelivery)
+				r.Delete("/{id}", milkDeliveryHandler.DeleteDelivery)
+			})
+
+			r.Route("/milk-pricing", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionFinanceRead, middleware.PermissionFinanceWrite))
+				r.Post("/tables", milkDeliveryHandler.CreatePriceTable)
+				r.Get("/tables", milkDeliveryHandler.GetPriceTables)
+				r.Get("/tables/{id}", milkDeliveryHandler.GetPriceTableByID)
+				r.Put("/tables/{id}", milkDeliveryHandler.UpdatePriceTable)
+				r.Delete("/tables/{id}", milkDeliveryHandler.DeletePriceTable)
+				r.Post("/payments", milkDeliveryHandler.CreatePayment)
+				r.Get("/payments", milkDeliveryHandler.GetPayments)
+				r.Delete("/payments/{id}", milkDeliveryHandler.DeletePayment)
+				r.Get("/statement", milkDeliveryHandler.GetStatement)
+			})
+
+			batchService := serviceFactory.CreateBatchService()
+			batchHandler := handlers.NewBatchHandler(batchService)
+
+			r.Route("/batches", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionHerdRead, middleware.PermissionHerdWrite))
+				r.Get("/rules", batchHandler.GetRules)
+				r.With(middleware.RequirePermission(middleware.PermissionFarmWrite, middleware.PermissionFarmWrite)).Put("/rules", batchHandler.SaveRules)
+				r.Post("/rebatch", batchHandler.Rebatch)
+				r.Get("/moves", batchHandler.GetMoves)
 			})
 
 			reproductionService := serviceFactory.CreateReproductionService()
 			reproductionHandler := handlers.NewReproductionHandler(reproductionService)
+			matingRecommendationService := serviceFactory.CreateMatingRecommendationService()
+			matingRecommendationHandler := handlers.NewMatingRecommendationHandler(matingRecommendationService)
+			reproductiveKPIService := serviceFactory.CreateReproductiveKPIService()
+			reproductiveKPIHandler := handlers.NewReproductiveKPIHandler(reproductiveKPIService)
+			gestationService := serviceFactory.CreateGestationService()
+			gestationHandler := handlers.NewGestationHandler(gestationService)
 
 			r.Route("/reproductions", func(r chi.Router) {
-				r.Use(middleware.Auth(cfg.JWTSecret))
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionReproductionRead, middleware.PermissionReproductionWrite))
 				r.Post("/", reproductionHandler.CreateReproduction)
 				r.Get("/", reproductionHandler.GetReproduction)
 				r.Get("/animal", reproductionHandler.GetReproductionByAnimal)
 				r.Get("/farm", reproductionHandler.GetReproductionsByFarm)
 				r.Get("/phase", reproductionHandler.GetReproductionsByPhase)
 				r.Get("/next-to-calve", reproductionHandler.GetNextToCalve)
+				r.Get("/mating-recommendations", matingRecommendationHandler.GetRecommendations)
+				r.Post("/events", reproductionHandler.CreateReproductionEvent)
+				r.Get("/events", reproductionHandler.GetReproductionEvents)
+				r.Get("/calvings", reproductionHandler.GetCalvingHistory)
+				r.Get("/kpis", reproductiveKPIHandler.GetKPIs)
+				r.Get("/gestation-periods", gestationHandler.GetGestationTable)
+				r.With(middleware.RequirePermission(middleware.PermissionFarmWrite, middleware.PermissionFarmWrite)).Put("/gestation-periods", gestationHandler.SaveOverride)
+				r.With(middleware.RequirePermission(middleware.PermissionFarmWrite, middleware.PermissionFarmWrite)).Delete("/gestation-periods/{id}", gestationHandler.DeleteOverride)
 				r.Put("/", reproductionHandler.UpdateReproduction)
 				r.Put("/phase", reproductionHandler.UpdateReproductionPhase)
 				r.Delete("/", reproductionHandler.DeleteReproduction)
 			})
 
+			notificationService := serviceFactory.CreateNotificationService()
+			notificationHandler := handlers.NewNotificationHandler(notificationService)
+
+			r.Route("/notifications", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionFarmRead, middleware.PermissionFarmRead))
+				r.Get("/", notificationHandler.GetNotifications)
+				r.Put("/read-all", notificationHandler.MarkAllAsRead)
+				r.Put("/{id}/read", notificationHandler.MarkAsRead)
+			})
+
+			semenCatalogService := serviceFactory.CreateSemenCatalogService()
+			semenCatalogHandler := handlers.NewSemenCatalogHandler(semenCatalogService)
+
+			r.Route("/semen-catalog", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionReproductionRead, middleware.PermissionReproductionWrite))
+				r.Post("/", semenCatalogHandler.CreateEntry)
+				r.Get("/", semenCatalogHandler.GetEntriesByFarm)
+				r.Get("/{id}", semenCatalogHandler.GetEntryByID)
+				r.Put("/{id}", semenCatalogHandler.UpdateEntry)
+				r.Delete("/{id}", semenCatalogHandler.DeleteEntry)
+			})
+
 			farmService := serviceFactory.CreateFarmService()
 			farmHandler := handlers.NewFarmHandler(farmService)
 
 			r.Route("/farm", func(r chi.Router) {
-				r.Use(middleware.Auth(cfg.JWTSecret))
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionFarmRead, middleware.PermissionFarmWrite))
 				r.Get("/", farmHandler.GetFarm)
 				r.Put("/", farmHandler.UpdateFarm)
 			})
 
 			saleService := serviceFactory.CreateSaleService()
-			saleHandler := handlers.NewSaleChiHandler(saleService)
+			expenseService := serviceFactory.CreateExpenseService()
+			saleHandler := handlers.NewSaleChiHandler(saleService, expenseService)
 
 			r.Route("/sales", func(r chi.Router) {
-				r.Use(middleware.Auth(cfg.JWTSecret))
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionFinanceRead, middleware.PermissionFinanceWrite))
 				r.Post("/", saleHandler.CreateSale)
 				r.Get("/", saleHandler.GetSalesByFarm)
 				r.Get("/history", saleHandler.GetSalesHistory)
@@ -218,9 +338,76 @@ func SetupRoutes(app *app.Application, db *repository.Database, cfg *config.Conf
 			})
 
 			r.Route("/animals/{animal_id}/sales", func(r chi.Router) {
-				r.Use(middleware.Auth(cfg.JWTSecret))
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionFinanceRead, middleware.PermissionFinanceWrite))
 				r.Get("/", saleHandler.GetSalesByAnimal)
 			})
+
+			weightService := serviceFactory.CreateWeightService()
+			weightHandler := handlers.NewWeightHandler(weightService)
+
+			r.Route("/weights", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionHerdRead, middleware.PermissionHerdWrite))
+				r.Post("/", weightHandler.CreateWeight)
+				r.Get("/", weightHandler.GetWeightsByFarm)
+				r.Get("/animal/{animalId}", weightHandler.GetWeightsByAnimal)
+				r.Get("/{id}", weightHandler.GetWeightByID)
+				r.Put("/{id}", weightHandler.UpdateWeight)
+				r.Delete("/{id}", weightHandler.DeleteWeight)
+			})
+
+			expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+			r.Route("/expenses", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionFinanceRead, middleware.PermissionFinanceWrite))
+				r.Post("/", expenseHandler.CreateExpense)
+				r.Get("/", expenseHandler.GetExpensesByFarm)
+				r.Get("/monthly", expenseHandler.GetMonthlyExpenses)
+				r.Get("/by-category", expenseHandler.GetTotalsByCategory)
+				r.Get("/{id}", expenseHandler.GetExpenseByID)
+				r.Put("/{id}", expenseHandler.UpdateExpense)
+				r.Delete("/{id}", expenseHandler.DeleteExpense)
+			})
+
+			debtService := serviceFactory.CreateDebtService()
+			debtHandler := handlers.NewDebtHandler(debtService)
+
+			r.Route("/debts", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionFinanceRead, middleware.PermissionFinanceWrite))
+				r.Post("/", debtHandler.CreateDebt)
+				r.Get("/", debtHandler.GetDebts)
+				r.Get("/overdue", debtHandler.GetOverdueDebts)
+				r.Get("/upcoming", debtHandler.GetUpcomingDebts)
+				r.Get("/total-by-person", debtHandler.GetTotalByPerson)
+				r.Get("/{id}", debtHandler.GetDebtByID)
+				r.Post("/{id}/payments", debtHandler.RegisterPayment)
+				r.Delete("/{id}", debtHandler.DeleteDebt)
+			})
+
+			reportService := serviceFactory.CreateReportService()
+			reportHandler := handlers.NewReportHandler(reportService)
+			pdfReportService := serviceFactory.CreatePDFReportService()
+			pdfReportHandler := handlers.NewPDFReportHandler(pdfReportService)
+
+			r.Route("/reports", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.With(middleware.RequirePermission(middleware.PermissionFinanceRead, middleware.PermissionFinanceWrite)).Get("/pnl", reportHandler.GetProfitAndLoss)
+				r.With(middleware.RequirePermission(middleware.PermissionFinanceRead, middleware.PermissionFinanceWrite)).Get("/sales.pdf", pdfReportHandler.SalesStatement)
+				r.With(middleware.RequirePermission(middleware.PermissionHerdRead, middleware.PermissionHerdWrite)).Get("/herd.pdf", pdfReportHandler.HerdInventory)
+				r.With(middleware.RequirePermission(middleware.PermissionReproductionRead, middleware.PermissionReproductionWrite)).Get("/reproduction-calendar.pdf", pdfReportHandler.ReproductionCalendar)
+			})
+
+			exportService := serviceFactory.CreateExportService(blobStorage)
+			exportHandler := handlers.NewExportHandler(exportService)
+
+			r.Route("/export", func(r chi.Router) {
+				r.Use(middleware.Auth(cfg.JWTSecret, farmRoles))
+				r.Use(middleware.RequirePermission(middleware.PermissionFinanceRead, middleware.PermissionFinanceWrite))
+				r.Get("/", exportHandler.Export)
+			})
 		})
 
 		app.Logger.Println("Rotas de animais configuradas: /api/v1/animals/farm")
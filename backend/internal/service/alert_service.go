@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/notification"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	AlertPregnancyCheckDays = 30
+	AlertDryOffDays         = 60
+	AlertDryOffLeadDays     = 7
+	AlertCalvingOverdueDays = 7
+	alertDateFormat         = "02/01/2006"
+)
+
+var AlertRecipientRoles = []string{models.FarmRoleOwner, models.FarmRoleManager, models.FarmRoleVeterinarian}
+
+type AlertRunSummary struct {
+	ReproductionsScanned int
+	ReproductionsFailed  int
+	NotificationsCreated int
+	FarmsNotified        int
+	DeliveryFailures     int
+}
+
+type AlertService interface {
+	GenerateAlerts(ctx context.Context, now time.Time) (*AlertRunSummary, error)
+}
+
+type alertService struct {
+	reproductionRepo repository.ReproductionRepositoryInterface
+	eventRepo        repository.ReproductionEventRepositoryInterface
+	notificationRepo repository.NotificationRepository
+	userRepo         repository.UserRepositoryInterface
+	channels         []notification.Channel
+}
+
+func NewAlertService(reproductionRepo repository.ReproductionRepositoryInterface, eventRepo repository.ReproductionEventRepositoryInterface, notificationRepo repository.NotificationRepository, userRepo repository.UserRepositoryInterface, channels []notification.Channel) AlertService {
+	return &alertService{
+		reproductionRepo: reproductionRepo,
+		eventRepo:        eventRepo,
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		channels:         channels,
+	}
+}
+
+func (s *alertService) GenerateAlerts(ctx context.Context, now time.Time) (*AlertRunSummary, error) {
+	reproductions, err := s.reproductionRepo.FindActiveByPhases([]models.ReproductionPhase{
+		models.PhaseVazias,
+		models.PhasePrenhas,
+		models.PhaseSecando,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AlertRunSummary{ReproductionsScanned: len(reproductions)}
+	created := make(map[uint][]*models.Notification)
+
+	for i := range reproductions {
+		reproduction := &reproductions[i]
+		alerts, err := s.reproductionAlerts(reproduction, now)
+		if err == nil {
+			err = s.createAlerts(ctx, alerts, created, summary)
+		}
+		if err != nil {
+			log.Printf("Erro ao gerar alertas da reprodução %d (animal %d): %v", reproduction.ID, reproduction.AnimalID, err)
+			summary.ReproductionsFailed++
+		}
+	}
+
+	farmIDs := make([]uint, 0, len(created))
+	for farmID := range created {
+		farmIDs = append(farmIDs, farmID)
+	}
+	sort.Slice(farmIDs, func(i, j int) bool { return farmIDs[i] < farmIDs[j] })
+
+	for _, farmID := range farmIDs {
+		summary.DeliveryFailures += s.deliver(ctx, farmID, created[farmID])
+		summary.FarmsNotified++
+	}
+
+	return summary, nil
+}
+
+func (s *alertService) createAlerts(ctx context.Context, alerts []*models.Notification, created map[uint][]*models.Notification, summary *AlertRunSummary) error {
+	for _, alert := range alerts {
+		isNew, err := s.notificationRepo.CreateIfNotExists(ctx, alert)
+		if err != nil {
+			return err
+		}
+		if isNew {
+			created[alert.FarmID] = append(created[alert.FarmID], alert)
+			summary.NotificationsCreated++
+		}
+	}
+	return nil
+}
+
+func (s *alertService) reproductionAlerts(reproduction *models.Reproduction, now time.Time) ([]*models.Notification, error) {
+	var alerts []*models.Notification
+	animal := &reproduction.Animal
+
+	switch reproduction.CurrentPhase {
+	case models.PhaseVazias:
+		if reproduction.InseminationDate == nil {
+			break
+		}
+		dueDate := reproduction.InseminationDate.AddDate(0, 0, AlertPregnancyCheckDays)
+		if now.Before(dueDate) {
+			break
+		}
+		pending, err := s.pregnancyCheckPending(animal, *reproduction.InseminationDate)
+		if err != nil {
+			return nil, err
+		}
+		if pending {
+			alerts = append(alerts, newAlert(animal, models.NotificationPregnancyCheckDue, *reproduction.InseminationDate, dueDate,
+				"Diagnóstico de gestação pendente",
+				fmt.Sprintf("%s inseminada em %s sem diagnóstico de gestação registrado.", animalLabel(animal), reproduction.InseminationDate.Format(alertDateFormat))))
+		}
+	case models.PhasePrenhas, models.PhaseSecando:
+		if reproduction.ExpectedBirthDate == nil {
+			break
+		}
+		expected := *reproduction.ExpectedBirthDate
+
+		lactating := reproduction.LactationStartDate != nil && reproduction.LactationEndDate == nil
+		dryOffDate := expected.AddDate(0, 0, -AlertDryOffDays)
+		if reproduction.CurrentPhase == models.PhasePrenhas && lactating && !now.Before(dryOffDate.AddDate(0, 0, -AlertDryOffLeadDays)) && now.Before(expected) {
+			alerts = append(alerts, newAlert(animal, models.NotificationDryOffDue, expected, dryOffDate,
+				"Secagem prevista",
+				fmt.Sprintf("%s deve ser secada em %s, %d dias antes do parto previsto para %s.", animalLabel(animal), dryOffDate.Format(alertDateFormat), AlertDryOffDays, expected.Format(alertDateFormat))))
+		}
+
+		if now.After(expected.AddDate(0, 0, AlertCalvingOverdueDays)) {
+			alerts = append(alerts, newAlert(animal, models.NotificationCalvingOverdue, expected, expected,
+				"Parto atrasado",
+				fmt.Sprintf("%s tinha parto previsto para %s e nenhum parto foi registrado.", animalLabel(animal), expected.Format(alertDateFormat))))
+		}
+	}
+
+	return alerts, nil
+}
+
+func (s *alertService) pregnancyCheckPending(animal *models.Animal, inseminationDate time.Time) (bool, error) {
+	events, err := s.eventRepo.FindByAnimalID(animal.ID, animal.FarmID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, event := range events {
+		if event.Date.Before(inseminationDate) {
+			continue
+		}
+		switch event.Type {
+		case models.ReproductionEventPregnancyCheck, models.ReproductionEventCalving, models.ReproductionEventAbortion:
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func newAlert(animal *models.Animal, alertType string, reference, dueDate time.Time, title, message string) *models.Notification {
+	animalID := animal.ID
+	return &models.Notification{
+		FarmID:   animal.FarmID,
+		AnimalID: &animalID,
+		Type:     alertType,
+		Title:    title,
+		Message:  message,
+		DueDate:  &dueDate,
+		DedupKey: fmt.Sprintf("%s:%d:%s", alertType, animal.ID, reference.Format("2006-01-02")),
+	}
+}
+
+func animalLabel(animal *models.Animal) string {
+	return fmt.Sprintf("%s (brinco %d)", animal.AnimalName, animal.EarTagNumberLocal)
+}
+
+func (s *alertService) deliver(ctx context.Context, farmID uint, notifications []*models.Notification) int {
+	if len(s.channels) == 0 {
+		return 0
+	}
+
+	recipients, err := s.userRepo.FindFarmUserEmails(farmID, AlertRecipientRoles)
+	if err != nil {
+		log.Printf("Erro ao buscar destinatários dos alertas da fazenda %d: %v", farmID, err)
+	}
+
+	message := notification.Message{
+		FarmID:     farmID,
+		Recipients: recipients,
+		Items:      make([]notification.Item, len(notifications)),
+	}
+	for i, n := range notifications {
+		message.Items[i] = notification.Item{
+			ID:        n.ID,
+			Type:      n.Type,
+			Title:     n.Title,
+			Message:   n.Message,
+			AnimalID:  n.AnimalID,
+			DueDate:   n.DueDate,
+			CreatedAt: n.CreatedAt,
+		}
+	}
+
+	failures := 0
+	for _, channel := range s.channels {
+		if err := channel.Send(ctx, message); err != nil {
+			log.Printf("Erro ao enviar alertas da fazenda %d pelo canal %s: %v", farmID, channel.Name(), err)
+			failures++
+		}
+	}
+	return failures
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type alertReproductionRepository struct {
+	repository.ReproductionRepositoryInterface
+	reproductions []models.Reproduction
+}
+
+func (r alertReproductionRepository) FindActiveByPhases(phases []models.ReproductionPhase) ([]models.Reproduction, error) {
+	return r.reproductions, nil
+}
+
+type alertEventRepository struct {
+	repository.ReproductionEventRepositoryInterface
+	failingAnimals map[uint]bool
+}
+
+func (r alertEventRepository) FindByAnimalID(animalID, farmID uint) ([]models.ReproductionEvent, error) {
+	if r.failingAnimals[animalID] {
+		return nil, errors.New("falha ao consultar eventos")
+	}
+	return nil, nil
+}
+
+type alertNotificationRepository struct {
+	repository.NotificationRepository
+	created []*models.Notification
+}
+
+func (r *alertNotificationRepository) CreateIfNotExists(ctx context.Context, notification *models.Notification) (bool, error) {
+	notification.ID = uint(len(r.created) + 1)
+	r.created = append(r.created, notification)
+	return true, nil
+}
+
+func TestGenerateAlertsContinuesAfterReproductionFailure(t *testing.T) {
+	inseminated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reproductions := make([]models.Reproduction, 3)
+	for i := range reproductions {
+		animalID := uint(10 + i)
+		reproductions[i] = models.Reproduction{
+			ID:               uint(i + 1),
+			AnimalID:         animalID,
+			Animal:           models.Animal{ID: animalID, FarmID: ownerFarmID, AnimalName: "Vaca", EarTagNumberLocal: int(animalID)},
+			CurrentPhase:     models.PhaseVazias,
+			InseminationDate: &inseminated,
+		}
+	}
+	notifications := &alertNotificationRepository{}
+	service := NewAlertService(
+		alertReproductionRepository{reproductions: reproductions},
+		alertEventRepository{failingAnimals: map[uint]bool{11: true}},
+		notifications,
+		nil,
+		nil,
+	)
+
+	summary, err := service.GenerateAlerts(context.Background(), inseminated.AddDate(0, 0, AlertPregnancyCheckDays+1))
+	if err != nil {
+		t.Fatalf("GenerateAlerts: %v", err)
+	}
+	if summary.ReproductionsScanned != 3 || summary.ReproductionsFailed != 1 {
+		t.Fatalf("scanned = %d, failed = %d; want 3 and 1", summary.ReproductionsScanned, summary.ReproductionsFailed)
+	}
+	if summary.NotificationsCreated != 2 || summary.FarmsNotified != 1 || len(notifications.created) != 2 {
+		t.Fatalf("notifications = %d (stored %d), farms = %d; want 2 notifications for 1 farm", summary.NotificationsCreated, len(notifications.created), summary.FarmsNotified)
+	}
+	for _, n := range notifications.created {
+		if n.AnimalID == nil || *n.AnimalID == 11 || n.Type != models.NotificationPregnancyCheckDue {
+			t.Fatalf("unexpected notification %+v", n)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
+	"github.com/fazendapro/FazendaPro-api/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	AnimalAttachmentMaxSize   = 20 << 20
+	AnimalAttachmentKeyPrefix = "attachments/"
+
+	attachmentContentTypePDF   = "application/pdf"
+	attachmentFileNameMaxRunes = 255
+)
+
+type AnimalAttachmentService interface {
+	UploadAttachment(ctx context.Context, attachment *models.AnimalAttachment, data []byte) error
+	GetAttachments(ctx context.Context, animalID, farmID uint, kind string) ([]*models.AnimalAttachment, error)
+	OpenAttachment(ctx context.Context, id, animalID, farmID uint, thumbnail bool) (*models.AnimalAttachment, *storage.Object, error)
+	DeleteAttachment(ctx context.Context, id, animalID, farmID uint) error
+	RemoveFiles(ctx context.Context, attachments []*models.AnimalAttachment)
+}
+
+type animalAttachmentService struct {
+	attachmentRepo repository.AnimalAttachmentRepository
+	animalRepo     repository.AnimalRepositoryInterface
+	storage        storage.BlobStorage
+}
+
+func NewAnimalAttachmentService(attachmentRepo repository.AnimalAttachmentRepository, animalRepo repository.AnimalRepositoryInterface, blobStorage storage.BlobStorage) AnimalAttachmentService {
+	return &animalAttachmentService{
+		attachmentRepo: attachmentRepo,
+		animalRepo:     animalRepo,
+		storage:        blobStorage,
+	}
+}
+
+func (s *animalAttachmentService) checkAnimal(animalID, farmID uint) error {
+	animal, err := s.animalRepo.FindByIDAndFarmID(animalID, farmID)
+	if err != nil {
+		return err
+	}
+	if animal == nil {
+		return errors.New(ErrAnimalNotFound)
+	}
+	return nil
+}
+
+func (s *animalAttachmentService) UploadAttachment(ctx context.Context, attachment *models.AnimalAttachment, data []byte) error {
+	if len(data) > AnimalAttachmentMaxSize {
+		return errors.New(ErrAnimalAttachmentTooLarge)
+	}
+	if attachment.Kind != "" && !models.IsValidAnimalAttachmentKind(attachment.Kind) {
+		return errors.New(ErrInvalidAnimalAttachmentKind)
+	}
+	if err := s.checkAnimal(attachment.AnimalID, attachment.FarmID); err != nil {
+		return err
+	}
+
+	contentType, extension, isImage, err := detectAttachmentType(data)
+	if err != nil {
+		return err
+	}
+	if attachment.Kind == "" {
+		attachment.Kind = models.AnimalAttachmentKindOther
+		if isImage {
+			attachment.Kind = models.AnimalAttachmentKindPhoto
+		}
+	}
+	if attachment.Kind == models.AnimalAttachmentKindPhoto && !isImage {
+		return errors.New(ErrAnimalAttachmentPhotoNotImage)
+	}
+
+	prefix := fmt.Sprintf("%s%d/%d/%s", AnimalAttachmentKeyPrefix, attachment.FarmID, attachment.AnimalID, uuid.NewString())
+	attachment.StorageKey = prefix + extension
+	if isImage {
+		thumbnail, err := utils.MakeThumbnail(data, AnimalPhotoThumbnailSize)
+		if err != nil {
+			return err
+		}
+		attachment.ThumbnailKey = prefix + "-thumb.jpg"
+		if err := s.storage.Put(ctx, attachment.ThumbnailKey, thumbnail, utils.ImageContentTypeJPEG); err != nil {
+			return fmt.Errorf("erro ao salvar miniatura: %w", err)
+		}
+	}
+	if err := s.storage.Put(ctx, attachment.StorageKey, data, contentType); err != nil {
+		deleteStoredFiles(ctx, s.storage, attachment.ThumbnailKey)
+		return fmt.Errorf("erro ao salvar anexo: %w", err)
+	}
+
+	attachment.ContentType = contentType
+	attachment.Size = int64(len(data))
+	attachment.FileName = sanitizeAttachmentFileName(attachment.FileName, attachment.Kind+extension)
+	attachment.Description = strings.TrimSpace(attachment.Description)
+
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		deleteStoredFiles(ctx, s.storage, attachment.StorageKey, attachment.ThumbnailKey)
+		return err
+	}
+	return nil
+}
+
+func (s *animalAttachmentService) GetAttachments(ctx context.Context, animalID, farmID uint, kind string) ([]*models.AnimalAttachment, error) {
+	if kind != "" && !models.IsValidAnimalAttachmentKind(kind) {
+		return nil, errors.New(ErrInvalidAnimalAttachmentKind)
+	}
+	if err := s.checkAnimal(animalID, farmID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.GetByAnimalID(ctx, animalID, farmID, kind)
+}
+
+func (s *animalAttachmentService) OpenAttachment(ctx context.Context, id, animalID, farmID uint, thumbnail bool) (*models.AnimalAttachment, *storage.Object, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, id, animalID, farmID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == "" {
+			return nil, nil, storage.ErrNotFound
+		}
+		key = attachment.ThumbnailKey
+	}
+
+	object, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, object, nil
+}
+
+func (s *animalAttachmentService) DeleteAttachment(ctx context.Context, id, animalID, farmID uint) error {
+	attachment, err := s.attachmentRepo.GetByID(ctx, id, animalID, farmID)
+	if err != nil {
+		return err
+	}
+	if err := s.attachmentRepo.Delete(ctx, id, animalID, farmID); err != nil {
+		return err
+	}
+	s.RemoveFiles(ctx, []*models.AnimalAttachment{attachment})
+	return nil
+}
+
+func (s *animalAttachmentService) RemoveFiles(ctx context.Context, attachments []*models.AnimalAttachment) {
+	for _, attachment := range attachments {
+		deleteStoredFiles(ctx, s.storage, attachment.StorageKey, attachment.ThumbnailKey)
+	}
+}
+
+func detectAttachmentType(data []byte) (string, string, bool, error) {
+	contentType, extension, err := utils.DetectImageType(data)
+	if err == nil {
+		return contentType, extension, true, nil
+	}
+	if errors.Is(err, utils.ErrImageTooLarge) {
+		return "", "", false, err
+	}
+	if http.DetectContentType(data) == attachmentContentTypePDF {
+		return attachmentContentTypePDF, ".pdf", false, nil
+	}
+	return "", "", false, errors.New(ErrUnsupportedAnimalAttachment)
+}
+
+func sanitizeAttachmentFileName(name, fallback string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(name, "")))
+	if name == "" || name == "." || name == "/" {
+		return fallback
+	}
+	if utf8.RuneCountInString(name) > attachmentFileNameMaxRunes {
+		name = string([]rune(name)[:attachmentFileNameMaxRunes])
+	}
+	return name
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/utils"
+)
+
+const (
+	AnimalImportFormatCSV  = "csv"
+	AnimalImportFormatXLSX = "xlsx"
+	AnimalImportMaxRows    = 5000
+)
+
+type AnimalImportRowError struct {
+	Row     int
+	EarTag  int
+	Message string
+}
+
+type AnimalImportResult struct {
+	DryRun     bool
+	TotalRows  int
+	ValidRows  int
+	Imported   int
+	Duplicates []AnimalImportRowError
+	Errors     []AnimalImportRowError
+}
+
+var animalImportColumns = map[string][]string{
+	"ear_tag":        {"ear_tag", "ear_tag_number_local", "brinco", "brinco_local", "brinca"},
+	"ear_tag_number": {"ear_tag_number_register", "registro", "brinco_registro"},
+	"animal_name":    {"animal_name", "name", "nome"},
+	"sex":            {"sex", "sexo"},
+	"breed":          {"breed", "raca", "raça"},
+	"type":           {"type", "tipo"},
+	"birth_date":     {"birth_date", "nascimento", "data_nascimento", "data_de_nascimento"},
+	"father_ear_tag": {"father_ear_tag", "brinco_pai", "pai"},
+	"mother_ear_tag": {"mother_ear_tag", "brinco_mae", "brinco_mãe", "mae", "mãe"},
+	"animal_type":    {"animal_type", "categoria"},
+	"purpose":        {"purpose", "proposito", "propósito", "finalidade"},
+	"confinement":    {"confinement", "confinado", "confinamento"},
+	"fertilization":  {"fertilization", "inseminada", "fertilizacao", "fertilização"},
+	"castrated":      {"castrated", "castrado"},
+	"current_batch":  {"current_batch", "batch", "lote"},
+}
+
+type animalImportRow struct {
+	line      int
+	animal    *models.Animal
+	fatherTag int
+	motherTag int
+	failed    bool
+}
+
+func (s *AnimalService) ImportAnimals(farmID uint, format string, reader io.Reader, dryRun bool) (*AnimalImportResult, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo: %w", err)
+	}
+
+	records, err := readImportRecords(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("arquivo deve ter uma linha de cabeçalho")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		name = strings.ReplaceAll(name, " ", "_")
+		for column, aliases := range animalImportColumns {
+			for _, alias := range aliases {
+				if name == alias {
+					columns[column] = i
+				}
+			}
+		}
+	}
+	if _, ok := columns["ear_tag"]; !ok {
+		return nil, errors.New("coluna de brinco (ear_tag) é obrigatória")
+	}
+
+	existingAnimals, err := s.repository.FindByFarmID(farmID)
+	if err != nil {
+		return nil, err
+	}
+	existingByTag := make(map[int]*models.Animal, len(existingAnimals))
+	for i := range existingAnimals {
+		existingByTag[existingAnimals[i].EarTagNumberLocal] = &existingAnimals[i]
+	}
+
+	result := &AnimalImportResult{DryRun: dryRun}
+	var rows []*animalImportRow
+	fileTags := make(map[int]int)
+
+	for i, record := range records[1:] {
+		line := i + 2
+		if isBlankImportRecord(record) {
+			continue
+		}
+		result.TotalRows++
+		if result.TotalRows > AnimalImportMaxRows {
+			return nil, fmt.Errorf("o arquivo pode ter no máximo %d animais", AnimalImportMaxRows)
+		}
+
+		field := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		row, err := parseAnimalImportRow(field)
+		if err != nil {
+			result.Errors = append(result.Errors, AnimalImportRowError{Row: line, Message: err.Error()})
+			continue
+		}
+		row.line = line
+		row.animal.FarmID = farmID
+		earTag := row.animal.EarTagNumberLocal
+
+		if err := validateNewAnimal(row.animal); err != nil {
+			result.Errors = append(result.Errors, AnimalImportRowError{Row: line, EarTag: earTag, Message: err.Error()})
+			continue
+		}
+		if previous, duplicated := fileTags[earTag]; duplicated {
+			result.Errors = append(result.Errors, AnimalImportRowError{Row: line, EarTag: earTag, Message: fmt.Sprintf("brinco repetido no arquivo (linha %d)", previous)})
+			continue
+		}
+		fileTags[earTag] = line
+
+		if _, exists := existingByTag[earTag]; exists {
+			result.Duplicates = append(result.Duplicates, AnimalImportRowError{Row: line, EarTag: earTag, Message: ErrAnimalEarTagAlreadyExists})
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	fatherRefs, motherRefs := resolveAnimalImportParents(rows, existingByTag, result)
+	checkAnimalImportCycles(rows, fatherRefs, motherRefs, result)
+
+	for _, row := range rows {
+		if !row.failed {
+			result.ValidRows++
+		}
+	}
+
+	if dryRun || len(result.Errors) > 0 || len(rows) == 0 {
+		return result, nil
+	}
+
+	animals := make([]*models.Animal, len(rows))
+	for i, row := range rows {
+		animals[i] = row.animal
+	}
+	if err := s.repository.CreateWithParents(animals, fatherRefs, motherRefs); err != nil {
+		return nil, err
+	}
+	result.Imported = len(animals)
+
+	cacheKey := fmt.Sprintf(CacheKeyAnimalsFarm, farmID)
+	if err := s.cache.Delete(cacheKey); err != nil {
+		log.Printf(ErrInvalidateCache, err)
+	}
+
+	return result, nil
+}
+
+func resolveAnimalImportParents(rows []*animalImportRow, existingByTag map[int]*models.Animal, result *AnimalImportResult) (map[int]int, map[int]int) {
+	indexByTag := make(map[int]int, len(rows))
+	for i, row := range rows {
+		indexByTag[row.animal.EarTagNumberLocal] = i
+	}
+
+	fatherRefs := make(map[int]int)
+	motherRefs := make(map[int]int)
+	for i, row := range rows {
+		parents := []struct {
+			tag   int
+			sex   int
+			label string
+			refs  map[int]int
+			id    **uint
+		}{
+			{row.fatherTag, models.AnimalSexMale, "pai", fatherRefs, &row.animal.FatherID},
+			{row.motherTag, models.AnimalSexFemale, "mãe", motherRefs, &row.animal.MotherID},
+		}
+
+		for _, parent := range parents {
+			if parent.tag == 0 {
+				continue
+			}
+
+			var message string
+			if parent.tag == row.animal.EarTagNumberLocal {
+				message = fmt.Sprintf("animal não pode ser %s de si mesmo", parent.label)
+			} else if index, inFile := indexByTag[parent.tag]; inFile {
+				if rows[index].animal.Sex != parent.sex {
+					message = fmt.Sprintf("%s com brinco %d tem o sexo incompatível", parent.label, parent.tag)
+				} else {
+					parent.refs[i] = index
+				}
+			} else if existing, found := existingByTag[parent.tag]; found {
+				if existing.Sex != parent.sex {
+					message = fmt.Sprintf("%s com brinco %d tem o sexo incompatível", parent.label, parent.tag)
+				} else {
+					id := existing.ID
+					*parent.id = &id
+				}
+			} else {
+				message = fmt.Sprintf("%s com brinco %d não encontrado na fazenda nem no arquivo", parent.label, parent.tag)
+			}
+
+			if message != "" {
+				row.failed = true
+				result.Errors = append(result.Errors, AnimalImportRowError{Row: row.line, EarTag: row.animal.EarTagNumberLocal, Message: message})
+			}
+		}
+	}
+
+	return fatherRefs, motherRefs
+}
+
+func checkAnimalImportCycles(rows []*animalImportRow, fatherRefs, motherRefs map[int]int, result *AnimalImportResult) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(rows))
+
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		if state[i] == visiting {
+			return true
+		}
+		if state[i] == done {
+			return false
+		}
+		state[i] = visiting
+		cycle := false
+		for _, refs := range []map[int]int{fatherRefs, motherRefs} {
+			if parent, ok := refs[i]; ok && visit(parent) {
+				cycle = true
+			}
+		}
+		state[i] = done
+		return cycle
+	}
+
+	for i, row := range rows {
+		if state[i] == unvisited && visit(i) {
+			row.failed = true
+			result.Errors = append(result.Errors, AnimalImportRowError{Row: row.line, EarTag: row.animal.EarTagNumberLocal, Message: "ciclo de parentesco no arquivo"})
+		}
+	}
+}
+
+func parseAnimalImportRow(field func(string) string) (*animalImportRow, error) {
+	animal := &models.Animal{
+		AnimalName: field("animal_name"),
+		Breed:      field("breed"),
+		Type:       field("type"),
+		Status:     models.AnimalStatusActive,
+	}
+	row := &animalImportRow{animal: animal}
+
+	ints := []struct {
+		column string
+		label  string
+		target *int
+	}{
+		{"ear_tag", "brinco", &animal.EarTagNumberLocal},
+		{"ear_tag_number", "registro", &animal.EarTagNumberRegister},
+		{"animal_type", "tipo de animal", &animal.AnimalType},
+		{"current_batch", "lote", &animal.CurrentBatch},
+		{"father_ear_tag", "brinco do pai", &row.fatherTag},
+		{"mother_ear_tag", "brinco da mãe", &row.motherTag},
+	}
+	for _, column := range ints {
+		value, err := parseImportInt(field(column.column))
+		if err != nil {
+			return nil, fmt.Errorf("%s inválido: %q", column.label, field(column.column))
+		}
+		*column.target = value
+	}
+
+	sex, err := parseAnimalImportSex(field("sex"))
+	if err != nil {
+		return nil, err
+	}
+	animal.Sex = sex
+
+	purpose, err := parseAnimalImportPurpose(field("purpose"))
+	if err != nil {
+		return nil, err
+	}
+	animal.Purpose = purpose
+
+	if value := field("birth_date"); value != "" {
+		birthDate, err := parseAnimalImportDate(value)
+		if err != nil {
+			return nil, err
+		}
+		if birthDate.After(time.Now()) {
+			return nil, errors.New("data de nascimento não pode ser futura")
+		}
+		animal.BirthDate = &birthDate
+	}
+
+	bools := []struct {
+		column string
+		target *bool
+	}{
+		{"confinement", &animal.Confinement},
+		{"fertilization", &animal.Fertilization},
+		{"castrated", &animal.Castrated},
+	}
+	for _, column := range bools {
+		value, err := parseImportBool(field(column.column))
+		if err != nil {
+			return nil, err
+		}
+		*column.target = value
+	}
+
+	return row, nil
+}
+
+func readImportRecords(data []byte, format string) ([][]string, error) {
+	switch format {
+	case AnimalImportFormatXLSX:
+		return utils.ReadXLSXRows(data)
+	case AnimalImportFormatCSV:
+		csvReader := csv.NewReader(bytes.NewReader(data))
+		csvReader.FieldsPerRecord = -1
+		csvReader.TrimLeadingSpace = true
+		if strings.Contains(strings.SplitN(string(data), "\n", 2)[0], ";") {
+			csvReader.Comma = ';'
+		}
+		records, err := csvReader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("arquivo CSV inválido: %w", err)
+		}
+		return records, nil
+	}
+	return nil, fmt.Errorf("formato de arquivo não suportado: %q, use csv ou xlsx", format)
+}
+
+func isBlankImportRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseImportInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || number != math.Trunc(number) || math.Abs(number) > math.MaxInt32 {
+		return 0, fmt.Errorf("número inteiro inválido: %q", value)
+	}
+	return int(number), nil
+}
+
+func parseImportBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "0", "false", "nao", "não", "n", "no":
+		return false, nil
+	case "1", "true", "sim", "s", "yes", "y":
+		return true, nil
+	}
+	return false, fmt.Errorf("valor booleano inválido: %q, use sim ou não", value)
+}
+
+func parseAnimalImportSex(value string) (int, error) {
+	switch strings.ToLower(value) {
+	case "0", "f", "femea", "fêmea", "female":
+		return models.AnimalSexFemale, nil
+	case "1", "m", "macho", "male":
+		return models.AnimalSexMale, nil
+	}
+	return 0, fmt.Errorf("sexo inválido: %q, use F (Fêmea) ou M (Macho)", value)
+}
+
+func parseAnimalImportPurpose(value string) (int, error) {
+	switch strings.ToLower(value) {
+	case "", "0", "carne", "corte", "meat", "beef":
+		return 0, nil
+	case "1", "leite", "milk", "dairy":
+		return 1, nil
+	case "2", "reproducao", "reprodução", "breeding":
+		return 2, nil
+	}
+	return 0, fmt.Errorf("propósito inválido: %q, use carne, leite ou reprodução", value)
+}
+
+func parseAnimalImportDate(value string) (time.Time, error) {
+	if date, err := parseImportDate(value); err == nil {
+		return date, nil
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial >= 1 && serial < 2958466 {
+		return time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(serial)), nil
+	}
+	return time.Time{}, fmt.Errorf("data de nascimento inválida: %q, use AAAA-MM-DD ou DD/MM/AAAA", value)
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/fazendapro/FazendaPro-api/internal/cache"
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
+	"github.com/fazendapro/FazendaPro-api/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	AnimalPhotoMaxSize       = 10 << 20
+	AnimalPhotoThumbnailSize = 320
+	AnimalPhotoKeyPrefix     = "animals/"
+
+	photoMigrationBatchSize = 100
+)
+
+type AnimalPhotoService interface {
+	UploadPhoto(ctx context.Context, animalID, farmID uint, data []byte) (*models.Animal, error)
+	GetPhoto(ctx context.Context, key string, farmID uint) (*storage.Object, error)
+	RemovePhotos(ctx context.Context, animal *models.Animal)
+	MigrateLegacyPhotos(ctx context.Context, dryRun bool) (*PhotoMigrationSummary, error)
+}
+
+type PhotoMigrationSummary struct {
+	Scanned  int
+	Migrated int
+	Failed   int
+}
+
+type animalPhotoService struct {
+	animalRepo repository.AnimalRepositoryInterface
+	storage    storage.BlobStorage
+	cache      cache.CacheInterface
+}
+
+func NewAnimalPhotoService(animalRepo repository.AnimalRepositoryInterface, blobStorage storage.BlobStorage, cacheClient cache.CacheInterface) AnimalPhotoService {
+	return &animalPhotoService{
+		animalRepo: animalRepo,
+		storage:    blobStorage,
+		cache:      cacheClient,
+	}
+}
+
+func (s *animalPhotoService) UploadPhoto(ctx context.Context, animalID, farmID uint, data []byte) (*models.Animal, error) {
+	if len(data) > AnimalPhotoMaxSize {
+		return nil, errors.New(ErrAnimalPhotoTooLarge)
+	}
+
+	animal, err := s.animalRepo.FindByIDAndFarmID(animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	if animal == nil {
+		return nil, errors.New(ErrAnimalNotFound)
+	}
+
+	if err := s.replacePhoto(ctx, animal, data); err != nil {
+		return nil, err
+	}
+	s.invalidateFarm(farmID)
+
+	return s.animalRepo.FindByIDAndFarmID(animalID, farmID)
+}
+
+func (s *animalPhotoService) GetPhoto(ctx context.Context, key string, farmID uint) (*storage.Object, error) {
+	if !strings.HasPrefix(key, AnimalPhotoKeyPrefix) || !storage.ValidKey(key) {
+		return nil, storage.ErrNotFound
+	}
+
+	exists, err := s.animalRepo.ExistsWithPhotoKey(farmID, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, storage.ErrNotFound
+	}
+	return s.storage.Get(ctx, key)
+}
+
+func (s *animalPhotoService) RemovePhotos(ctx context.Context, animal *models.Animal) {
+	deleteStoredFiles(ctx, s.storage, animal.PhotoKey, animal.PhotoThumbnailKey)
+}
+
+func (s *animalPhotoService) MigrateLegacyPhotos(ctx context.Context, dryRun bool) (*PhotoMigrationSummary, error) {
+	summary := &PhotoMigrationSummary{}
+	farms := make(map[uint]bool)
+
+	var afterID uint
+	for {
+		animals, err := s.animalRepo.FindWithLegacyPhoto(afterID, photoMigrationBatchSize)
+		if err != nil {
+			return summary, err
+		}
+		if len(animals) == 0 {
+			break
+		}
+
+		for i := range animals {
+			animal := &animals[i]
+			afterID = animal.ID
+			summary.Scanned++
+
+			data, ok := decodeBase64Image(animal.Photo)
+			if !ok {
+				log.Printf("Foto do animal %d não é base64 válido, mantida no banco", animal.ID)
+				summary.Failed++
+				continue
+			}
+			if _, _, err := utils.DetectImageType(data); err != nil {
+				log.Printf("Foto do animal %d não pôde ser migrada: %v", animal.ID, err)
+				summary.Failed++
+				continue
+			}
+			if dryRun {
+				summary.Migrated++
+				continue
+			}
+
+			if err := s.replacePhoto(ctx, animal, data); err != nil {
+				log.Printf("Foto do animal %d não pôde ser migrada: %v", animal.ID, err)
+				summary.Failed++
+				continue
+			}
+			farms[animal.FarmID] = true
+			summary.Migrated++
+		}
+	}
+
+	for farmID := range farms {
+		s.invalidateFarm(farmID)
+	}
+	return summary, nil
+}
+
+func (s *animalPhotoService) replacePhoto(ctx context.Context, animal *models.Animal, data []byte) error {
+	photoKey, thumbnailKey, err := storeAnimalPhoto(ctx, s.storage, data)
+	if err != nil {
+		return err
+	}
+
+	if err := s.animalRepo.UpdatePhoto(animal.ID, animal.FarmID, photoKey, thumbnailKey); err != nil {
+		deleteStoredFiles(ctx, s.storage, photoKey, thumbnailKey)
+		return err
+	}
+
+	deleteStoredFiles(ctx, s.storage, animal.PhotoKey, animal.PhotoThumbnailKey)
+	return nil
+}
+
+func (s *animalPhotoService) invalidateFarm(farmID uint) {
+	cacheKey := fmt.Sprintf(CacheKeyAnimalsFarm, farmID)
+	if err := s.cache.Delete(cacheKey); err != nil {
+		log.Printf(ErrInvalidateCache, err)
+	}
+}
+
+func storeAnimalPhoto(ctx context.Context, blobStorage storage.BlobStorage, data []byte) (string, string, error) {
+	contentType, extension, err := utils.DetectImageType(data)
+	if err != nil {
+		return "", "", err
+	}
+	thumbnail, err := utils.MakeThumbnail(data, AnimalPhotoThumbnailSize)
+	if err != nil {
+		return "", "", err
+	}
+
+	id := uuid.NewString()
+	photoKey := AnimalPhotoKeyPrefix + id + extension
+	thumbnailKey := AnimalPhotoKeyPrefix + id + "-thumb.jpg"
+
+	if err := blobStorage.Put(ctx, photoKey, data, contentType); err != nil {
+		return "", "", fmt.Errorf("erro ao salvar foto: %w", err)
+	}
+	if err := blobStorage.Put(ctx, thumbnailKey, thumbnail, utils.ImageContentTypeJPEG); err != nil {
+		deleteStoredFiles(ctx, blobStorage, photoKey)
+		return "", "", fmt.Errorf("erro ao salvar miniatura: %w", err)
+	}
+	return photoKey, thumbnailKey, nil
+}
+
+func deleteStoredFiles(ctx context.Context, blobStorage storage.BlobStorage, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := blobStorage.Delete(ctx, key); err != nil {
+			log.Printf("Erro ao remover arquivo %s (não crítico): %v", key, err)
+		}
+	}
+}
+
+func decodeBase64Image(value string) ([]byte, bool) {
+	if value == "" {
+		return nil, false
+	}
+	if strings.HasPrefix(value, "data:") {
+		meta, payload, found := strings.Cut(value, ",")
+		if !found || !strings.HasSuffix(meta, ";base64") {
+			return nil, false
+		}
+		value = payload
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
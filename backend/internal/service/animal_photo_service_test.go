@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
+)
+
+type fakeBlobStorage struct {
+	storage.BlobStorage
+	objects map[string]string
+}
+
+func (s *fakeBlobStorage) Get(ctx context.Context, key string) (*storage.Object, error) {
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return &storage.Object{Body: io.NopCloser(strings.NewReader(data)), ContentType: "image/jpeg", Size: int64(len(data))}, nil
+}
+
+func (r *fakeAnimalRepository) ExistsWithPhotoKey(farmID uint, key string) (bool, error) {
+	for _, animal := range r.animals {
+		if animal.FarmID == farmID && (animal.PhotoKey == key || animal.PhotoThumbnailKey == key) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestGetPhotoRejectsOtherFarm(t *testing.T) {
+	animals, _, _ := newFarmScopeFixture()
+	animals.animals[10].PhotoKey = "animals/mimosa.jpg"
+	animals.animals[10].PhotoThumbnailKey = "animals/mimosa-thumb.jpg"
+	blobs := &fakeBlobStorage{objects: map[string]string{
+		"animals/mimosa.jpg":       "foto",
+		"animals/mimosa-thumb.jpg": "miniatura",
+	}}
+	service := NewAnimalPhotoService(animals, blobs, fakeCache{})
+
+	if _, err := service.GetPhoto(context.Background(), "animals/mimosa.jpg", otherFarmID); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("GetPhoto from other farm = %v, want %v", err, storage.ErrNotFound)
+	}
+
+	for _, key := range []string{"animals/mimosa.jpg", "animals/mimosa-thumb.jpg"} {
+		object, err := service.GetPhoto(context.Background(), key, ownerFarmID)
+		if err != nil {
+			t.Fatalf("GetPhoto(%s) from owner farm: %v", key, err)
+		}
+		object.Body.Close()
+	}
+}
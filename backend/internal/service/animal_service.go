@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -13,18 +14,59 @@ import (
 
 type AnimalService struct {
 	repository repository.AnimalRepositoryInterface
+	weightRepo repository.WeightRepository
 	cache      cache.CacheInterface
 }
 
-func NewAnimalService(repository repository.AnimalRepositoryInterface, cacheClient cache.CacheInterface) *AnimalService {
+func NewAnimalService(repository repository.AnimalRepositoryInterface, weightRepo repository.WeightRepository, cacheClient cache.CacheInterface) *AnimalService {
 	return &AnimalService{
 		repository: repository,
+		weightRepo: weightRepo,
 		cache:      cacheClient,
 	}
 }
 
 func (s *AnimalService) CreateAnimal(animal *models.Animal) error {
 	log.Println("Creating animal", animal)
+	if err := validateNewAnimal(animal); err != nil {
+		return err
+	}
+
+	if err := s.checkParentsFarm(animal); err != nil {
+		return err
+	}
+
+	existingAnimal, err := s.repository.FindByEarTagNumber(animal.FarmID, animal.EarTagNumberLocal)
+	if err != nil {
+		return err
+	}
+
+	if existingAnimal != nil {
+		return errors.New(ErrAnimalEarTagAlreadyExists)
+	}
+
+	if animal.Status == 0 {
+		animal.Status = 0
+	}
+
+	now := time.Now()
+	animal.CreatedAt = now
+	animal.UpdatedAt = now
+
+	err = s.repository.Create(animal)
+	if err != nil {
+		return err
+	}
+
+	cacheKey := fmt.Sprintf(CacheKeyAnimalsFarm, animal.FarmID)
+	if err := s.cache.Delete(cacheKey); err != nil {
+		log.Printf(ErrInvalidateCache, err)
+	}
+
+	return nil
+}
+
+func validateNewAnimal(animal *models.Animal) error {
 	if animal.FarmID == 0 {
 		return errors.New("farm ID é obrigatório")
 	}
@@ -57,38 +99,11 @@ func (s *AnimalService) CreateAnimal(animal *models.Animal) error {
 		return errors.New("propósito deve ser 0 (Carne), 1 (Leite) ou 2 (Reprodução)")
 	}
 
-	existingAnimal, err := s.repository.FindByEarTagNumber(animal.FarmID, animal.EarTagNumberLocal)
-	if err != nil {
-		return err
-	}
-
-	if existingAnimal != nil {
-		return errors.New("já existe um animal com este número de brinca nesta fazenda")
-	}
-
-	if animal.Status == 0 {
-		animal.Status = 0
-	}
-
-	now := time.Now()
-	animal.CreatedAt = now
-	animal.UpdatedAt = now
-
-	err = s.repository.Create(animal)
-	if err != nil {
-		return err
-	}
-
-	cacheKey := fmt.Sprintf(CacheKeyAnimalsFarm, animal.FarmID)
-	if err := s.cache.Delete(cacheKey); err != nil {
-		log.Printf(ErrInvalidateCache, err)
-	}
-
 	return nil
 }
 
-func (s *AnimalService) GetAnimalByID(id uint) (*models.Animal, error) {
-	return s.repository.FindByID(id)
+func (s *AnimalService) GetAnimalByID(id, farmID uint) (*models.Animal, error) {
+	return s.repository.FindByIDAndFarmID(id, farmID)
 }
 
 func (s *AnimalService) GetAnimalsByFarmID(farmID uint) ([]models.Animal, error) {
@@ -114,12 +129,42 @@ func (s *AnimalService) GetAnimalsByFarmID(farmID uint) ([]models.Animal, error)
 	return animals, nil
 }
 
-func (s *AnimalService) UpdateAnimal(animal *models.Animal) error {
+func (s *AnimalService) GetWeightSummary(animalID, farmID uint) (*AnimalWeightSummary, error) {
+	weights, err := s.weightRepo.GetByAnimalID(context.Background(), animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+	summary := buildWeightSummary(weights)
+	summary.AnimalID = animalID
+	return summary, nil
+}
+
+func (s *AnimalService) GetWeightSummaries(farmID uint) (map[uint]*AnimalWeightSummary, error) {
+	weights, err := s.weightRepo.GetLatestByFarmID(context.Background(), farmID, WeightSummaryLatestCount)
+	if err != nil {
+		return nil, err
+	}
+
+	byAnimal := make(map[uint][]*models.Weight)
+	for _, weight := range weights {
+		byAnimal[weight.AnimalID] = append(byAnimal[weight.AnimalID], weight)
+	}
+
+	summaries := make(map[uint]*AnimalWeightSummary, len(byAnimal))
+	for animalID, animalWeights := range byAnimal {
+		summary := buildWeightSummary(animalWeights)
+		summary.AnimalID = animalID
+		summaries[animalID] = summary
+	}
+	return summaries, nil
+}
+
+func (s *AnimalService) UpdateAnimal(animal *models.Animal, farmID uint) error {
 	if animal.ID == 0 {
 		return errors.New("ID do animal é obrigatório")
 	}
 
-	existingAnimal, err := s.repository.FindByID(animal.ID)
+	existingAnimal, err := s.repository.FindByIDAndFarmID(animal.ID, farmID)
 	if err != nil {
 		return err
 	}
@@ -142,6 +187,10 @@ func (s *AnimalService) UpdateAnimal(animal *models.Animal) error {
 
 	animal.FarmID = existingAnimal.FarmID
 
+	if err := s.checkParentsFarm(animal); err != nil {
+		return err
+	}
+
 	now := time.Now()
 	animal.UpdatedAt = now
 
@@ -158,12 +207,12 @@ func (s *AnimalService) UpdateAnimal(animal *models.Animal) error {
 	return nil
 }
 
-func (s *AnimalService) DeleteAnimal(id uint) error {
+func (s *AnimalService) DeleteAnimal(id, farmID uint) error {
 	if id == 0 {
 		return errors.New("ID do animal é obrigatório")
 	}
 
-	existingAnimal, err := s.repository.FindByID(id)
+	existingAnimal, err := s.repository.FindByIDAndFarmID(id, farmID)
 	if err != nil {
 		return err
 	}
@@ -172,8 +221,7 @@ func (s *AnimalService) DeleteAnimal(id uint) error {
 		return errors.New("animal não encontrado")
 	}
 
-	farmID := existingAnimal.FarmID
-	err = s.repository.Delete(id)
+	err = s.repository.Delete(id, farmID)
 	if err != nil {
 		return err
 	}
@@ -189,3 +237,32 @@ func (s *AnimalService) DeleteAnimal(id uint) error {
 func (s *AnimalService) GetAnimalsByFarmIDAndSex(farmID uint, sex int) ([]models.Animal, error) {
 	return s.repository.FindByFarmIDAndSex(farmID, sex)
 }
+
+func (s *AnimalService) checkParentsFarm(animal *models.Animal) error {
+	if animal.FatherID != nil {
+		if err := checkAnimalInFarm(s.repository, *animal.FatherID, animal.FarmID); err != nil {
+			return errors.New("pai não encontrado nesta fazenda")
+		}
+	}
+
+	if animal.MotherID != nil {
+		if err := checkAnimalInFarm(s.repository, *animal.MotherID, animal.FarmID); err != nil {
+			return errors.New("mãe não encontrada nesta fazenda")
+		}
+	}
+
+	return nil
+}
+
+func checkAnimalInFarm(animalRepo repository.AnimalRepositoryInterface, animalID, farmID uint) error {
+	animal, err := animalRepo.FindByIDAndFarmID(animalID, farmID)
+	if err != nil {
+		return err
+	}
+
+	if animal == nil {
+		return errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+
+	return nil
+}
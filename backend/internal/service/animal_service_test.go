@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type latestWeightRepository struct {
+	repository.WeightRepository
+	weights   []*models.Weight
+	perAnimal int
+}
+
+func (r *latestWeightRepository) GetLatestByFarmID(ctx context.Context, farmID uint, perAnimal int) ([]*models.Weight, error) {
+	r.perAnimal = perAnimal
+	return r.weights, nil
+}
+
+func TestGetWeightSummariesUsesLatestWeights(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	weights := &latestWeightRepository{weights: []*models.Weight{
+		{ID: 3, AnimalID: 10, Date: day(21), AnimalWeight: 420},
+		{ID: 2, AnimalID: 10, Date: day(11), AnimalWeight: 400},
+		{ID: 5, AnimalID: 11, Date: day(5), AnimalWeight: 180},
+	}}
+	service := NewAnimalService(nil, weights, fakeCache{})
+
+	summaries, err := service.GetWeightSummaries(ownerFarmID)
+	if err != nil {
+		t.Fatalf("GetWeightSummaries: %v", err)
+	}
+	if weights.perAnimal != WeightSummaryLatestCount {
+		t.Fatalf("requested %d weights per animal, want %d", weights.perAnimal, WeightSummaryLatestCount)
+	}
+
+	mimosa := summaries[10]
+	if mimosa == nil || mimosa.LatestWeight.ID != 3 || mimosa.AverageDailyGain == nil || *mimosa.AverageDailyGain != 2 {
+		t.Fatalf("summary of animal 10 = %+v, want latest weight 3 and 2 kg/day", mimosa)
+	}
+	calf := summaries[11]
+	if calf == nil || calf.LatestWeight.ID != 5 || calf.AverageDailyGain != nil {
+		t.Fatalf("summary of animal 11 = %+v, want latest weight 5 without gain", calf)
+	}
+}
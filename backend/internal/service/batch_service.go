@@ -1,49 +1,348 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"github.com/fazendapro/FazendaPro-api/internal/repository"
 )
 
+const (
+	BatchMaxRules      = 20
+	BatchMovesLimit    = 100
+	BatchMovesLimitMax = 500
+)
+
+type RebatchSummary struct {
+	FarmsProcessed   int
+	FarmsFailed      int
+	AnimalsEvaluated int
+	AnimalsMoved     int
+}
+
 type BatchService struct {
-	animalRepository repository.AnimalRepositoryInterface
-	milkRepository   repository.MilkCollectionRepositoryInterface
+	animalRepository       repository.AnimalRepositoryInterface
+	milkRepository         repository.MilkCollectionRepositoryInterface
+	reproductionRepository repository.ReproductionRepositoryInterface
+	batchRepository        repository.BatchRepositoryInterface
+	farmRepository         repository.FarmRepositoryInterface
 }
 
-func NewBatchService(animalRepository repository.AnimalRepositoryInterface, milkRepository repository.MilkCollectionRepositoryInterface) *BatchService {
+func NewBatchService(animalRepository repository.AnimalRepositoryInterface, milkRepository repository.MilkCollectionRepositoryInterface, reproductionRepository repository.ReproductionRepositoryInterface, batchRepository repository.BatchRepositoryInterface, farmRepository repository.FarmRepositoryInterface) *BatchService {
 	return &BatchService{
-		animalRepository: animalRepository,
-		milkRepository:   milkRepository,
+		animalRepository:       animalRepository,
+		milkRepository:         milkRepository,
+		reproductionRepository: reproductionRepository,
+		batchRepository:        batchRepository,
+		farmRepository:         farmRepository,
+	}
+}
+
+func (s *BatchService) GetRules(farmID uint) ([]models.BatchRule, error) {
+	return s.batchRepository.FindRulesByFarmID(farmID)
+}
+
+func (s *BatchService) SaveRules(farmID uint, rules []models.BatchRule) (*RebatchSummary, error) {
+	if len(rules) > BatchMaxRules {
+		return nil, fmt.Errorf("máximo de %d regras de lote por fazenda", BatchMaxRules)
+	}
+
+	for i := range rules {
+		rules[i].Priority = i + 1
+		if err := validateBatchRule(&rules[i]); err != nil {
+			return nil, fmt.Errorf("regra %d: %w", i+1, err)
+		}
+	}
+
+	if err := s.batchRepository.ReplaceRules(farmID, rules); err != nil {
+		return nil, err
 	}
+
+	return s.RebatchFarm(farmID, time.Now())
 }
 
-func (s *BatchService) UpdateAnimalBatch(animalID uint) error {
-	animal, err := s.animalRepository.FindByID(animalID)
+func validateBatchRule(rule *models.BatchRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return errors.New("nome do lote é obrigatório")
+	}
+
+	if rule.Batch < 1 {
+		return errors.New("número do lote deve ser maior que zero")
+	}
+
+	if rule.AverageWindowDays == 0 {
+		rule.AverageWindowDays = models.DefaultBatchAverageWindowDays
+	}
+	if rule.AverageWindowDays < 1 || rule.AverageWindowDays > models.MaxBatchAverageWindowDays {
+		return fmt.Errorf("janela da média deve estar entre 1 e %d dias", models.MaxBatchAverageWindowDays)
+	}
+
+	if (rule.MinLiters != nil && *rule.MinLiters < 0) || (rule.MaxLiters != nil && *rule.MaxLiters < 0) {
+		return errors.New("litros não podem ser negativos")
+	}
+	if rule.MinLiters != nil && rule.MaxLiters != nil && *rule.MinLiters >= *rule.MaxLiters {
+		return errors.New("litros mínimos devem ser menores que os máximos")
+	}
+
+	if rule.Phase != nil && (*rule.Phase < models.PhaseLactacao || *rule.Phase > models.PhasePrenhas) {
+		return errors.New("fase de reprodução inválida")
+	}
+
+	if (rule.MinDaysInMilk != nil && *rule.MinDaysInMilk < 0) || (rule.MaxDaysInMilk != nil && *rule.MaxDaysInMilk < 0) {
+		return errors.New("dias em lactação não podem ser negativos")
+	}
+	if rule.MinDaysInMilk != nil && rule.MaxDaysInMilk != nil && *rule.MinDaysInMilk >= *rule.MaxDaysInMilk {
+		return errors.New("dias em lactação mínimos devem ser menores que os máximos")
+	}
+
+	return nil
+}
+
+func (s *BatchService) GetMoves(farmID uint, animalID *uint, limit int) ([]models.BatchMove, error) {
+	if limit <= 0 {
+		limit = BatchMovesLimit
+	}
+	if limit > BatchMovesLimitMax {
+		limit = BatchMovesLimitMax
+	}
+
+	if animalID != nil {
+		if err := checkAnimalInFarm(s.animalRepository, *animalID, farmID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.batchRepository.FindMoves(farmID, animalID, limit)
+}
+
+func (s *BatchService) UpdateAnimalBatch(animalID, farmID uint) error {
+	animal, err := s.animalRepository.FindByIDAndFarmID(animalID, farmID)
+	if err != nil {
+		return err
+	}
+
+	if animal == nil {
+		return errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+
+	rules, err := s.batchRepository.FindRulesByFarmID(farmID)
+	if err != nil {
+		return err
+	}
+
+	milkCollections, err := s.milkRepository.FindByAnimalID(animalID, farmID)
 	if err != nil {
 		return err
 	}
 
-	milkCollections, err := s.milkRepository.FindByAnimalID(animalID)
+	reproduction, err := s.reproductionRepository.FindByAnimalID(animalID, farmID)
 	if err != nil {
 		return err
 	}
 
-	if len(milkCollections) == 0 {
+	now := time.Now()
+	move := evaluateAnimalBatch(animal, rules, milkCollections, reproduction, now)
+	if move == nil {
 		return nil
 	}
 
-	latestMilkCollection := milkCollections[0]
+	move.Reason = models.BatchMoveReasonMilkCollection
+	return s.batchRepository.ApplyMoves([]models.BatchMove{*move})
+}
+
+func (s *BatchService) UpdateAnimalsBatch(animalIDs []uint, farmID uint) (*RebatchSummary, error) {
+	if len(animalIDs) == 0 {
+		return &RebatchSummary{FarmsProcessed: 1}, nil
+	}
+
+	animals, err := s.animalRepository.FindByFarmIDAndSex(farmID, models.AnimalSexFemale)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[uint]bool, len(animalIDs))
+	for _, id := range animalIDs {
+		selected[id] = true
+	}
+
+	var sessionAnimals []models.Animal
+	for _, animal := range animals {
+		if selected[animal.ID] {
+			sessionAnimals = append(sessionAnimals, animal)
+		}
+	}
+
+	return s.rebatchAnimals(farmID, sessionAnimals, models.BatchMoveReasonMilkCollection, time.Now())
+}
+
+func (s *BatchService) RebatchFarm(farmID uint, now time.Time) (*RebatchSummary, error) {
+	animals, err := s.animalRepository.FindByFarmIDAndSex(farmID, models.AnimalSexFemale)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rebatchAnimals(farmID, animals, models.BatchMoveReasonRebatch, now)
+}
+
+func (s *BatchService) rebatchAnimals(farmID uint, animals []models.Animal, reason string, now time.Time) (*RebatchSummary, error) {
+	rules, err := s.batchRepository.FindRulesByFarmID(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	var milkCollections []models.MilkCollection
+	if len(rules) == 0 {
+		milkCollections, err = s.milkRepository.FindByFarmID(farmID)
+	} else {
+		start := calendarDay(now).AddDate(0, 0, -batchWindowDays(rules))
+		milkCollections, err = s.milkRepository.FindByFarmIDWithDateRange(farmID, &start, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reproductions, err := s.reproductionRepository.FindByFarmID(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionsByAnimal := make(map[uint][]models.MilkCollection)
 	for _, collection := range milkCollections {
-		if collection.Date.After(latestMilkCollection.Date) {
-			latestMilkCollection = collection
+		collectionsByAnimal[collection.AnimalID] = append(collectionsByAnimal[collection.AnimalID], collection)
+	}
+	reproductionByAnimal := make(map[uint]*models.Reproduction)
+	for i := range reproductions {
+		reproductionByAnimal[reproductions[i].AnimalID] = &reproductions[i]
+	}
+
+	summary := &RebatchSummary{FarmsProcessed: 1}
+	var moves []models.BatchMove
+	for i := range animals {
+		animal := &animals[i]
+		if animal.Status != models.AnimalStatusActive {
+			continue
 		}
+		summary.AnimalsEvaluated++
+
+		move := evaluateAnimalBatch(animal, rules, collectionsByAnimal[animal.ID], reproductionByAnimal[animal.ID], now)
+		if move == nil {
+			continue
+		}
+		move.Reason = reason
+		moves = append(moves, *move)
 	}
 
-	newBatch := models.GetBatchByLiters(latestMilkCollection.Liters)
-	if animal.CurrentBatch != newBatch {
-		animal.CurrentBatch = newBatch
-		return s.animalRepository.Update(animal)
+	if err := s.batchRepository.ApplyMoves(moves); err != nil {
+		return nil, err
 	}
+	summary.AnimalsMoved = len(moves)
 
-	return nil
+	return summary, nil
+}
+
+func (s *BatchService) RebatchAllFarms(now time.Time) (*RebatchSummary, error) {
+	farmIDs, err := s.farmRepository.FindAllIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &RebatchSummary{}
+	for _, farmID := range farmIDs {
+		farmSummary, err := s.RebatchFarm(farmID, now)
+		if err != nil {
+			log.Printf("Erro ao recalcular lotes da fazenda %d: %v", farmID, err)
+			summary.FarmsFailed++
+			continue
+		}
+		summary.FarmsProcessed++
+		summary.AnimalsEvaluated += farmSummary.AnimalsEvaluated
+		summary.AnimalsMoved += farmSummary.AnimalsMoved
+	}
+
+	return summary, nil
+}
+
+func batchWindowDays(rules []models.BatchRule) int {
+	days := models.DefaultBatchAverageWindowDays
+	for _, rule := range rules {
+		if rule.AverageWindowDays > days {
+			days = rule.AverageWindowDays
+		}
+	}
+	return days
+}
+
+func evaluateAnimalBatch(animal *models.Animal, rules []models.BatchRule, milkCollections []models.MilkCollection, reproduction *models.Reproduction, now time.Time) *models.BatchMove {
+	var newBatch int
+	var ruleID *uint
+	var ruleName string
+
+	if len(rules) == 0 {
+		if len(milkCollections) == 0 {
+			return nil
+		}
+		latestDay := calendarDay(milkCollections[0].Date)
+		for _, collection := range milkCollections {
+			if day := calendarDay(collection.Date); day.After(latestDay) {
+				latestDay = day
+			}
+		}
+		var latestDayLiters float64
+		for _, collection := range milkCollections {
+			if calendarDay(collection.Date).Equal(latestDay) {
+				latestDayLiters += collection.Liters
+			}
+		}
+		newBatch = models.GetBatchByLiters(latestDayLiters)
+	} else {
+		rule := models.MatchBatchRule(rules, batchMetrics(milkCollections, reproduction, now))
+		if rule == nil {
+			return nil
+		}
+		newBatch = rule.Batch
+		id := rule.ID
+		ruleID = &id
+		ruleName = rule.Name
+	}
+
+	if animal.CurrentBatch == newBatch {
+		return nil
+	}
+
+	return &models.BatchMove{
+		FarmID:    animal.FarmID,
+		AnimalID:  animal.ID,
+		FromBatch: animal.CurrentBatch,
+		ToBatch:   newBatch,
+		RuleID:    ruleID,
+		RuleName:  ruleName,
+		MovedAt:   now,
+	}
+}
+
+func batchMetrics(milkCollections []models.MilkCollection, reproduction *models.Reproduction, now time.Time) models.BatchMetrics {
+	metrics := models.BatchMetrics{
+		DailyLiters:  make(map[time.Time]float64),
+		ReferenceDay: calendarDay(now),
+	}
+
+	for _, collection := range milkCollections {
+		metrics.DailyLiters[calendarDay(collection.Date)] += collection.Liters
+	}
+
+	if reproduction != nil {
+		phase := reproduction.CurrentPhase
+		metrics.Phase = &phase
+		if reproduction.LactationStartDate != nil && reproduction.LactationEndDate == nil {
+			daysInMilk := daysBetween(calendarDay(*reproduction.LactationStartDate), metrics.ReferenceDay)
+			metrics.DaysInMilk = &daysInMilk
+		}
+	}
+
+	return metrics
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type rebatchAnimalRepository struct {
+	repository.AnimalRepositoryInterface
+	failingFarms map[uint]bool
+}
+
+func (r *rebatchAnimalRepository) FindByFarmIDAndSex(farmID uint, sex int) ([]models.Animal, error) {
+	if r.failingFarms[farmID] {
+		return nil, errors.New("connection reset")
+	}
+	return []models.Animal{{ID: farmID * 100, FarmID: farmID, Status: models.AnimalStatusActive, CurrentBatch: models.Batch1}}, nil
+}
+
+type rebatchMilkRepository struct {
+	repository.MilkCollectionRepositoryInterface
+}
+
+func (rebatchMilkRepository) FindByFarmID(farmID uint) ([]models.MilkCollection, error) {
+	return nil, nil
+}
+
+type rebatchReproductionRepository struct {
+	repository.ReproductionRepositoryInterface
+}
+
+func (rebatchReproductionRepository) FindByFarmID(farmID uint) ([]models.Reproduction, error) {
+	return nil, nil
+}
+
+type rebatchBatchRepository struct {
+	repository.BatchRepositoryInterface
+}
+
+func (rebatchBatchRepository) FindRulesByFarmID(farmID uint) ([]models.BatchRule, error) {
+	return nil, nil
+}
+
+func (rebatchBatchRepository) ApplyMoves(moves []models.BatchMove) error {
+	return nil
+}
+
+type rebatchFarmRepository struct {
+	repository.FarmRepositoryInterface
+	ids []uint
+}
+
+func (r rebatchFarmRepository) FindAllIDs() ([]uint, error) {
+	return r.ids, nil
+}
+
+func TestRebatchAllFarmsContinuesAfterFarmError(t *testing.T) {
+	service := NewBatchService(
+		&rebatchAnimalRepository{failingFarms: map[uint]bool{2: true}},
+		rebatchMilkRepository{},
+		rebatchReproductionRepository{},
+		rebatchBatchRepository{},
+		rebatchFarmRepository{ids: []uint{1, 2, 3}},
+	)
+
+	summary, err := service.RebatchAllFarms(time.Now())
+	if err != nil {
+		t.Fatalf("RebatchAllFarms: %v", err)
+	}
+	if summary.FarmsProcessed != 2 || summary.FarmsFailed != 1 {
+		t.Fatalf("summary = %+v, want 2 farms processed and 1 failed", summary)
+	}
+	if summary.AnimalsEvaluated != 2 {
+		t.Fatalf("animals evaluated = %d, want 2", summary.AnimalsEvaluated)
+	}
+}
+
+func TestEvaluateAnimalBatchRecordsRuleName(t *testing.T) {
+	rules := []models.BatchRule{{ID: 8, Batch: models.Batch2, Name: "Alta produção", Priority: 1, AverageWindowDays: 7}}
+	animal := &models.Animal{ID: 5, FarmID: 1, CurrentBatch: models.Batch3}
+
+	move := evaluateAnimalBatch(animal, rules, nil, nil, time.Now())
+	if move == nil {
+		t.Fatal("evaluateAnimalBatch returned no move")
+	}
+	if move.RuleID == nil || *move.RuleID != 8 || move.RuleName != "Alta produção" {
+		t.Fatalf("move rule = %v %q, want 8 %q", move.RuleID, move.RuleName, "Alta produção")
+	}
+}
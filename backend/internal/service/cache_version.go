@@ -0,0 +1,22 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/fazendapro/FazendaPro-api/internal/cache"
+)
+
+func farmCacheVersion(cacheClient cache.CacheInterface, scope string, farmID uint) uint64 {
+	var version uint64
+	if err := cacheClient.Get(fmt.Sprintf(CacheKeyFarmVersion, scope, farmID), &version); err != nil {
+		return 0
+	}
+	return version
+}
+
+func bumpFarmCacheVersion(cacheClient cache.CacheInterface, scope string, farmID uint) {
+	if _, err := cacheClient.Increment(fmt.Sprintf(CacheKeyFarmVersion, scope, farmID), 1); err != nil {
+		log.Printf(ErrInvalidateCache, err)
+	}
+}
@@ -3,10 +3,79 @@ package service
 import "github.com/fazendapro/FazendaPro-api/internal/repository"
 
 const (
-	CacheKeyAnimalsFarm = "animals:farm:%d"
+	CacheKeyAnimalsFarm     = "animals:farm:%d"
+	CacheKeyMonthlyExpenses = "dashboard:monthly-expenses:%d:v%d:%d"
+	CacheKeyPnL             = "reports:pnl:%d:v%d:%s:%s"
+	CacheKeyFarmVersion     = "version:%s:%d"
+
+	CacheScopeExpenses = "expenses"
+	CacheScopePnL      = "pnl"
 
 	ErrInvalidateCache = "Erro ao invalidar cache (não crítico): %v"
 	ErrAnimalNotFound  = "animal not found"
+
+	ErrAnimalNotBelongsToFarm    = "animal does not belong to the specified farm"
+	ErrAnimalEarTagAlreadyExists = "já existe um animal com este número de brinca nesta fazenda"
+	ErrInvalidFarmRole           = "invalid farm role"
+	ErrUserNotInFarm             = "user does not belong to the specified farm"
+
+	ErrMilkPriceTableNotFoundForMonth = "no milk price table valid for buyer in month"
+
+	ErrInvalidMilkShift = "turno inválido, use morning, afternoon ou night"
+
+	ErrInvalidExportFormat = "invalid export format, use csv, xlsx or json"
+	ErrInvalidExportEntity = "invalid export entity"
+
+	ErrReadingRestoreArchive     = "erro ao ler arquivo de exportação: %w"
+	ErrInvalidRestoreArchive     = "arquivo não é uma exportação de fazenda do FazendaPro"
+	ErrUnsupportedRestoreVersion = "versão %d do arquivo de exportação não suportada"
+	ErrCompanyNotFound           = "empresa não encontrada"
+	ErrCompanyAccessDenied       = "usuário não é proprietário de nenhuma fazenda da empresa"
+
+	ErrInvalidReportPeriod = "start date cannot be after end date"
+
+	ErrReproductionFieldFromEvents = "derivado do histórico de eventos; registre a mudança em POST /api/v1/reproductions/events"
+
+	ErrAnimalPhotoTooLarge = "foto muito grande, máximo de 10 MB"
+
+	ErrAnimalAttachmentTooLarge      = "arquivo muito grande, máximo de 20 MB"
+	ErrInvalidAnimalAttachmentKind   = "tipo de anexo inválido, use photo, registration, gta, vet_report ou other"
+	ErrUnsupportedAnimalAttachment   = "formato de arquivo não suportado, use JPEG, PNG, GIF ou PDF"
+	ErrAnimalAttachmentPhotoNotImage = "anexos do tipo photo devem ser uma imagem JPEG, PNG ou GIF"
 )
 
 var ErrSaleNotFoundOrNotBelongsToFarm = repository.ErrSaleNotFoundOrNotBelongsToFarm
+
+var ErrWeightNotFoundOrNotBelongsToFarm = repository.ErrWeightNotFoundOrNotBelongsToFarm
+
+var ErrExpenseNotFoundOrNotBelongsToFarm = repository.ErrExpenseNotFoundOrNotBelongsToFarm
+
+var ErrDebtNotFoundOrNotBelongsToFarm = repository.ErrDebtNotFoundOrNotBelongsToFarm
+
+var ErrAnimalNotFoundOrNotBelongsToFarm = repository.ErrAnimalNotFoundOrNotBelongsToFarm
+
+var ErrMilkCollectionNotFoundOrNotBelongsToFarm = repository.ErrMilkCollectionNotFoundOrNotBelongsToFarm
+
+var ErrReproductionNotFoundOrNotBelongsToFarm = repository.ErrReproductionNotFoundOrNotBelongsToFarm
+
+var ErrSemenCatalogNotFoundOrNotBelongsToFarm = repository.ErrSemenCatalogNotFoundOrNotBelongsToFarm
+
+var ErrGestationPeriodNotFoundOrNotBelongsToFarm = repository.ErrGestationPeriodNotFoundOrNotBelongsToFarm
+
+var ErrNotificationNotFoundOrNotBelongsToFarm = repository.ErrNotificationNotFoundOrNotBelongsToFarm
+
+var ErrMilkQualityTestNotFoundOrNotBelongsToFarm = repository.ErrMilkQualityTestNotFoundOrNotBelongsToFarm
+
+var ErrMilkPriceTableNotFoundOrNotBelongsToFarm = repository.ErrMilkPriceTableNotFoundOrNotBelongsToFarm
+
+var ErrMilkDeliveryNotFoundOrNotBelongsToFarm = repository.ErrMilkDeliveryNotFoundOrNotBelongsToFarm
+
+var ErrMilkPaymentNotFoundOrNotBelongsToFarm = repository.ErrMilkPaymentNotFoundOrNotBelongsToFarm
+
+var ErrAnimalAttachmentNotFoundOrNotBelongsToFarm = repository.ErrAnimalAttachmentNotFoundOrNotBelongsToFarm
+
+var ErrDebtAlreadyPaid = repository.ErrDebtAlreadyPaid
+
+var ErrDebtPaymentExceedsBalance = repository.ErrDebtPaymentExceedsBalance
+
+var ErrMilkCollectionAlreadyExists = repository.ErrMilkCollectionAlreadyExists
@@ -1,46 +1,110 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"time"
 
+	"github.com/fazendapro/FazendaPro-api/internal/cache"
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"github.com/fazendapro/FazendaPro-api/internal/repository"
 )
 
 type DebtService struct {
-	repository repository.DebtRepositoryInterface
+	repository  repository.DebtRepositoryInterface
+	saleRepo    repository.SaleRepository
+	expenseRepo repository.ExpenseRepository
+	cache       cache.CacheInterface
 }
 
-func NewDebtService(repository repository.DebtRepositoryInterface) *DebtService {
-	return &DebtService{repository: repository}
+func NewDebtService(repository repository.DebtRepositoryInterface, saleRepo repository.SaleRepository, expenseRepo repository.ExpenseRepository, cacheClient cache.CacheInterface) *DebtService {
+	return &DebtService{
+		repository:  repository,
+		saleRepo:    saleRepo,
+		expenseRepo: expenseRepo,
+		cache:       cacheClient,
+	}
 }
 
-func (s *DebtService) CreateDebt(debt *models.Debt) error {
-	if debt.Person == "" {
-		return errors.New("nome da pessoa é obrigatório")
+func (s *DebtService) CreateDebt(ctx context.Context, debt *models.Debt) error {
+	if debt.FarmID == 0 {
+		return errors.New("fazenda é obrigatória")
 	}
 
 	if debt.Value <= 0 {
 		return errors.New("valor deve ser maior que zero")
 	}
 
+	if err := s.resolveCounterparty(ctx, debt); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(debt.Person) == "" {
+		return errors.New("nome da pessoa é obrigatório")
+	}
+
 	now := time.Now()
+	debt.PaidAmount = 0
+	debt.Status = models.DebtStatusOpen
+	debt.PaidAt = nil
 	debt.CreatedAt = now
 	debt.UpdatedAt = now
 
-	return s.repository.Create(debt)
+	if err := s.repository.Create(debt); err != nil {
+		return err
+	}
+
+	bumpFarmCacheVersion(s.cache, CacheScopePnL, debt.FarmID)
+	return nil
 }
 
-func (s *DebtService) GetDebtByID(id uint) (*models.Debt, error) {
+func (s *DebtService) resolveCounterparty(ctx context.Context, debt *models.Debt) error {
+	if debt.SaleID != nil && debt.ExpenseID != nil {
+		return errors.New("a dívida deve estar ligada a uma venda ou a uma despesa, não ambas")
+	}
+
+	if debt.SaleID != nil {
+		sale, err := s.saleRepo.GetByID(ctx, *debt.SaleID, debt.FarmID)
+		if err != nil || sale == nil {
+			return errors.New("venda não encontrada na fazenda")
+		}
+		debt.CounterpartyType = models.DebtCounterpartyBuyer
+		if strings.TrimSpace(debt.Person) == "" {
+			debt.Person = sale.BuyerName
+		}
+		return nil
+	}
+
+	if debt.ExpenseID != nil {
+		if _, err := s.expenseRepo.GetByID(ctx, *debt.ExpenseID, debt.FarmID); err != nil {
+			return errors.New("despesa não encontrada na fazenda")
+		}
+		debt.CounterpartyType = models.DebtCounterpartySupplier
+		return nil
+	}
+
+	return validateDebtCounterpartyType(debt.CounterpartyType)
+}
+
+func validateDebtCounterpartyType(counterpartyType string) error {
+	switch counterpartyType {
+	case "", models.DebtCounterpartyBuyer, models.DebtCounterpartySupplier:
+		return nil
+	default:
+		return errors.New("tipo de contraparte deve ser 'buyer' ou 'supplier'")
+	}
+}
+
+func (s *DebtService) GetDebtByID(id, farmID uint) (*models.Debt, error) {
 	if id == 0 {
 		return nil, errors.New("ID é obrigatório")
 	}
 
-	return s.repository.FindByID(id)
+	return s.repository.FindByID(id, farmID)
 }
 
-func (s *DebtService) GetDebtsWithPagination(page, limit int, year, month *int) ([]models.Debt, int64, error) {
+func (s *DebtService) GetDebtsWithPagination(farmID uint, page, limit int, filter repository.DebtFilter) ([]models.Debt, int64, error) {
 	if page <= 0 {
 		page = 1
 	}
@@ -48,23 +112,67 @@ func (s *DebtService) GetDebtsWithPagination(page, limit int, year, month *int)
 		limit = 10
 	}
 
-	return s.repository.FindAllWithPagination(page, limit, year, month)
+	switch filter.Status {
+	case "", models.DebtStatusOpen, models.DebtStatusPaid:
+	default:
+		return nil, 0, errors.New("status deve ser 'open' ou 'paid'")
+	}
+
+	return s.repository.FindAllWithPagination(farmID, page, limit, filter)
+}
+
+func (s *DebtService) GetOverdueDebts(farmID uint) ([]models.Debt, error) {
+	return s.repository.FindOverdue(farmID, startOfDay(time.Now()))
+}
+
+func (s *DebtService) GetUpcomingDebts(farmID uint, days int) ([]models.Debt, error) {
+	if days <= 0 {
+		days = 30
+	}
+	if days > 365 {
+		return nil, errors.New("período deve ser de no máximo 365 dias")
+	}
+
+	today := startOfDay(time.Now())
+	return s.repository.FindUpcoming(farmID, today, today.AddDate(0, 0, days+1).Add(-time.Nanosecond))
+}
+
+func (s *DebtService) RegisterPayment(debtID, farmID uint, payment *models.DebtPayment) (*models.Debt, error) {
+	if payment.Amount <= 0 {
+		return nil, errors.New("valor do pagamento deve ser maior que zero")
+	}
+
+	if payment.PaymentDate.IsZero() {
+		payment.PaymentDate = time.Now()
+	}
+
+	if err := s.repository.AddPayment(debtID, farmID, payment); err != nil {
+		return nil, err
+	}
+	bumpFarmCacheVersion(s.cache, CacheScopePnL, farmID)
+
+	return s.repository.FindByID(debtID, farmID)
 }
 
-func (s *DebtService) DeleteDebt(id uint) error {
+func (s *DebtService) DeleteDebt(id, farmID uint) error {
 	if id == 0 {
 		return errors.New("ID é obrigatório")
 	}
 
-	_, err := s.repository.FindByID(id)
+	_, err := s.repository.FindByID(id, farmID)
 	if err != nil {
 		return errors.New("dívida não encontrada")
 	}
 
-	return s.repository.Delete(id)
+	if err := s.repository.Delete(id, farmID); err != nil {
+		return err
+	}
+
+	bumpFarmCacheVersion(s.cache, CacheScopePnL, farmID)
+	return nil
 }
 
-func (s *DebtService) GetTotalByPersonInMonth(year, month int) ([]repository.PersonTotal, error) {
+func (s *DebtService) GetTotalByPersonInMonth(farmID uint, year, month int) ([]repository.PersonTotal, error) {
 	if year < 2000 || year > 3000 {
 		return nil, errors.New("ano deve estar entre 2000 e 3000")
 	}
@@ -73,5 +181,9 @@ func (s *DebtService) GetTotalByPersonInMonth(year, month int) ([]repository.Per
 		return nil, errors.New("mês deve estar entre 1 e 12")
 	}
 
-	return s.repository.GetTotalByPersonInMonth(year, month)
+	return s.repository.GetTotalByPersonInMonth(farmID, year, month)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/cache"
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type ExpenseService interface {
+	CreateExpense(ctx context.Context, expense *models.Expense) error
+	GetExpenseByID(ctx context.Context, id uint, farmID uint) (*models.Expense, error)
+	GetExpensesByFarmID(ctx context.Context, farmID uint, filter repository.ExpenseFilter) ([]*models.Expense, error)
+	GetMonthlyExpensesData(ctx context.Context, farmID uint, months int) ([]repository.MonthlyExpensesData, error)
+	GetTotalsByCategory(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]repository.CategoryTotal, error)
+	UpdateExpense(ctx context.Context, expense *models.Expense, farmID uint) error
+	DeleteExpense(ctx context.Context, id uint, farmID uint) error
+}
+
+type expenseService struct {
+	expenseRepo repository.ExpenseRepository
+	cache       cache.CacheInterface
+}
+
+func NewExpenseService(expenseRepo repository.ExpenseRepository, cacheClient cache.CacheInterface) ExpenseService {
+	return &expenseService{
+		expenseRepo: expenseRepo,
+		cache:       cacheClient,
+	}
+}
+
+func validateExpense(expense *models.Expense) error {
+	if strings.TrimSpace(expense.Description) == "" {
+		return errors.New("description is required")
+	}
+	if strings.TrimSpace(expense.Category) == "" {
+		return errors.New("category is required")
+	}
+	if expense.Amount <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	if expense.Date.IsZero() {
+		return errors.New("expense date is required")
+	}
+	return nil
+}
+
+func (s *expenseService) CreateExpense(ctx context.Context, expense *models.Expense) error {
+	if expense.FarmID == 0 {
+		return errors.New("farm ID is required")
+	}
+	if err := validateExpense(expense); err != nil {
+		return err
+	}
+	expense.Category = strings.TrimSpace(expense.Category)
+
+	if err := s.expenseRepo.Create(ctx, expense); err != nil {
+		return err
+	}
+
+	s.invalidateExpenseCache(expense.FarmID)
+
+	return nil
+}
+
+func (s *expenseService) GetExpenseByID(ctx context.Context, id uint, farmID uint) (*models.Expense, error) {
+	return s.expenseRepo.GetByID(ctx, id, farmID)
+}
+
+func (s *expenseService) GetExpensesByFarmID(ctx context.Context, farmID uint, filter repository.ExpenseFilter) ([]*models.Expense, error) {
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return nil, errors.New("start date cannot be after end date")
+	}
+	return s.expenseRepo.GetByFarmID(ctx, farmID, filter)
+}
+
+func (s *expenseService) GetMonthlyExpensesData(ctx context.Context, farmID uint, months int) ([]repository.MonthlyExpensesData, error) {
+	if months <= 0 {
+		months = 12
+	}
+	if months > 24 {
+		months = 24
+	}
+
+	version := farmCacheVersion(s.cache, CacheScopeExpenses, farmID)
+	cacheKey := fmt.Sprintf(CacheKeyMonthlyExpenses, farmID, version, months)
+	var cachedData []repository.MonthlyExpensesData
+
+	err := s.cache.Get(cacheKey, &cachedData)
+	if err == nil {
+		log.Printf("Cache HIT para despesas mensais da fazenda %d (meses: %d)", farmID, months)
+		return cachedData, nil
+	}
+
+	log.Printf("Cache MISS para despesas mensais da fazenda %d (meses: %d)", farmID, months)
+	data, err := s.expenseRepo.GetMonthlyExpensesData(ctx, farmID, months)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(cacheKey, data, 900); err != nil {
+		log.Printf("Erro ao salvar no cache (não crítico): %v", err)
+	}
+
+	return data, nil
+}
+
+func (s *expenseService) GetTotalsByCategory(ctx context.Context, farmID uint, startDate, endDate time.Time) ([]repository.CategoryTotal, error) {
+	if startDate.After(endDate) {
+		return nil, errors.New("start date cannot be after end date")
+	}
+	return s.expenseRepo.GetTotalsByCategory(ctx, farmID, startDate, endDate)
+}
+
+func (s *expenseService) UpdateExpense(ctx context.Context, expense *models.Expense, farmID uint) error {
+	if expense.ID == 0 {
+		return errors.New("expense ID is required")
+	}
+	if err := validateExpense(expense); err != nil {
+		return err
+	}
+
+	expense.FarmID = farmID
+	expense.Category = strings.TrimSpace(expense.Category)
+
+	if err := s.expenseRepo.Update(ctx, expense); err != nil {
+		return err
+	}
+
+	s.invalidateExpenseCache(farmID)
+
+	return nil
+}
+
+func (s *expenseService) DeleteExpense(ctx context.Context, id uint, farmID uint) error {
+	if err := s.expenseRepo.Delete(ctx, id, farmID); err != nil {
+		return err
+	}
+
+	s.invalidateExpenseCache(farmID)
+
+	return nil
+}
+
+func (s *expenseService) invalidateExpenseCache(farmID uint) {
+	bumpFarmCacheVersion(s.cache, CacheScopeExpenses, farmID)
+	bumpFarmCacheVersion(s.cache, CacheScopePnL, farmID)
+}
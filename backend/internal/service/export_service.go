@@ -0,0 +1,428 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
+	"github.com/fazendapro/FazendaPro-api/internal/utils"
+)
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+	ExportFormatJSON = "json"
+
+	ExportArchiveKind    = "fazendapro-farm-export"
+	ExportArchiveVersion = 1
+
+	ExportEntityAnimals            = "animals"
+	ExportEntityMilkCollections    = "milk_collections"
+	ExportEntityReproductions      = "reproductions"
+	ExportEntityReproductionEvents = "reproduction_events"
+	ExportEntitySales              = "sales"
+	ExportEntityExpenses           = "expenses"
+	ExportEntityDebts              = "debts"
+	ExportEntityDebtPayments       = "debt_payments"
+)
+
+var ExportEntities = []string{
+	ExportEntityAnimals,
+	ExportEntityMilkCollections,
+	ExportEntityReproductions,
+	ExportEntityReproductionEvents,
+	ExportEntitySales,
+	ExportEntityExpenses,
+	ExportEntityDebts,
+	ExportEntityDebtPayments,
+}
+
+type ExportService interface {
+	ValidateExport(format, entity string) error
+	WriteEntity(ctx context.Context, farmID uint, entity, format string, w io.Writer) error
+	WriteArchive(ctx context.Context, farmID uint, w io.Writer) error
+}
+
+type exportService struct {
+	repo    repository.ExportRepository
+	storage storage.BlobStorage
+}
+
+func NewExportService(repo repository.ExportRepository, blobStorage storage.BlobStorage) ExportService {
+	return &exportService{repo: repo, storage: blobStorage}
+}
+
+type exportArchiveHeader struct {
+	Kind       string            `json:"kind"`
+	Version    int               `json:"version"`
+	ExportedAt string            `json:"exported_at"`
+	Farm       exportArchiveFarm `json:"farm"`
+}
+
+type exportArchiveFarm struct {
+	ID          uint   `json:"id"`
+	CompanyName string `json:"company_name"`
+	Location    string `json:"location"`
+	CNPJ        string `json:"cnpj"`
+	Logo        string `json:"logo"`
+}
+
+type exportColumn struct {
+	name        string
+	archiveOnly bool
+}
+
+type exportTable struct {
+	columns []exportColumn
+	each    func(ctx context.Context, farmID uint, row func([]interface{}) error) error
+}
+
+func (t exportTable) columnNames(archive bool) []string {
+	names := make([]string, 0, len(t.columns))
+	for _, column := range t.columns {
+		if archive || !column.archiveOnly {
+			names = append(names, column.name)
+		}
+	}
+	return names
+}
+
+func (t exportTable) values(row []interface{}, archive bool) []interface{} {
+	if archive {
+		return row
+	}
+	values := make([]interface{}, 0, len(row))
+	for i, value := range row {
+		if !t.columns[i].archiveOnly {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func exportColumns(names ...string) []exportColumn {
+	columns := make([]exportColumn, len(names))
+	for i, name := range names {
+		columns[i] = exportColumn{name: name}
+	}
+	return columns
+}
+
+func exportDate(date *time.Time) interface{} {
+	if date == nil {
+		return nil
+	}
+	return date.Format("2006-01-02")
+}
+
+func exportTimestamp(timestamp time.Time) interface{} {
+	return timestamp.UTC().Format(time.RFC3339)
+}
+
+func exportOptionalID(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+func exportOptionalBool(value *bool) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func (s *exportService) exportPhoto(ctx context.Context, animal *models.Animal) string {
+	if animal.PhotoKey == "" {
+		return animal.Photo
+	}
+	object, err := s.storage.Get(ctx, animal.PhotoKey)
+	if err != nil {
+		log.Printf("Foto do animal %d não exportada: %v", animal.ID, err)
+		return ""
+	}
+	defer object.Body.Close()
+	data, err := io.ReadAll(object.Body)
+	if err != nil {
+		log.Printf("Foto do animal %d não exportada: %v", animal.ID, err)
+		return ""
+	}
+	return fmt.Sprintf("data:%s;base64,%s", object.ContentType, base64.StdEncoding.EncodeToString(data))
+}
+
+func (s *exportService) tables() map[string]exportTable {
+	return map[string]exportTable{
+		ExportEntityAnimals: {
+			columns: append(exportColumns("id", "ear_tag_number_local", "ear_tag_number_register", "animal_name", "sex", "breed", "type",
+				"birth_date", "father_id", "mother_id", "confinement", "animal_type", "status", "fertilization", "castrated",
+				"purpose", "current_batch", "created_at", "updated_at"), exportColumn{name: "photo", archiveOnly: true}),
+			each: func(ctx context.Context, farmID uint, row func([]interface{}) error) error {
+				return s.repo.EachAnimal(ctx, farmID, func(batch []models.Animal) error {
+					for _, a := range batch {
+						if err := row([]interface{}{a.ID, a.EarTagNumberLocal, a.EarTagNumberRegister, a.AnimalName, a.Sex, a.Breed, a.Type,
+							exportDate(a.BirthDate), exportOptionalID(a.FatherID), exportOptionalID(a.MotherID), a.Confinement, a.AnimalType,
+							a.Status, a.Fertilization, a.Castrated, a.Purpose, a.CurrentBatch, exportTimestamp(a.CreatedAt),
+							exportTimestamp(a.UpdatedAt), s.exportPhoto(ctx, &a)}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+		ExportEntityMilkCollections: {
+			columns: exportColumns("id", "animal_id", "date", "shift", "liters", "created_at", "updated_at"),
+			each: func(ctx context.Context, farmID uint, row func([]interface{}) error) error {
+				return s.repo.EachMilkCollection(ctx, farmID, func(batch []models.MilkCollection) error {
+					for _, c := range batch {
+						if err := row([]interface{}{c.ID, c.AnimalID, exportDate(&c.Date), c.Shift, c.Liters,
+							exportTimestamp(c.CreatedAt), exportTimestamp(c.UpdatedAt)}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+		ExportEntityReproductions: {
+			columns: exportColumns("id", "animal_id", "current_phase", "insemination_date", "insemination_type", "pregnancy_date",
+				"expected_birth_date", "actual_birth_date", "lactation_start_date", "lactation_end_date", "dry_period_start_date",
+				"veterinary_confirmation", "observations", "created_at", "updated_at"),
+			each: func(ctx context.Context, farmID uint, row func([]interface{}) error) error {
+				return s.repo.EachReproduction(ctx, farmID, func(batch []models.Reproduction) error {
+					for _, r := range batch {
+						if err := row([]interface{}{r.ID, r.AnimalID, int(r.CurrentPhase), exportDate(r.InseminationDate), r.InseminationType,
+							exportDate(r.PregnancyDate), exportDate(r.ExpectedBirthDate), exportDate(r.ActualBirthDate),
+							exportDate(r.LactationStartDate), exportDate(r.LactationEndDate), exportDate(r.DryPeriodStartDate),
+							r.VeterinaryConfirmation, r.Observations, exportTimestamp(r.CreatedAt), exportTimestamp(r.UpdatedAt)}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+		ExportEntityReproductionEvents: {
+			columns: exportColumns("id", "animal_id", "type", "date", "insemination_type", "sire_id", "pregnancy_positive",
+				"veterinary_confirmation", "calf_id", "observations", "created_at"),
+			each: func(ctx context.Context, farmID uint, row func([]interface{}) error) error {
+				return s.repo.EachReproductionEvent(ctx, farmID, func(batch []models.ReproductionEvent) error {
+					for _, e := range batch {
+						if err := row([]interface{}{e.ID, e.AnimalID, e.Type, exportDate(&e.Date), e.InseminationType,
+							exportOptionalID(e.SireID), exportOptionalBool(e.PregnancyPositive), e.VeterinaryConfirmation,
+							exportOptionalID(e.CalfID), e.Observations, exportTimestamp(e.CreatedAt)}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+		ExportEntitySales: {
+			columns: exportColumns("id", "animal_id", "buyer_name", "price", "sale_date", "notes", "created_at", "updated_at"),
+			each: func(ctx context.Context, farmID uint, row func([]interface{}) error) error {
+				return s.repo.EachSale(ctx, farmID, func(batch []models.Sale) error {
+					for _, sale := range batch {
+						if err := row([]interface{}{sale.ID, sale.AnimalID, sale.BuyerName, sale.Price, exportDate(&sale.SaleDate), sale.Notes,
+							exportTimestamp(sale.CreatedAt), exportTimestamp(sale.UpdatedAt)}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+		ExportEntityExpenses: {
+			columns: exportColumns("id", "description", "amount", "category", "date", "notes", "created_at", "updated_at"),
+			each: func(ctx context.Context, farmID uint, row func([]interface{}) error) error {
+				return s.repo.EachExpense(ctx, farmID, func(batch []models.Expense) error {
+					for _, e := range batch {
+						if err := row([]interface{}{e.ID, e.Description, e.Amount, e.Category, exportDate(&e.Date), e.Notes,
+							exportTimestamp(e.CreatedAt), exportTimestamp(e.UpdatedAt)}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+		ExportEntityDebts: {
+			columns: exportColumns("id", "person", "counterparty_type", "sale_id", "expense_id", "value", "paid_amount", "due_date",
+				"status", "paid_at", "created_at", "updated_at"),
+			each: func(ctx context.Context, farmID uint, row func([]interface{}) error) error {
+				return s.repo.EachDebt(ctx, farmID, func(batch []models.Debt) error {
+					for _, d := range batch {
+						if err := row([]interface{}{d.ID, d.Person, d.CounterpartyType, exportOptionalID(d.SaleID), exportOptionalID(d.ExpenseID),
+							d.Value, d.PaidAmount, exportDate(d.DueDate), d.Status, exportDate(d.PaidAt),
+							exportTimestamp(d.CreatedAt), exportTimestamp(d.UpdatedAt)}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+		ExportEntityDebtPayments: {
+			columns: exportColumns("id", "debt_id", "amount", "payment_date", "notes", "created_at", "updated_at"),
+			each: func(ctx context.Context, farmID uint, row func([]interface{}) error) error {
+				return s.repo.EachDebtPayment(ctx, farmID, func(batch []models.DebtPayment) error {
+					for _, p := range batch {
+						if err := row([]interface{}{p.ID, p.DebtID, p.Amount, exportDate(&p.PaymentDate), p.Notes,
+							exportTimestamp(p.CreatedAt), exportTimestamp(p.UpdatedAt)}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+	}
+}
+
+func (s *exportService) ValidateExport(format, entity string) error {
+	switch format {
+	case ExportFormatJSON:
+		return nil
+	case ExportFormatCSV, ExportFormatXLSX:
+		if _, ok := s.tables()[entity]; !ok {
+			return fmt.Errorf("%s", ErrInvalidExportEntity)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s", ErrInvalidExportFormat)
+}
+
+func (s *exportService) WriteEntity(ctx context.Context, farmID uint, entity, format string, w io.Writer) error {
+	if err := s.ValidateExport(format, entity); err != nil {
+		return err
+	}
+	if format == ExportFormatJSON {
+		return fmt.Errorf("%s", ErrInvalidExportFormat)
+	}
+	table := s.tables()[entity]
+
+	if format == ExportFormatXLSX {
+		sheet, err := utils.NewXLSXWriter(w, entity)
+		if err != nil {
+			return err
+		}
+		header := make([]interface{}, 0, len(table.columns))
+		for _, name := range table.columnNames(false) {
+			header = append(header, name)
+		}
+		if err := sheet.WriteRow(header); err != nil {
+			return err
+		}
+		if err := table.each(ctx, farmID, func(row []interface{}) error {
+			return sheet.WriteRow(table.values(row, false))
+		}); err != nil {
+			return err
+		}
+		return sheet.Close()
+	}
+
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.columnNames(false)); err != nil {
+		return err
+	}
+	record := make([]string, 0, len(table.columns))
+	if err := table.each(ctx, farmID, func(row []interface{}) error {
+		record = record[:0]
+		for _, value := range table.values(row, false) {
+			record = append(record, exportCSVValue(value))
+		}
+		return writer.Write(record)
+	}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportCSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (s *exportService) WriteArchive(ctx context.Context, farmID uint, w io.Writer) error {
+	farm, err := s.repo.GetFarm(ctx, farmID)
+	if err != nil {
+		return err
+	}
+
+	header, err := json.Marshal(exportArchiveHeader{
+		Kind:       ExportArchiveKind,
+		Version:    ExportArchiveVersion,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Farm: exportArchiveFarm{
+			ID:          farm.ID,
+			CompanyName: farm.Company.CompanyName,
+			Location:    farm.Company.Location,
+			CNPJ:        farm.Company.FarmCNPJ,
+			Logo:        farm.Logo,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	out.Write(header[:len(header)-1])
+
+	tables := s.tables()
+	for _, entity := range ExportEntities {
+		table := tables[entity]
+		names := table.columnNames(true)
+		fmt.Fprintf(out, ",%q:[", entity)
+
+		first := true
+		err := table.each(ctx, farmID, func(row []interface{}) error {
+			if !first {
+				out.WriteByte(',')
+			}
+			first = false
+
+			out.WriteByte('{')
+			for i, value := range row {
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return err
+				}
+				if i > 0 {
+					out.WriteByte(',')
+				}
+				fmt.Fprintf(out, "%q:", names[i])
+				out.Write(encoded)
+			}
+			out.WriteByte('}')
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		out.WriteByte(']')
+	}
+	out.WriteString("}\n")
+
+	return out.Flush()
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type fakeExportRepository struct {
+	repository.ExportRepository
+	animals []models.Animal
+	events  []models.ReproductionEvent
+}
+
+func (r *fakeExportRepository) GetFarm(ctx context.Context, farmID uint) (*models.Farm, error) {
+	return &models.Farm{ID: farmID}, nil
+}
+
+func (r *fakeExportRepository) EachAnimal(ctx context.Context, farmID uint, fn func([]models.Animal) error) error {
+	return fn(r.animals)
+}
+
+func (r *fakeExportRepository) EachMilkCollection(ctx context.Context, farmID uint, fn func([]models.MilkCollection) error) error {
+	return nil
+}
+
+func (r *fakeExportRepository) EachReproduction(ctx context.Context, farmID uint, fn func([]models.Reproduction) error) error {
+	return nil
+}
+
+func (r *fakeExportRepository) EachReproductionEvent(ctx context.Context, farmID uint, fn func([]models.ReproductionEvent) error) error {
+	return fn(r.events)
+}
+
+func (r *fakeExportRepository) EachSale(ctx context.Context, farmID uint, fn func([]models.Sale) error) error {
+	return nil
+}
+
+func (r *fakeExportRepository) EachExpense(ctx context.Context, farmID uint, fn func([]models.Expense) error) error {
+	return nil
+}
+
+func (r *fakeExportRepository) EachDebt(ctx context.Context, farmID uint, fn func([]models.Debt) error) error {
+	return nil
+}
+
+func (r *fakeExportRepository) EachDebtPayment(ctx context.Context, farmID uint, fn func([]models.DebtPayment) error) error {
+	return nil
+}
+
+func TestWriteArchiveIncludesReproductionEvents(t *testing.T) {
+	positive := true
+	calfID := uint(11)
+	repo := &fakeExportRepository{
+		animals: []models.Animal{{ID: 10, FarmID: ownerFarmID, AnimalName: "Mimosa"}, {ID: 11, FarmID: ownerFarmID, AnimalName: "Bezerra"}},
+		events: []models.ReproductionEvent{
+			{ID: 1, AnimalID: 10, Type: models.ReproductionEventPregnancyCheck, Date: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), PregnancyPositive: &positive},
+			{ID: 2, AnimalID: 10, Type: models.ReproductionEventCalving, Date: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), CalfID: &calfID},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := NewExportService(repo, nil).WriteArchive(context.Background(), ownerFarmID, &out); err != nil {
+		t.Fatalf("WriteArchive: %v", err)
+	}
+
+	var archive map[string]json.RawMessage
+	if err := json.Unmarshal(out.Bytes(), &archive); err != nil {
+		t.Fatalf("archive is not valid JSON: %v\n%s", err, out.String())
+	}
+	for _, entity := range ExportEntities {
+		if _, ok := archive[entity]; !ok {
+			t.Fatalf("archive missing entity %q", entity)
+		}
+	}
+
+	var events []struct {
+		ID                uint   `json:"id"`
+		AnimalID          uint   `json:"animal_id"`
+		Type              string `json:"type"`
+		Date              string `json:"date"`
+		PregnancyPositive *bool  `json:"pregnancy_positive"`
+		CalfID            *uint  `json:"calf_id"`
+	}
+	if err := json.Unmarshal(archive[ExportEntityReproductionEvents], &events); err != nil {
+		t.Fatalf("reproduction events: %v", err)
+	}
+	if len(events) != 2 || events[0].ID != 1 || events[1].ID != 2 {
+		t.Fatalf("reproduction events = %+v, want ids 1 and 2 in order", events)
+	}
+	if events[0].PregnancyPositive == nil || !*events[0].PregnancyPositive || events[0].Date != "2025-02-01" {
+		t.Fatalf("pregnancy check exported as %+v", events[0])
+	}
+	if events[1].Type != models.ReproductionEventCalving || events[1].CalfID == nil || *events[1].CalfID != 11 {
+		t.Fatalf("calving exported as %+v", events[1])
+	}
+}
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"github.com/fazendapro/FazendaPro-api/internal/notification"
 	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
 )
 
 type ServiceFactory struct {
@@ -14,8 +16,14 @@ func NewServiceFactory(repoFactory *repository.RepositoryFactory) *ServiceFactor
 
 func (f *ServiceFactory) CreateAnimalService() *AnimalService {
 	animalRepo := f.repoFactory.CreateAnimalRepository()
+	weightRepo := f.repoFactory.CreateWeightRepository()
 	cacheClient := f.repoFactory.GetCache()
-	return NewAnimalService(animalRepo, cacheClient)
+	return NewAnimalService(animalRepo, weightRepo, cacheClient)
+}
+
+func (f *ServiceFactory) CreatePedigreeService() PedigreeService {
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	return NewPedigreeService(animalRepo)
 }
 
 func (f *ServiceFactory) CreateUserService() *UserService {
@@ -26,13 +34,86 @@ func (f *ServiceFactory) CreateUserService() *UserService {
 func (f *ServiceFactory) CreateMilkCollectionService() *MilkCollectionService {
 	milkCollectionRepo := f.repoFactory.CreateMilkCollectionRepository()
 	animalRepo := f.repoFactory.CreateAnimalRepository()
-	batchService := NewBatchService(animalRepo, milkCollectionRepo)
-	return NewMilkCollectionService(milkCollectionRepo, batchService)
+	return NewMilkCollectionService(milkCollectionRepo, animalRepo, f.CreateBatchService())
+}
+
+func (f *ServiceFactory) CreateBatchService() *BatchService {
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	milkCollectionRepo := f.repoFactory.CreateMilkCollectionRepository()
+	reproductionRepo := f.repoFactory.CreateReproductionRepository()
+	batchRepo := f.repoFactory.CreateBatchRepository()
+	farmRepo := f.repoFactory.CreateFarmRepository()
+	return NewBatchService(animalRepo, milkCollectionRepo, reproductionRepo, batchRepo, farmRepo)
+}
+
+func (f *ServiceFactory) CreateLactationService() LactationService {
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	reproductionEventRepo := f.repoFactory.CreateReproductionEventRepository()
+	milkCollectionRepo := f.repoFactory.CreateMilkCollectionRepository()
+	return NewLactationService(animalRepo, reproductionEventRepo, milkCollectionRepo)
+}
+
+func (f *ServiceFactory) CreateMilkQualityService() MilkQualityService {
+	milkQualityRepo := f.repoFactory.CreateMilkQualityRepository()
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	milkCollectionRepo := f.repoFactory.CreateMilkCollectionRepository()
+	return NewMilkQualityService(milkQualityRepo, animalRepo, milkCollectionRepo)
+}
+
+func (f *ServiceFactory) CreateMilkDeliveryService() MilkDeliveryService {
+	priceTableRepo := f.repoFactory.CreateMilkPriceTableRepository()
+	deliveryRepo := f.repoFactory.CreateMilkDeliveryRepository()
+	milkQualityRepo := f.repoFactory.CreateMilkQualityRepository()
+	milkCollectionRepo := f.repoFactory.CreateMilkCollectionRepository()
+	cacheClient := f.repoFactory.GetCache()
+	return NewMilkDeliveryService(priceTableRepo, deliveryRepo, milkQualityRepo, milkCollectionRepo, cacheClient)
 }
 
 func (f *ServiceFactory) CreateReproductionService() *ReproductionService {
 	reproductionRepo := f.repoFactory.CreateReproductionRepository()
-	return NewReproductionService(reproductionRepo)
+	reproductionEventRepo := f.repoFactory.CreateReproductionEventRepository()
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	gestationRepo := f.repoFactory.CreateGestationPeriodRepository()
+	return NewReproductionService(reproductionRepo, reproductionEventRepo, animalRepo, gestationRepo)
+}
+
+func (f *ServiceFactory) CreateSemenCatalogService() SemenCatalogService {
+	semenRepo := f.repoFactory.CreateSemenCatalogRepository()
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	return NewSemenCatalogService(semenRepo, animalRepo)
+}
+
+func (f *ServiceFactory) CreateMatingRecommendationService() MatingRecommendationService {
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	reproductionRepo := f.repoFactory.CreateReproductionRepository()
+	milkCollectionRepo := f.repoFactory.CreateMilkCollectionRepository()
+	semenRepo := f.repoFactory.CreateSemenCatalogRepository()
+	return NewMatingRecommendationService(animalRepo, reproductionRepo, milkCollectionRepo, semenRepo)
+}
+
+func (f *ServiceFactory) CreateGestationService() *GestationService {
+	gestationRepo := f.repoFactory.CreateGestationPeriodRepository()
+	reproductionRepo := f.repoFactory.CreateReproductionRepository()
+	return NewGestationService(gestationRepo, reproductionRepo)
+}
+
+func (f *ServiceFactory) CreateReproductiveKPIService() ReproductiveKPIService {
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	reproductionEventRepo := f.repoFactory.CreateReproductionEventRepository()
+	return NewReproductiveKPIService(animalRepo, reproductionEventRepo)
+}
+
+func (f *ServiceFactory) CreateNotificationService() NotificationService {
+	notificationRepo := f.repoFactory.CreateNotificationRepository()
+	return NewNotificationService(notificationRepo)
+}
+
+func (f *ServiceFactory) CreateAlertService(channels []notification.Channel) AlertService {
+	reproductionRepo := f.repoFactory.CreateReproductionRepository()
+	reproductionEventRepo := f.repoFactory.CreateReproductionEventRepository()
+	notificationRepo := f.repoFactory.CreateNotificationRepository()
+	userRepo := f.repoFactory.CreateUserRepository()
+	return NewAlertService(reproductionRepo, reproductionEventRepo, notificationRepo, userRepo, channels)
 }
 
 func (f *ServiceFactory) CreateFarmService() *FarmService {
@@ -47,7 +128,54 @@ func (f *ServiceFactory) CreateSaleService() SaleService {
 	return NewSaleService(saleRepo, animalRepo, cacheClient)
 }
 
+func (f *ServiceFactory) CreateWeightService() WeightService {
+	weightRepo := f.repoFactory.CreateWeightRepository()
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	return NewWeightService(weightRepo, animalRepo)
+}
+
+func (f *ServiceFactory) CreateExpenseService() ExpenseService {
+	expenseRepo := f.repoFactory.CreateExpenseRepository()
+	cacheClient := f.repoFactory.GetCache()
+	return NewExpenseService(expenseRepo, cacheClient)
+}
+
+func (f *ServiceFactory) CreateReportService() ReportService {
+	reportRepo := f.repoFactory.CreateReportRepository()
+	cacheClient := f.repoFactory.GetCache()
+	return NewReportService(reportRepo, cacheClient)
+}
+
 func (f *ServiceFactory) CreateDebtService() *DebtService {
 	debtRepo := f.repoFactory.CreateDebtRepository()
-	return NewDebtService(debtRepo)
+	saleRepo := f.repoFactory.CreateSaleRepository()
+	expenseRepo := f.repoFactory.CreateExpenseRepository()
+	cacheClient := f.repoFactory.GetCache()
+	return NewDebtService(debtRepo, saleRepo, expenseRepo, cacheClient)
+}
+
+func (f *ServiceFactory) CreateExportService(blobStorage storage.BlobStorage) ExportService {
+	exportRepo := f.repoFactory.CreateExportRepository()
+	return NewExportService(exportRepo, blobStorage)
+}
+
+func (f *ServiceFactory) CreateRestoreService(blobStorage storage.BlobStorage) RestoreService {
+	restoreRepo := f.repoFactory.CreateRestoreRepository()
+	return NewRestoreService(restoreRepo, blobStorage)
+}
+
+func (f *ServiceFactory) CreatePDFReportService() PDFReportService {
+	return NewPDFReportService(f.CreateAnimalService(), f.CreateSaleService(), f.CreateReproductionService(), f.CreateFarmService())
+}
+
+func (f *ServiceFactory) CreateAnimalPhotoService(blobStorage storage.BlobStorage) AnimalPhotoService {
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	cacheClient := f.repoFactory.GetCache()
+	return NewAnimalPhotoService(animalRepo, blobStorage, cacheClient)
+}
+
+func (f *ServiceFactory) CreateAnimalAttachmentService(blobStorage storage.BlobStorage) AnimalAttachmentService {
+	attachmentRepo := f.repoFactory.CreateAnimalAttachmentRepository()
+	animalRepo := f.repoFactory.CreateAnimalRepository()
+	return NewAnimalAttachmentService(attachmentRepo, animalRepo, blobStorage)
 }
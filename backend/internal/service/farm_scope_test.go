@@ -0,0 +1,248 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/cache"
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	ownerFarmID = uint(1)
+	otherFarmID = uint(2)
+)
+
+type fakeCache struct{}
+
+func (fakeCache) Get(key string, dest interface{}) error                    { return cache.ErrCacheMiss }
+func (fakeCache) Set(key string, value interface{}, expiration int32) error { return nil }
+func (fakeCache) Delete(key string) error                                   { return nil }
+func (fakeCache) Increment(key string, delta uint64) (uint64, error)        { return delta, nil }
+
+type fakeAnimalRepository struct {
+	repository.AnimalRepositoryInterface
+	animals map[uint]*models.Animal
+}
+
+func (r *fakeAnimalRepository) FindByIDAndFarmID(id, farmID uint) (*models.Animal, error) {
+	animal, ok := r.animals[id]
+	if !ok || animal.FarmID != farmID {
+		return nil, nil
+	}
+	copied := *animal
+	return &copied, nil
+}
+
+func (r *fakeAnimalRepository) Update(animal *models.Animal) error {
+	existing, ok := r.animals[animal.ID]
+	if !ok || existing.FarmID != animal.FarmID {
+		return fmt.Errorf("%s", repository.ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+	copied := *animal
+	r.animals[animal.ID] = &copied
+	return nil
+}
+
+func (r *fakeAnimalRepository) Delete(id, farmID uint) error {
+	animal, ok := r.animals[id]
+	if !ok || animal.FarmID != farmID {
+		return fmt.Errorf("%s", repository.ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+	delete(r.animals, id)
+	return nil
+}
+
+func (r *fakeAnimalRepository) inFarm(animalID, farmID uint) bool {
+	animal, ok := r.animals[animalID]
+	return ok && animal.FarmID == farmID
+}
+
+type fakeMilkCollectionRepository struct {
+	repository.MilkCollectionRepositoryInterface
+	animals     *fakeAnimalRepository
+	collections map[uint]*models.MilkCollection
+}
+
+func (r *fakeMilkCollectionRepository) FindByID(id, farmID uint) (*models.MilkCollection, error) {
+	collection, ok := r.collections[id]
+	if !ok || !r.animals.inFarm(collection.AnimalID, farmID) {
+		return nil, fmt.Errorf("record not found")
+	}
+	copied := *collection
+	return &copied, nil
+}
+
+func (r *fakeMilkCollectionRepository) ExistsForAnimalShift(animalID uint, day time.Time, shift string, excludeID uint) (bool, error) {
+	return false, nil
+}
+
+func (r *fakeMilkCollectionRepository) Update(milkCollection *models.MilkCollection, farmID uint) error {
+	existing, ok := r.collections[milkCollection.ID]
+	if !ok || !r.animals.inFarm(existing.AnimalID, farmID) {
+		return fmt.Errorf("%s", repository.ErrMilkCollectionNotFoundOrNotBelongsToFarm)
+	}
+	copied := *milkCollection
+	r.collections[milkCollection.ID] = &copied
+	return nil
+}
+
+func (r *fakeMilkCollectionRepository) Delete(id, farmID uint) error {
+	collection, ok := r.collections[id]
+	if !ok || !r.animals.inFarm(collection.AnimalID, farmID) {
+		return fmt.Errorf("%s", repository.ErrMilkCollectionNotFoundOrNotBelongsToFarm)
+	}
+	delete(r.collections, id)
+	return nil
+}
+
+type fakeReproductionRepository struct {
+	repository.ReproductionRepositoryInterface
+	animals        *fakeAnimalRepository
+	reproductions  map[uint]*models.Reproduction
+	deletedAnimals []uint
+}
+
+func (r *fakeReproductionRepository) FindByID(id, farmID uint) (*models.Reproduction, error) {
+	reproduction, ok := r.reproductions[id]
+	if !ok || !r.animals.inFarm(reproduction.AnimalID, farmID) {
+		return nil, nil
+	}
+	copied := *reproduction
+	return &copied, nil
+}
+
+func (r *fakeReproductionRepository) Update(reproduction *models.Reproduction, farmID uint) error {
+	existing, ok := r.reproductions[reproduction.ID]
+	if !ok || !r.animals.inFarm(existing.AnimalID, farmID) {
+		return fmt.Errorf("%s", repository.ErrReproductionNotFoundOrNotBelongsToFarm)
+	}
+	copied := *reproduction
+	r.reproductions[reproduction.ID] = &copied
+	return nil
+}
+
+type fakeReproductionEventRepository struct {
+	repository.ReproductionEventRepositoryInterface
+	reproductions *fakeReproductionRepository
+}
+
+func (r *fakeReproductionEventRepository) DeleteAnimalHistory(animalID, farmID uint) error {
+	if !r.reproductions.animals.inFarm(animalID, farmID) {
+		return fmt.Errorf("%s", repository.ErrReproductionNotFoundOrNotBelongsToFarm)
+	}
+	for id, reproduction := range r.reproductions.reproductions {
+		if reproduction.AnimalID == animalID {
+			delete(r.reproductions.reproductions, id)
+		}
+	}
+	r.reproductions.deletedAnimals = append(r.reproductions.deletedAnimals, animalID)
+	return nil
+}
+
+func newFarmScopeFixture() (*fakeAnimalRepository, *fakeMilkCollectionRepository, *fakeReproductionRepository) {
+	animals := &fakeAnimalRepository{animals: map[uint]*models.Animal{
+		10: {ID: 10, FarmID: ownerFarmID, AnimalName: "Mimosa", EarTagNumberLocal: 10},
+	}}
+	collections := &fakeMilkCollectionRepository{animals: animals, collections: map[uint]*models.MilkCollection{
+		20: {ID: 20, AnimalID: 10, Liters: 12, Shift: models.MilkShiftMorning, Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}}
+	reproductions := &fakeReproductionRepository{animals: animals, reproductions: map[uint]*models.Reproduction{
+		30: {ID: 30, AnimalID: 10, Observations: "original"},
+	}}
+	return animals, collections, reproductions
+}
+
+func TestAnimalServiceRejectsOtherFarm(t *testing.T) {
+	animals, _, _ := newFarmScopeFixture()
+	service := NewAnimalService(animals, nil, fakeCache{})
+
+	animal, err := service.GetAnimalByID(10, otherFarmID)
+	if err != nil || animal != nil {
+		t.Fatalf("GetAnimalByID from other farm = %v, %v; want nil, nil", animal, err)
+	}
+
+	update := &models.Animal{ID: 10, AnimalName: "Invasora", EarTagNumberLocal: 10}
+	if err := service.UpdateAnimal(update, otherFarmID); err == nil {
+		t.Fatal("UpdateAnimal from other farm succeeded")
+	}
+	if name := animals.animals[10].AnimalName; name != "Mimosa" {
+		t.Fatalf("animal name = %q after cross-farm update, want Mimosa", name)
+	}
+
+	if err := service.DeleteAnimal(10, otherFarmID); err == nil {
+		t.Fatal("DeleteAnimal from other farm succeeded")
+	}
+	if _, ok := animals.animals[10]; !ok {
+		t.Fatal("animal removed by cross-farm delete")
+	}
+
+	if err := service.DeleteAnimal(10, ownerFarmID); err != nil {
+		t.Fatalf("DeleteAnimal from owner farm: %v", err)
+	}
+}
+
+func TestMilkCollectionServiceRejectsOtherFarm(t *testing.T) {
+	animals, collections, _ := newFarmScopeFixture()
+	service := NewMilkCollectionService(collections, animals, nil)
+
+	if _, err := service.GetMilkCollectionByID(20, otherFarmID); err == nil {
+		t.Fatal("GetMilkCollectionByID from other farm succeeded")
+	}
+
+	update := &models.MilkCollection{ID: 20, AnimalID: 10, Liters: 99, Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}
+	err := service.UpdateMilkCollection(update, otherFarmID)
+	if err == nil || err.Error() != ErrAnimalNotFoundOrNotBelongsToFarm {
+		t.Fatalf("UpdateMilkCollection from other farm = %v, want %q", err, ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+	if liters := collections.collections[20].Liters; liters != 12 {
+		t.Fatalf("liters = %v after cross-farm update, want 12", liters)
+	}
+
+	err = service.DeleteMilkCollection(20, otherFarmID)
+	if err == nil || err.Error() != ErrMilkCollectionNotFoundOrNotBelongsToFarm {
+		t.Fatalf("DeleteMilkCollection from other farm = %v, want %q", err, ErrMilkCollectionNotFoundOrNotBelongsToFarm)
+	}
+	if _, ok := collections.collections[20]; !ok {
+		t.Fatal("milk collection removed by cross-farm delete")
+	}
+
+	if _, err := service.GetMilkCollectionByID(20, ownerFarmID); err != nil {
+		t.Fatalf("GetMilkCollectionByID from owner farm: %v", err)
+	}
+}
+
+func TestReproductionServiceRejectsOtherFarm(t *testing.T) {
+	animals, _, reproductions := newFarmScopeFixture()
+	events := &fakeReproductionEventRepository{reproductions: reproductions}
+	service := NewReproductionService(reproductions, events, animals, nil)
+
+	reproduction, err := service.GetReproductionByID(30, otherFarmID)
+	if err != nil || reproduction != nil {
+		t.Fatalf("GetReproductionByID from other farm = %v, %v; want nil, nil", reproduction, err)
+	}
+
+	observations := "alterada"
+	update := ReproductionUpdate{PhaseTransitionData: PhaseTransitionData{Observations: &observations}}
+	err = service.UpdateReproduction(30, otherFarmID, update)
+	if err == nil || err.Error() != ErrReproductionNotFoundOrNotBelongsToFarm {
+		t.Fatalf("UpdateReproduction from other farm = %v, want %q", err, ErrReproductionNotFoundOrNotBelongsToFarm)
+	}
+	if observations := reproductions.reproductions[30].Observations; observations != "original" {
+		t.Fatalf("observations = %q after cross-farm update, want original", observations)
+	}
+
+	err = service.DeleteReproduction(30, otherFarmID)
+	if err == nil || err.Error() != ErrReproductionNotFoundOrNotBelongsToFarm {
+		t.Fatalf("DeleteReproduction from other farm = %v, want %q", err, ErrReproductionNotFoundOrNotBelongsToFarm)
+	}
+	if len(reproductions.deletedAnimals) != 0 {
+		t.Fatalf("history deleted for animals %v by cross-farm delete", reproductions.deletedAnimals)
+	}
+
+	if err := service.DeleteReproduction(30, ownerFarmID); err != nil {
+		t.Fatalf("DeleteReproduction from owner farm: %v", err)
+	}
+}
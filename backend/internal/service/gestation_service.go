@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const GestationMaxDays = 400
+
+type GestationService struct {
+	repository             repository.GestationPeriodRepositoryInterface
+	reproductionRepository repository.ReproductionRepositoryInterface
+}
+
+func NewGestationService(repository repository.GestationPeriodRepositoryInterface, reproductionRepository repository.ReproductionRepositoryInterface) *GestationService {
+	return &GestationService{
+		repository:             repository,
+		reproductionRepository: reproductionRepository,
+	}
+}
+
+func (s *GestationService) GetGestationTable(farmID uint) ([]models.GestationPeriod, error) {
+	return s.repository.FindByFarmID(farmID)
+}
+
+func (s *GestationService) SaveOverride(period *models.GestationPeriod, farmID uint) (int, error) {
+	period.Breed = strings.TrimSpace(period.Breed)
+
+	if models.GetAnimalTypeName(period.AnimalType) == "Desconhecido" {
+		return 0, errors.New("tipo de animal inválido")
+	}
+
+	if period.Days < 1 || period.Days > GestationMaxDays {
+		return 0, errors.New("duração da gestação deve estar entre 1 e 400 dias")
+	}
+
+	existing, err := s.repository.FindOverride(farmID, period.AnimalType, period.Breed)
+	if err != nil {
+		return 0, err
+	}
+
+	if existing != nil {
+		existing.Days = period.Days
+		existing.UpdatedAt = time.Now()
+		if err := s.repository.Update(existing); err != nil {
+			return 0, err
+		}
+		*period = *existing
+	} else {
+		period.ID = 0
+		period.FarmID = &farmID
+		if err := s.repository.Create(period); err != nil {
+			return 0, err
+		}
+	}
+
+	return s.RecalculateExpectedBirthDates(farmID)
+}
+
+func (s *GestationService) DeleteOverride(id, farmID uint) (int, error) {
+	period, err := s.repository.FindOverrideByID(id, farmID)
+	if err != nil {
+		return 0, err
+	}
+
+	if period == nil {
+		return 0, errors.New(ErrGestationPeriodNotFoundOrNotBelongsToFarm)
+	}
+
+	if err := s.repository.Delete(id, farmID); err != nil {
+		return 0, err
+	}
+
+	return s.RecalculateExpectedBirthDates(farmID)
+}
+
+func (s *GestationService) RecalculateExpectedBirthDates(farmID uint) (int, error) {
+	periods, err := s.repository.FindByFarmID(farmID)
+	if err != nil {
+		return 0, err
+	}
+
+	reproductions, err := s.reproductionRepository.FindByFarmID(farmID)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := models.ExpectedBirthDateChanges(reproductions, periods)
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	if err := s.reproductionRepository.UpdateExpectedBirthDates(changed); err != nil {
+		return 0, err
+	}
+
+	return len(changed), nil
+}
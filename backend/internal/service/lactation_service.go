@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	LactationStandardDays   = 305
+	LactationCurveMinPoints = 5
+	LactationCurveMinR2     = 0.5
+	LactationEarlyDays      = 100
+	LactationMidDays        = 200
+)
+
+type LactationPoint struct {
+	Date        time.Time
+	DaysInMilk  int
+	Liters      float64
+	FittedValue *float64
+}
+
+type WoodCurve struct {
+	A           float64
+	B           float64
+	C           float64
+	RSquared    float64
+	Reliable    bool
+	PeakDay     *float64
+	PeakYield   *float64
+	Persistency *float64
+}
+
+func (c *WoodCurve) Value(day float64) float64 {
+	return c.A * math.Pow(day, c.B) * math.Exp(-c.C*day)
+}
+
+type Lactation struct {
+	Number                int
+	StartDate             time.Time
+	EndDate               *time.Time
+	Ongoing               bool
+	DaysInMilk            int
+	TestDays              int
+	TotalYield            float64
+	AverageDailyYield     *float64
+	PeakYield             *float64
+	PeakDaysInMilk        *int
+	Persistency           *float64
+	Projected305Yield     *float64
+	Projected305Estimated bool
+	Curve                 *WoodCurve
+	Points                []LactationPoint
+}
+
+type LactationService interface {
+	GetLactations(ctx context.Context, animalID, farmID uint) ([]Lactation, error)
+}
+
+type lactationService struct {
+	animalRepo         repository.AnimalRepositoryInterface
+	eventRepo          repository.ReproductionEventRepositoryInterface
+	milkCollectionRepo repository.MilkCollectionRepositoryInterface
+}
+
+func NewLactationService(animalRepo repository.AnimalRepositoryInterface, eventRepo repository.ReproductionEventRepositoryInterface, milkCollectionRepo repository.MilkCollectionRepositoryInterface) LactationService {
+	return &lactationService{
+		animalRepo:         animalRepo,
+		eventRepo:          eventRepo,
+		milkCollectionRepo: milkCollectionRepo,
+	}
+}
+
+func (s *lactationService) GetLactations(ctx context.Context, animalID, farmID uint) ([]Lactation, error) {
+	if err := checkAnimalInFarm(s.animalRepo, animalID, farmID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.FindByAnimalID(animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := s.milkCollectionRepo.FindByAnimalID(animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeLactations(events, collections, time.Now()), nil
+}
+
+func calendarDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func computeLactations(events []models.ReproductionEvent, collections []models.MilkCollection, now time.Time) []Lactation {
+	ordered := make([]models.ReproductionEvent, len(events))
+	copy(ordered, events)
+	models.SortReproductionEvents(ordered)
+
+	var lactations []Lactation
+	for _, event := range ordered {
+		date := calendarDay(event.Date)
+		switch event.Type {
+		case models.ReproductionEventCalving:
+			if len(lactations) > 0 {
+				last := &lactations[len(lactations)-1]
+				if last.EndDate == nil {
+					end := date.AddDate(0, 0, -1)
+					last.EndDate = &end
+				}
+			}
+			lactations = append(lactations, Lactation{Number: len(lactations) + 1, StartDate: date})
+		case models.ReproductionEventDryOff:
+			if len(lactations) > 0 {
+				last := &lactations[len(lactations)-1]
+				if last.EndDate == nil && !date.Before(last.StartDate) {
+					last.EndDate = &date
+				}
+			}
+		}
+	}
+
+	dailyTotals := make(map[time.Time]float64)
+	for _, collection := range collections {
+		dailyTotals[calendarDay(collection.Date)] += collection.Liters
+	}
+
+	today := calendarDay(now)
+	for i := range lactations {
+		lactation := &lactations[i]
+		end := today
+		if lactation.EndDate != nil {
+			end = *lactation.EndDate
+		} else {
+			lactation.Ongoing = true
+		}
+		lactation.DaysInMilk = daysBetween(lactation.StartDate, end)
+
+		for day, liters := range dailyTotals {
+			if day.Before(lactation.StartDate) || day.After(end) {
+				continue
+			}
+			lactation.Points = append(lactation.Points, LactationPoint{
+				Date:       day,
+				DaysInMilk: daysBetween(lactation.StartDate, day),
+				Liters:     liters,
+			})
+		}
+		sort.Slice(lactation.Points, func(a, b int) bool { return lactation.Points[a].DaysInMilk < lactation.Points[b].DaysInMilk })
+
+		summarizeLactation(lactation)
+	}
+
+	return lactations
+}
+
+func summarizeLactation(lactation *Lactation) {
+	lactation.TestDays = len(lactation.Points)
+	if lactation.TestDays == 0 {
+		return
+	}
+
+	var early, mid kpiAccumulator
+	for i := range lactation.Points {
+		point := &lactation.Points[i]
+		lactation.TotalYield += point.Liters
+		if lactation.PeakYield == nil || point.Liters > *lactation.PeakYield {
+			peak, dim := point.Liters, point.DaysInMilk
+			lactation.PeakYield = &peak
+			lactation.PeakDaysInMilk = &dim
+		}
+		switch {
+		case point.DaysInMilk >= 1 && point.DaysInMilk <= LactationEarlyDays:
+			early.add(point.Liters)
+		case point.DaysInMilk > LactationEarlyDays && point.DaysInMilk <= LactationMidDays:
+			mid.add(point.Liters)
+		}
+	}
+
+	average := lactation.TotalYield / float64(lactation.TestDays)
+	lactation.AverageDailyYield = &average
+
+	earlyAverage, midAverage := early.metric().Value, mid.metric().Value
+	if earlyAverage != nil && midAverage != nil && *earlyAverage > 0 {
+		persistency := *midAverage / *earlyAverage * 100
+		lactation.Persistency = &persistency
+	}
+
+	lactation.Curve = fitWoodCurve(lactation.Points)
+	if lactation.Curve != nil && lactation.Curve.Reliable {
+		for i := range lactation.Points {
+			if lactation.Points[i].DaysInMilk < 1 {
+				continue
+			}
+			fitted := lactation.Curve.Value(float64(lactation.Points[i].DaysInMilk))
+			lactation.Points[i].FittedValue = &fitted
+		}
+	}
+
+	lactation.Projected305Yield, lactation.Projected305Estimated = projected305Yield(lactation)
+}
+
+func projected305Yield(lactation *Lactation) (*float64, bool) {
+	lastDay := LactationStandardDays
+	if !lactation.Ongoing && lactation.DaysInMilk < lastDay {
+		lastDay = lactation.DaysInMilk
+	}
+
+	observed := make(map[int]float64)
+	var days []int
+	for _, point := range lactation.Points {
+		if point.DaysInMilk >= 1 && point.DaysInMilk <= lastDay {
+			observed[point.DaysInMilk] = point.Liters
+			days = append(days, point.DaysInMilk)
+		}
+	}
+	if len(days) == 0 {
+		return nil, false
+	}
+
+	curve := lactation.Curve
+	if curve != nil && !curve.Reliable {
+		curve = nil
+	}
+
+	total := 0.0
+	estimated := false
+	next := 0
+	for day := 1; day <= lastDay; day++ {
+		for next < len(days) && days[next] < day {
+			next++
+		}
+		switch {
+		case next < len(days) && days[next] == day:
+			total += observed[day]
+			continue
+		case curve != nil:
+			total += curve.Value(float64(day))
+			continue
+		case next == 0:
+			total += observed[days[0]]
+		case next == len(days):
+			total += extrapolateDecline(observed, days, day)
+		default:
+			before, after := days[next-1], days[next]
+			weight := float64(day-before) / float64(after-before)
+			total += observed[before] + (observed[after]-observed[before])*weight
+		}
+		estimated = true
+	}
+
+	return &total, estimated
+}
+
+func extrapolateDecline(observed map[int]float64, days []int, day int) float64 {
+	last := days[len(days)-1]
+	if len(days) == 1 {
+		return observed[last]
+	}
+
+	previous := days[len(days)-2]
+	slope := math.Min(0, (observed[last]-observed[previous])/float64(last-previous))
+	return math.Max(0, observed[last]+slope*float64(day-last))
+}
+
+func fitWoodCurve(points []LactationPoint) *WoodCurve {
+	var samples []LactationPoint
+	for _, point := range points {
+		if point.DaysInMilk >= 1 && point.Liters > 0 {
+			samples = append(samples, point)
+		}
+	}
+	if len(samples) < LactationCurveMinPoints {
+		return nil
+	}
+
+	var normal [3][4]float64
+	for _, sample := range samples {
+		t := float64(sample.DaysInMilk)
+		row := [3]float64{1, math.Log(t), -t}
+		y := math.Log(sample.Liters)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				normal[i][j] += row[i] * row[j]
+			}
+			normal[i][3] += row[i] * y
+		}
+	}
+
+	solution, ok := solveLinearSystem(normal)
+	if !ok {
+		return nil
+	}
+
+	curve := &WoodCurve{A: math.Exp(solution[0]), B: solution[1], C: solution[2]}
+
+	var mean, totalSquares, residualSquares float64
+	for _, sample := range samples {
+		mean += sample.Liters
+	}
+	mean /= float64(len(samples))
+	for _, sample := range samples {
+		residual := sample.Liters - curve.Value(float64(sample.DaysInMilk))
+		residualSquares += residual * residual
+		totalSquares += (sample.Liters - mean) * (sample.Liters - mean)
+	}
+	if totalSquares > 0 {
+		curve.RSquared = 1 - residualSquares/totalSquares
+	}
+
+	if curve.B > 0 && curve.C > 0 {
+		curve.Reliable = curve.RSquared >= LactationCurveMinR2
+		peakDay := curve.B / curve.C
+		peakYield := curve.Value(peakDay)
+		persistency := -(curve.B + 1) * math.Log(curve.C)
+		curve.PeakDay = &peakDay
+		curve.PeakYield = &peakYield
+		curve.Persistency = &persistency
+	}
+
+	return curve
+}
+
+func solveLinearSystem(matrix [3][4]float64) ([3]float64, bool) {
+	var solution [3]float64
+	for col := 0; col < 3; col++ {
+		pivot := col
+		for row := col + 1; row < 3; row++ {
+			if math.Abs(matrix[row][col]) > math.Abs(matrix[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(matrix[pivot][col]) < 1e-12 {
+			return solution, false
+		}
+		matrix[col], matrix[pivot] = matrix[pivot], matrix[col]
+
+		for row := col + 1; row < 3; row++ {
+			factor := matrix[row][col] / matrix[col][col]
+			for k := col; k < 4; k++ {
+				matrix[row][k] -= factor * matrix[col][k]
+			}
+		}
+	}
+
+	for row := 2; row >= 0; row-- {
+		value := matrix[row][3]
+		for k := row + 1; k < 3; k++ {
+			value -= matrix[row][k] * solution[k]
+		}
+		solution[row] = value / matrix[row][row]
+	}
+	return solution, true
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func lactationWithYields(ongoing bool, daysInMilk int, yield func(day int) float64, days ...int) *Lactation {
+	lactation := &Lactation{Ongoing: ongoing, DaysInMilk: daysInMilk}
+	for _, day := range days {
+		lactation.Points = append(lactation.Points, LactationPoint{DaysInMilk: day, Liters: yield(day)})
+	}
+	return lactation
+}
+
+func TestSummarizeLactationUsesReliableWoodCurve(t *testing.T) {
+	wood := func(day int) float64 { return 15 * math.Pow(float64(day), 0.25) * math.Exp(-0.004*float64(day)) }
+	lactation := lactationWithYields(true, 150, wood, 10, 30, 50, 70, 90, 110, 130, 150)
+
+	summarizeLactation(lactation)
+
+	if lactation.Curve == nil || !lactation.Curve.Reliable {
+		t.Fatalf("curve = %+v, want reliable Wood curve", lactation.Curve)
+	}
+	if lactation.Projected305Yield == nil || lactation.Projected305Estimated {
+		t.Fatalf("projection = %v estimated=%v, want curve-based projection", lactation.Projected305Yield, lactation.Projected305Estimated)
+	}
+	if lactation.Points[0].FittedValue == nil {
+		t.Fatal("fitted values missing for reliable curve")
+	}
+}
+
+func TestSummarizeLactationFallsBackToLinearEstimate(t *testing.T) {
+	rising := func(day int) float64 { return 10 + float64(day)/5 }
+	lactation := lactationWithYields(true, 30, rising, 5, 10, 15, 20, 25)
+
+	summarizeLactation(lactation)
+
+	if lactation.Curve != nil && lactation.Curve.Reliable {
+		t.Fatalf("curve = %+v, want unreliable curve for rising yields", lactation.Curve)
+	}
+	if lactation.Points[0].FittedValue != nil {
+		t.Fatal("fitted values set for unreliable curve")
+	}
+	if lactation.Projected305Yield == nil || !lactation.Projected305Estimated {
+		t.Fatalf("projection = %v estimated=%v, want linear estimate", lactation.Projected305Yield, lactation.Projected305Estimated)
+	}
+
+	maxTotal := rising(25) * LactationStandardDays
+	if *lactation.Projected305Yield > maxTotal {
+		t.Fatalf("projection = %.1f, want at most %.1f when rising trend is not extrapolated", *lactation.Projected305Yield, maxTotal)
+	}
+}
+
+func TestExtrapolateDeclineStopsAtZero(t *testing.T) {
+	observed := map[int]float64{10: 20, 20: 10}
+	days := []int{10, 20}
+
+	if got := extrapolateDecline(observed, days, 25); got != 5 {
+		t.Fatalf("extrapolateDecline(day 25) = %v, want 5", got)
+	}
+	if got := extrapolateDecline(observed, days, 60); got != 0 {
+		t.Fatalf("extrapolateDecline(day 60) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,428 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	MatingSourceFarm            = "farm"
+	MatingSourceCatalog         = "catalog"
+	MatingInbreedingThreshold   = 0.0625
+	MatingDefaultLimit          = 5
+	MatingMaxLimit              = 20
+	MatingPerformanceWindowDays = 365
+	MatingStandardLactationDays = 305
+)
+
+type MatingCandidate struct {
+	Source                string
+	Sire                  *models.Animal
+	SemenEntry            *models.SemenCatalogEntry
+	InseminationType      string
+	InbreedingCoefficient float64
+	CommonAncestors       []*models.Animal
+	DaughterCount         int
+	DaughterAverageLiters *float64
+	MilkIndex             *float64
+	Recommended           bool
+	Explanation           []string
+}
+
+type CowMatingRecommendation struct {
+	Cow                  *models.Animal
+	LastInseminationType string
+	Candidates           []*MatingCandidate
+}
+
+type MatingRecommendations struct {
+	FarmID              uint
+	HerdAverageLiters   *float64
+	InbreedingThreshold float64
+	Cows                []*CowMatingRecommendation
+}
+
+type MatingRecommendationService interface {
+	GetRecommendations(ctx context.Context, farmID, cowID uint, limit int) (*MatingRecommendations, error)
+}
+
+type matingRecommendationService struct {
+	animalRepo       repository.AnimalRepositoryInterface
+	reproductionRepo repository.ReproductionRepositoryInterface
+	milkRepo         repository.MilkCollectionRepositoryInterface
+	semenRepo        repository.SemenCatalogRepository
+}
+
+func NewMatingRecommendationService(
+	animalRepo repository.AnimalRepositoryInterface,
+	reproductionRepo repository.ReproductionRepositoryInterface,
+	milkRepo repository.MilkCollectionRepositoryInterface,
+	semenRepo repository.SemenCatalogRepository,
+) MatingRecommendationService {
+	return &matingRecommendationService{
+		animalRepo:       animalRepo,
+		reproductionRepo: reproductionRepo,
+		milkRepo:         milkRepo,
+		semenRepo:        semenRepo,
+	}
+}
+
+type sirePerformance struct {
+	daughterCount int
+	averageLiters *float64
+	milkIndex     *float64
+}
+
+type candidateSire struct {
+	source      string
+	sire        *models.Animal
+	entry       *models.SemenCatalogEntry
+	performance sirePerformance
+}
+
+func validateMatingLimit(limit int) (int, error) {
+	if limit == 0 {
+		return MatingDefaultLimit, nil
+	}
+	if limit < 1 || limit > MatingMaxLimit {
+		return 0, errors.New("limit must be between 1 and 20")
+	}
+	return limit, nil
+}
+
+func (s *matingRecommendationService) GetRecommendations(ctx context.Context, farmID, cowID uint, limit int) (*MatingRecommendations, error) {
+	limit, err := validateMatingLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	animals, err := s.animalRepo.FindByFarmID(farmID)
+	if err != nil {
+		return nil, err
+	}
+	index := newPedigreeIndex(animals)
+
+	cows, err := s.loadCows(index, farmID, cowID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.semenRepo.GetByFarmID(ctx, farmID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := time.Now().AddDate(0, 0, -MatingPerformanceWindowDays)
+	collections, err := s.milkRepo.FindByFarmIDWithDateRange(farmID, &startDate, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	yields := dailyYieldsByAnimal(collections)
+	herdAverage := averageDailyYield(yields, nil)
+
+	var sires []*candidateSire
+	for _, animal := range sortedAnimals(index) {
+		if animal.Sex != models.AnimalSexMale || animal.Status != models.AnimalStatusActive || animal.Castrated {
+			continue
+		}
+		sires = append(sires, &candidateSire{
+			source:      MatingSourceFarm,
+			sire:        animal,
+			performance: daughterPerformance(index, animal.ID, yields, herdAverage),
+		})
+	}
+	for _, entry := range entries {
+		sires = append(sires, &candidateSire{
+			source:      MatingSourceCatalog,
+			entry:       entry,
+			performance: catalogPerformance(entry, herdAverage),
+		})
+	}
+
+	result := &MatingRecommendations{
+		FarmID:              farmID,
+		HerdAverageLiters:   herdAverage,
+		InbreedingThreshold: MatingInbreedingThreshold,
+		Cows:                make([]*CowMatingRecommendation, 0, len(cows)),
+	}
+
+	for _, cow := range cows {
+		candidates := make([]*MatingCandidate, 0, len(sires))
+		for _, sire := range sires {
+			candidates = append(candidates, buildMatingCandidate(index, cow, sire, herdAverage))
+		}
+		rankMatingCandidates(candidates)
+		if len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+
+		result.Cows = append(result.Cows, &CowMatingRecommendation{
+			Cow:                  &cow.Animal,
+			LastInseminationType: cow.InseminationType,
+			Candidates:           candidates,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *matingRecommendationService) loadCows(index *pedigreeIndex, farmID, cowID uint) ([]*models.Reproduction, error) {
+	if cowID != 0 {
+		cow := index.animals[cowID]
+		if cow == nil {
+			return nil, errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
+		}
+		if cow.Sex != models.AnimalSexFemale {
+			return nil, errors.New("animal must be a female animal")
+		}
+
+		reproduction, err := s.reproductionRepo.FindByAnimalID(cowID, farmID)
+		if err != nil {
+			return nil, err
+		}
+		if reproduction == nil {
+			reproduction = &models.Reproduction{AnimalID: cowID}
+		}
+		reproduction.Animal = *cow
+		return []*models.Reproduction{reproduction}, nil
+	}
+
+	reproductions, err := s.reproductionRepo.FindByPhase(farmID, models.PhaseVazias)
+	if err != nil {
+		return nil, err
+	}
+
+	cows := make([]*models.Reproduction, 0, len(reproductions))
+	for i := range reproductions {
+		cow := index.animals[reproductions[i].AnimalID]
+		if cow == nil || cow.Sex != models.AnimalSexFemale || cow.Status != models.AnimalStatusActive {
+			continue
+		}
+		reproductions[i].Animal = *cow
+		cows = append(cows, &reproductions[i])
+	}
+	sort.Slice(cows, func(i, j int) bool {
+		return cows[i].Animal.EarTagNumberLocal < cows[j].Animal.EarTagNumberLocal
+	})
+
+	return cows, nil
+}
+
+func sortedAnimals(index *pedigreeIndex) []*models.Animal {
+	animals := make([]*models.Animal, 0, len(index.animals))
+	for _, animal := range index.animals {
+		animals = append(animals, animal)
+	}
+	sort.Slice(animals, func(i, j int) bool {
+		return animals[i].ID < animals[j].ID
+	})
+	return animals
+}
+
+func dailyYieldsByAnimal(collections []models.MilkCollection) map[uint][]float64 {
+	totals := make(map[uint]map[string]float64)
+	for _, collection := range collections {
+		if totals[collection.AnimalID] == nil {
+			totals[collection.AnimalID] = make(map[string]float64)
+		}
+		totals[collection.AnimalID][collection.Date.Format("2006-01-02")] += collection.Liters
+	}
+
+	yields := make(map[uint][]float64, len(totals))
+	for animalID, days := range totals {
+		for _, liters := range days {
+			yields[animalID] = append(yields[animalID], liters)
+		}
+	}
+	return yields
+}
+
+func averageDailyYield(yields map[uint][]float64, animalIDs []uint) *float64 {
+	if animalIDs == nil {
+		for animalID := range yields {
+			animalIDs = append(animalIDs, animalID)
+		}
+	}
+
+	var total float64
+	var days int
+	for _, animalID := range animalIDs {
+		for _, liters := range yields[animalID] {
+			total += liters
+			days++
+		}
+	}
+	if days == 0 {
+		return nil
+	}
+
+	average := total / float64(days)
+	return &average
+}
+
+func milkIndex(averageLiters float64, herdAverage *float64) *float64 {
+	if herdAverage == nil || *herdAverage == 0 {
+		return nil
+	}
+	index := (averageLiters - *herdAverage) / *herdAverage * 100
+	return &index
+}
+
+func daughterPerformance(index *pedigreeIndex, sireID uint, yields map[uint][]float64, herdAverage *float64) sirePerformance {
+	var daughters []uint
+	for _, childID := range index.children[sireID] {
+		if index.animals[childID].Sex == models.AnimalSexFemale && len(yields[childID]) > 0 {
+			daughters = append(daughters, childID)
+		}
+	}
+
+	performance := sirePerformance{daughterCount: len(daughters)}
+	if len(daughters) == 0 {
+		return performance
+	}
+
+	performance.averageLiters = averageDailyYield(yields, daughters)
+	performance.milkIndex = milkIndex(*performance.averageLiters, herdAverage)
+	return performance
+}
+
+func catalogPerformance(entry *models.SemenCatalogEntry, herdAverage *float64) sirePerformance {
+	var performance sirePerformance
+	if entry.PTAMilk == nil || herdAverage == nil {
+		return performance
+	}
+
+	expected := *herdAverage + *entry.PTAMilk/MatingStandardLactationDays
+	performance.milkIndex = milkIndex(expected, herdAverage)
+	return performance
+}
+
+func (p *pedigreeIndex) knownParent(id *uint) uint {
+	if id == nil || p.animals[*id] == nil {
+		return 0
+	}
+	return *id
+}
+
+func buildMatingCandidate(index *pedigreeIndex, cow *models.Reproduction, sire *candidateSire, herdAverage *float64) *MatingCandidate {
+	candidate := &MatingCandidate{
+		Source:                sire.source,
+		Sire:                  sire.sire,
+		SemenEntry:            sire.entry,
+		DaughterCount:         sire.performance.daughterCount,
+		DaughterAverageLiters: sire.performance.averageLiters,
+		MilkIndex:             sire.performance.milkIndex,
+	}
+
+	cowLine := index.lineage(cow.AnimalID)
+	if sire.source == MatingSourceFarm {
+		candidate.InseminationType = models.InseminationTypeNatural
+		candidate.InbreedingCoefficient = index.coancestry(sire.sire.ID, cow.AnimalID)
+		candidate.CommonAncestors = index.commonAncestors(index.lineage(sire.sire.ID), cowLine)
+	} else {
+		fatherID := index.knownParent(sire.entry.FatherID)
+		motherID := index.knownParent(sire.entry.MotherID)
+
+		sireLine := make(map[uint]bool)
+		for _, parentID := range []uint{fatherID, motherID} {
+			if parentID == 0 {
+				continue
+			}
+			for id := range index.lineage(parentID) {
+				sireLine[id] = true
+			}
+		}
+
+		candidate.InseminationType = models.InseminationTypeArtificial
+		candidate.InbreedingCoefficient = 0.5 * (index.coancestry(fatherID, cow.AnimalID) + index.coancestry(motherID, cow.AnimalID))
+		candidate.CommonAncestors = index.commonAncestors(sireLine, cowLine)
+	}
+
+	candidate.Recommended = candidate.InbreedingCoefficient < MatingInbreedingThreshold
+	candidate.Explanation = explainMatingCandidate(candidate, cow, herdAverage)
+	return candidate
+}
+
+func explainMatingCandidate(candidate *MatingCandidate, cow *models.Reproduction, herdAverage *float64) []string {
+	var explanation []string
+
+	if candidate.Source == MatingSourceFarm {
+		explanation = append(explanation, fmt.Sprintf("Touro da fazenda %s (brinco %d), monta natural", candidate.Sire.AnimalName, candidate.Sire.EarTagNumberLocal))
+	} else {
+		explanation = append(explanation, fmt.Sprintf("Sêmen do catálogo %s (%s), %d doses disponíveis, inseminação artificial", candidate.SemenEntry.BullName, candidate.SemenEntry.Code, candidate.SemenEntry.DosesAvailable))
+	}
+
+	explanation = append(explanation, fmt.Sprintf("Endogamia esperada da cria: %.2f%%", candidate.InbreedingCoefficient*100))
+
+	if len(candidate.CommonAncestors) == 0 {
+		explanation = append(explanation, "Nenhum ancestral comum registrado com a vaca")
+	} else {
+		names := make([]string, len(candidate.CommonAncestors))
+		for i, ancestor := range candidate.CommonAncestors {
+			names[i] = ancestor.AnimalName
+		}
+		explanation = append(explanation, "Ancestrais comuns com a vaca: "+strings.Join(names, ", "))
+	}
+
+	if !candidate.Recommended {
+		explanation = append(explanation, fmt.Sprintf("Atenção: endogamia igual ou acima do limite de %.2f%%, acasalamento não recomendado", MatingInbreedingThreshold*100))
+	}
+
+	if candidate.Source == MatingSourceFarm {
+		if candidate.DaughterAverageLiters == nil {
+			explanation = append(explanation, fmt.Sprintf("Sem filhas com produção registrada nos últimos %d dias", MatingPerformanceWindowDays))
+		} else {
+			explanation = append(explanation, fmt.Sprintf("%d filha(s) em produção com média de %.2f L/dia", candidate.DaughterCount, *candidate.DaughterAverageLiters))
+		}
+	} else if candidate.SemenEntry.PTAMilk == nil {
+		explanation = append(explanation, "PTA de leite não informada no catálogo")
+	} else {
+		explanation = append(explanation, fmt.Sprintf("PTA de leite de %+.0f kg por lactação (%+.2f L/dia em %d dias)", *candidate.SemenEntry.PTAMilk, *candidate.SemenEntry.PTAMilk/MatingStandardLactationDays, MatingStandardLactationDays))
+	}
+
+	if candidate.MilkIndex != nil {
+		explanation = append(explanation, fmt.Sprintf("Índice de leite: %+.1f%% em relação à média do rebanho (%.2f L/dia)", *candidate.MilkIndex, *herdAverage))
+	} else if herdAverage == nil {
+		explanation = append(explanation, "Sem produção do rebanho registrada para comparação")
+	}
+
+	if cow.InseminationType != "" {
+		explanation = append(explanation, "Última inseminação da vaca: "+cow.InseminationType)
+	}
+
+	return explanation
+}
+
+func rankMatingCandidates(candidates []*MatingCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Recommended != b.Recommended {
+			return a.Recommended
+		}
+		if (a.MilkIndex == nil) != (b.MilkIndex == nil) {
+			return a.MilkIndex != nil
+		}
+		if a.MilkIndex != nil && *a.MilkIndex != *b.MilkIndex {
+			return *a.MilkIndex > *b.MilkIndex
+		}
+		if a.InbreedingCoefficient != b.InbreedingCoefficient {
+			return a.InbreedingCoefficient < b.InbreedingCoefficient
+		}
+		return a.sireName() < b.sireName()
+	})
+}
+
+func (c *MatingCandidate) sireName() string {
+	if c.Sire != nil {
+		return c.Sire.AnimalName
+	}
+	return c.SemenEntry.BullName
+}
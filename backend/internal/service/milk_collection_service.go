@@ -1,40 +1,206 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
 	"time"
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
 	"github.com/fazendapro/FazendaPro-api/internal/repository"
 )
 
+const (
+	MilkSessionMaxRows     = 500
+	MilkSessionMaxLiters   = 100
+	MilkDailyTotalsMaxDays = 366
+)
+
+type MilkDailyTotal struct {
+	Date        time.Time
+	Animal      models.Animal
+	Shifts      map[string]float64
+	Milkings    int
+	TotalLiters float64
+}
+
+type MilkSessionRow struct {
+	EarTag int
+	Liters float64
+}
+
+type MilkSessionRowError struct {
+	Row     int
+	EarTag  int
+	Message string
+}
+
+type MilkSessionResult struct {
+	Date         time.Time
+	Shift        string
+	Collections  []models.MilkCollection
+	TotalLiters  float64
+	AnimalsMoved int
+	Errors       []MilkSessionRowError
+}
+
 type MilkCollectionService struct {
-	repository   repository.MilkCollectionRepositoryInterface
-	batchService *BatchService
+	repository       repository.MilkCollectionRepositoryInterface
+	animalRepository repository.AnimalRepositoryInterface
+	batchService     *BatchService
 }
 
-func NewMilkCollectionService(repository repository.MilkCollectionRepositoryInterface, batchService *BatchService) *MilkCollectionService {
+func NewMilkCollectionService(repository repository.MilkCollectionRepositoryInterface, animalRepository repository.AnimalRepositoryInterface, batchService *BatchService) *MilkCollectionService {
 	return &MilkCollectionService{
-		repository:   repository,
-		batchService: batchService,
+		repository:       repository,
+		animalRepository: animalRepository,
+		batchService:     batchService,
 	}
 }
 
-func (s *MilkCollectionService) CreateMilkCollection(milkCollection *models.MilkCollection) error {
+func (s *MilkCollectionService) CreateMilkCollection(milkCollection *models.MilkCollection, farmID uint) error {
+	if err := checkAnimalInFarm(s.animalRepository, milkCollection.AnimalID, farmID); err != nil {
+		return err
+	}
+
+	if milkCollection.Shift == "" {
+		milkCollection.Shift = models.MilkShiftMorning
+	}
+	if err := s.checkMilkShift(milkCollection, 0); err != nil {
+		return err
+	}
+
 	err := s.repository.Create(milkCollection)
 	if err != nil {
 		return err
 	}
 
-	err = s.batchService.UpdateAnimalBatch(milkCollection.AnimalID)
+	if err := s.batchService.UpdateAnimalBatch(milkCollection.AnimalID, farmID); err != nil {
+		log.Printf("Erro ao recalcular lote do animal %d após a coleta (coleta salva): %v", milkCollection.AnimalID, err)
+	}
+
+	return nil
+}
+
+func (s *MilkCollectionService) checkMilkShift(milkCollection *models.MilkCollection, excludeID uint) error {
+	shift, ok := models.ParseMilkShift(milkCollection.Shift)
+	if !ok {
+		return errors.New(ErrInvalidMilkShift)
+	}
+	milkCollection.Shift = shift
+
+	exists, err := s.repository.ExistsForAnimalShift(milkCollection.AnimalID, calendarDay(milkCollection.Date), shift, excludeID)
 	if err != nil {
 		return err
 	}
-
+	if exists {
+		return errors.New(ErrMilkCollectionAlreadyExists)
+	}
 	return nil
 }
 
-func (s *MilkCollectionService) GetMilkCollectionByID(id uint) (*models.MilkCollection, error) {
-	return s.repository.FindByID(id)
+func (s *MilkCollectionService) CreateMilkSession(farmID uint, date time.Time, shift string, rows []MilkSessionRow) (*MilkSessionResult, error) {
+	shift, ok := models.ParseMilkShift(shift)
+	if !ok {
+		return nil, errors.New(ErrInvalidMilkShift)
+	}
+	date = calendarDay(date)
+	if date.After(calendarDay(time.Now())) {
+		return nil, errors.New("data da ordenha não pode ser futura")
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("informe ao menos um animal na ordenha")
+	}
+	if len(rows) > MilkSessionMaxRows {
+		return nil, fmt.Errorf("a ordenha pode ter no máximo %d animais", MilkSessionMaxRows)
+	}
+
+	result := &MilkSessionResult{Date: date, Shift: shift}
+	collections, err := s.repository.CreateSession(farmID, date, shift, func(animals []models.Animal, collected map[uint]bool) []models.MilkCollection {
+		return buildMilkSession(result, rows, animals, collected)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+	result.Collections = collections
+
+	animalIDs := make([]uint, len(result.Collections))
+	for i, collection := range result.Collections {
+		animalIDs[i] = collection.AnimalID
+	}
+	summary, err := s.batchService.UpdateAnimalsBatch(animalIDs, farmID)
+	if err != nil {
+		log.Printf("Erro ao recalcular lotes após a ordenha da fazenda %d (coletas salvas): %v", farmID, err)
+		return result, nil
+	}
+	result.AnimalsMoved = summary.AnimalsMoved
+
+	return result, nil
+}
+
+func buildMilkSession(result *MilkSessionResult, rows []MilkSessionRow, animals []models.Animal, collected map[uint]bool) []models.MilkCollection {
+	animalsByTag := make(map[int]*models.Animal, len(animals))
+	for i := range animals {
+		animalsByTag[animals[i].EarTagNumberLocal] = &animals[i]
+	}
+
+	result.Errors = nil
+	result.TotalLiters = 0
+	var collections []models.MilkCollection
+	seen := make(map[int]int, len(rows))
+	for i, row := range rows {
+		rowError := func(message string) {
+			result.Errors = append(result.Errors, MilkSessionRowError{Row: i + 1, EarTag: row.EarTag, Message: message})
+		}
+
+		if previous, duplicated := seen[row.EarTag]; duplicated {
+			rowError(fmt.Sprintf("brinco repetido na ordenha (linha %d)", previous))
+			continue
+		}
+		seen[row.EarTag] = i + 1
+
+		if row.Liters <= 0 || row.Liters > MilkSessionMaxLiters {
+			rowError(fmt.Sprintf("litros devem ser maiores que zero e no máximo %d", MilkSessionMaxLiters))
+			continue
+		}
+
+		animal, found := animalsByTag[row.EarTag]
+		if !found {
+			rowError("brinco não encontrado entre as fêmeas da fazenda")
+			continue
+		}
+		if animal.Status != models.AnimalStatusActive {
+			rowError("animal não está ativo")
+			continue
+		}
+		if collected[animal.ID] {
+			rowError(ErrMilkCollectionAlreadyExists)
+			continue
+		}
+
+		collections = append(collections, models.MilkCollection{
+			AnimalID: animal.ID,
+			Animal:   *animal,
+			Liters:   row.Liters,
+			Date:     result.Date,
+			Shift:    result.Shift,
+		})
+		result.TotalLiters += row.Liters
+	}
+
+	if len(result.Errors) > 0 {
+		result.TotalLiters = 0
+		return nil
+	}
+	return collections
+}
+
+func (s *MilkCollectionService) GetMilkCollectionByID(id, farmID uint) (*models.MilkCollection, error) {
+	return s.repository.FindByID(id, farmID)
 }
 
 func (s *MilkCollectionService) GetMilkCollectionsByFarmID(farmID uint) ([]models.MilkCollection, error) {
@@ -45,14 +211,98 @@ func (s *MilkCollectionService) GetMilkCollectionsByFarmIDWithDateRange(farmID u
 	return s.repository.FindByFarmIDWithDateRange(farmID, startDate, endDate)
 }
 
-func (s *MilkCollectionService) GetMilkCollectionsByAnimalID(animalID uint) ([]models.MilkCollection, error) {
-	return s.repository.FindByAnimalID(animalID)
+func (s *MilkCollectionService) GetMilkCollectionsByAnimalID(animalID, farmID uint) ([]models.MilkCollection, error) {
+	if err := checkAnimalInFarm(s.animalRepository, animalID, farmID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.FindByAnimalID(animalID, farmID)
 }
 
-func (s *MilkCollectionService) UpdateMilkCollection(milkCollection *models.MilkCollection) error {
-	return s.repository.Update(milkCollection)
+func (s *MilkCollectionService) GetDailyTotals(farmID uint, startDate, endDate time.Time, animalID *uint) ([]MilkDailyTotal, error) {
+	startDate, endDate = calendarDay(startDate), calendarDay(endDate)
+	if endDate.Before(startDate) {
+		return nil, errors.New("data final não pode ser anterior à data inicial")
+	}
+	if daysBetween(startDate, endDate) >= MilkDailyTotalsMaxDays {
+		return nil, fmt.Errorf("o período pode ter no máximo %d dias", MilkDailyTotalsMaxDays)
+	}
+
+	var milkCollections []models.MilkCollection
+	var err error
+	if animalID != nil {
+		if err := checkAnimalInFarm(s.animalRepository, *animalID, farmID); err != nil {
+			return nil, err
+		}
+		milkCollections, err = s.repository.FindByAnimalID(*animalID, farmID)
+	} else {
+		dayEnd := endDate.Add(24*time.Hour - time.Nanosecond)
+		milkCollections, err = s.repository.FindByFarmIDWithDateRange(farmID, &startDate, &dayEnd)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMilkDailyTotals(milkCollections, startDate, endDate), nil
+}
+
+func buildMilkDailyTotals(milkCollections []models.MilkCollection, startDate, endDate time.Time) []MilkDailyTotal {
+	type dailyKey struct {
+		animalID uint
+		day      time.Time
+	}
+
+	totals := make(map[dailyKey]*MilkDailyTotal)
+	for _, collection := range milkCollections {
+		day := calendarDay(collection.Date)
+		if day.Before(startDate) || day.After(endDate) {
+			continue
+		}
+
+		key := dailyKey{animalID: collection.AnimalID, day: day}
+		total, exists := totals[key]
+		if !exists {
+			total = &MilkDailyTotal{Date: day, Animal: collection.Animal, Shifts: make(map[string]float64)}
+			totals[key] = total
+		}
+		total.Shifts[collection.Shift] += collection.Liters
+		total.Milkings++
+		total.TotalLiters += collection.Liters
+	}
+
+	result := make([]MilkDailyTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.After(result[j].Date)
+		}
+		return result[i].Animal.EarTagNumberLocal < result[j].Animal.EarTagNumberLocal
+	})
+
+	return result
+}
+
+func (s *MilkCollectionService) UpdateMilkCollection(milkCollection *models.MilkCollection, farmID uint) error {
+	if err := checkAnimalInFarm(s.animalRepository, milkCollection.AnimalID, farmID); err != nil {
+		return err
+	}
+
+	if milkCollection.Shift == "" {
+		existing, err := s.repository.FindByID(milkCollection.ID, farmID)
+		if err != nil {
+			return errors.New(ErrMilkCollectionNotFoundOrNotBelongsToFarm)
+		}
+		milkCollection.Shift = existing.Shift
+	}
+	if err := s.checkMilkShift(milkCollection, milkCollection.ID); err != nil {
+		return err
+	}
+
+	return s.repository.Update(milkCollection, farmID)
 }
 
-func (s *MilkCollectionService) DeleteMilkCollection(id uint) error {
-	return s.repository.Delete(id)
+func (s *MilkCollectionService) DeleteMilkCollection(id, farmID uint) error {
+	return s.repository.Delete(id, farmID)
 }
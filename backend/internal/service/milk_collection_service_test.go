@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type sessionMilkRepository struct {
+	repository.MilkCollectionRepositoryInterface
+	animals   []models.Animal
+	collected map[uint]bool
+	created   []models.MilkCollection
+}
+
+func (r *sessionMilkRepository) CreateSession(farmID uint, day time.Time, shift string, build repository.MilkSessionBuilder) ([]models.MilkCollection, error) {
+	collections := build(r.animals, r.collected)
+	for i := range collections {
+		collections[i].ID = uint(len(r.created) + 1)
+		r.created = append(r.created, collections[i])
+	}
+	return collections, nil
+}
+
+func newSessionService(repo *sessionMilkRepository, failingBatchFarms map[uint]bool) *MilkCollectionService {
+	batchService := NewBatchService(
+		&rebatchAnimalRepository{failingFarms: failingBatchFarms},
+		rebatchMilkRepository{},
+		rebatchReproductionRepository{},
+		rebatchBatchRepository{},
+		rebatchFarmRepository{},
+	)
+	return NewMilkCollectionService(repo, nil, batchService)
+}
+
+func sessionAnimals() []models.Animal {
+	return []models.Animal{
+		{ID: 10, FarmID: ownerFarmID, EarTagNumberLocal: 101, Status: models.AnimalStatusActive},
+		{ID: 11, FarmID: ownerFarmID, EarTagNumberLocal: 102, Status: models.AnimalStatusActive},
+	}
+}
+
+func TestCreateMilkSessionKeepsCollectionsWhenRebatchFails(t *testing.T) {
+	repo := &sessionMilkRepository{animals: sessionAnimals(), collected: map[uint]bool{}}
+	service := newSessionService(repo, map[uint]bool{ownerFarmID: true})
+
+	rows := []MilkSessionRow{{EarTag: 101, Liters: 12}, {EarTag: 102, Liters: 9.5}}
+	result, err := service.CreateMilkSession(ownerFarmID, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), models.MilkShiftMorning, rows)
+	if err != nil {
+		t.Fatalf("CreateMilkSession with failing rebatch: %v", err)
+	}
+	if len(result.Collections) != 2 || result.Collections[0].ID == 0 {
+		t.Fatalf("collections = %+v, want 2 saved collections", result.Collections)
+	}
+	if result.TotalLiters != 21.5 || result.AnimalsMoved != 0 {
+		t.Fatalf("total liters = %v, animals moved = %d; want 21.5 and 0", result.TotalLiters, result.AnimalsMoved)
+	}
+	if len(repo.created) != 2 {
+		t.Fatalf("%d collections stored, want 2", len(repo.created))
+	}
+}
+
+func TestCreateMilkSessionRejectsCollectedShiftInsideTransaction(t *testing.T) {
+	repo := &sessionMilkRepository{animals: sessionAnimals(), collected: map[uint]bool{11: true}}
+	service := newSessionService(repo, nil)
+
+	rows := []MilkSessionRow{{EarTag: 101, Liters: 12}, {EarTag: 102, Liters: 9.5}}
+	result, err := service.CreateMilkSession(ownerFarmID, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), models.MilkShiftMorning, rows)
+	if err != nil {
+		t.Fatalf("CreateMilkSession: %v", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Row != 2 || result.Errors[0].Message != ErrMilkCollectionAlreadyExists {
+		t.Fatalf("errors = %+v, want duplicate shift on row 2", result.Errors)
+	}
+	if len(result.Collections) != 0 || result.TotalLiters != 0 || len(repo.created) != 0 {
+		t.Fatalf("session with errors stored %d collections (result %d, %v liters)", len(repo.created), len(result.Collections), result.TotalLiters)
+	}
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/cache"
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const MilkPriceTableMaxTiers = 50
+
+type MilkStatementAdjustment struct {
+	Parameter  string
+	Value      *float64
+	Tier       *models.MilkPriceQualityTier
+	Adjustment float64
+}
+
+type MilkStatement struct {
+	Buyer            string
+	Month            time.Time
+	PriceTable       *models.MilkPriceTable
+	Deliveries       int
+	DeliveredLiters  float64
+	CollectedLiters  float64
+	QualityTests     int
+	FatPercent       *float64
+	ProteinPercent   *float64
+	SomaticCellCount *float64
+	BacterialCount   *float64
+	BasePrice        float64
+	VolumeBonus      float64
+	Adjustments      []MilkStatementAdjustment
+	UnitPrice        float64
+	EstimatedAmount  float64
+	Payments         []*models.MilkPayment
+	ReceivedAmount   float64
+	Difference       float64
+}
+
+type MilkDeliveryService interface {
+	CreatePriceTable(ctx context.Context, table *models.MilkPriceTable, farmID uint) error
+	GetPriceTableByID(ctx context.Context, id uint, farmID uint) (*models.MilkPriceTable, error)
+	GetPriceTables(ctx context.Context, farmID uint, buyer string) ([]*models.MilkPriceTable, error)
+	UpdatePriceTable(ctx context.Context, table *models.MilkPriceTable, farmID uint) error
+	DeletePriceTable(ctx context.Context, id uint, farmID uint) error
+	CreateDelivery(ctx context.Context, delivery *models.MilkDelivery, farmID uint) error
+	GetDeliveryByID(ctx context.Context, id uint, farmID uint) (*models.MilkDelivery, error)
+	GetDeliveries(ctx context.Context, farmID uint, filter repository.MilkDeliveryFilter) ([]*models.MilkDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.MilkDelivery, farmID uint) error
+	DeleteDelivery(ctx context.Context, id uint, farmID uint) error
+	CreatePayment(ctx context.Context, payment *models.MilkPayment, farmID uint) error
+	GetPayments(ctx context.Context, farmID uint, buyer string, month *time.Time) ([]*models.MilkPayment, error)
+	DeletePayment(ctx context.Context, id uint, farmID uint) error
+	GetStatement(ctx context.Context, farmID uint, buyer string, month time.Time) (*MilkStatement, error)
+}
+
+type milkDeliveryService struct {
+	priceTableRepo repository.MilkPriceTableRepository
+	deliveryRepo   repository.MilkDeliveryRepository
+	qualityRepo    repository.MilkQualityRepository
+	milkRepo       repository.MilkCollectionRepositoryInterface
+	cache          cache.CacheInterface
+}
+
+func NewMilkDeliveryService(priceTableRepo repository.MilkPriceTableRepository, deliveryRepo repository.MilkDeliveryRepository, qualityRepo repository.MilkQualityRepository, milkRepo repository.MilkCollectionRepositoryInterface, cacheClient cache.CacheInterface) MilkDeliveryService {
+	return &milkDeliveryService{
+		priceTableRepo: priceTableRepo,
+		deliveryRepo:   deliveryRepo,
+		qualityRepo:    qualityRepo,
+		milkRepo:       milkRepo,
+		cache:          cacheClient,
+	}
+}
+
+func monthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func validateMilkPriceTable(table *models.MilkPriceTable) error {
+	table.Buyer = strings.TrimSpace(table.Buyer)
+	table.Name = strings.TrimSpace(table.Name)
+
+	if table.Buyer == "" {
+		return errors.New("buyer is required")
+	}
+	if table.Name == "" {
+		return errors.New("name is required")
+	}
+	if table.BasePrice <= 0 {
+		return errors.New("base price must be greater than zero")
+	}
+	if table.ValidFrom.IsZero() {
+		return errors.New("valid from date is required")
+	}
+	if table.ValidTo != nil && table.ValidTo.Before(table.ValidFrom) {
+		return errors.New("valid to date cannot be before valid from date")
+	}
+	if len(table.VolumeTiers)+len(table.QualityTiers) > MilkPriceTableMaxTiers {
+		return fmt.Errorf("price table cannot have more than %d tiers", MilkPriceTableMaxTiers)
+	}
+
+	volumes := make(map[float64]bool)
+	for _, tier := range table.VolumeTiers {
+		if tier.MinLiters < 0 {
+			return errors.New("volume tier liters cannot be negative")
+		}
+		if volumes[tier.MinLiters] {
+			return fmt.Errorf("duplicate volume tier for %.0f liters", tier.MinLiters)
+		}
+		volumes[tier.MinLiters] = true
+	}
+
+	positions := make(map[string]int)
+	for i := range table.QualityTiers {
+		tier := &table.QualityTiers[i]
+		if !isMilkPriceParameter(tier.Parameter) {
+			return fmt.Errorf("invalid quality parameter %q", tier.Parameter)
+		}
+		if tier.Min == nil && tier.Max == nil {
+			return fmt.Errorf("quality tier %d must have min or max", i+1)
+		}
+		if tier.Min != nil && tier.Max != nil && *tier.Min >= *tier.Max {
+			return fmt.Errorf("quality tier %d min must be lower than max", i+1)
+		}
+		tier.Position = positions[tier.Parameter]
+		positions[tier.Parameter]++
+	}
+
+	return nil
+}
+
+func isMilkPriceParameter(parameter string) bool {
+	for _, valid := range models.MilkPriceParameters {
+		if parameter == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *milkDeliveryService) CreatePriceTable(ctx context.Context, table *models.MilkPriceTable, farmID uint) error {
+	if farmID == 0 {
+		return errors.New("farm ID is required")
+	}
+	table.FarmID = farmID
+	if err := validateMilkPriceTable(table); err != nil {
+		return err
+	}
+	return s.priceTableRepo.Create(ctx, table)
+}
+
+func (s *milkDeliveryService) GetPriceTableByID(ctx context.Context, id uint, farmID uint) (*models.MilkPriceTable, error) {
+	return s.priceTableRepo.GetByID(ctx, id, farmID)
+}
+
+func (s *milkDeliveryService) GetPriceTables(ctx context.Context, farmID uint, buyer string) ([]*models.MilkPriceTable, error) {
+	return s.priceTableRepo.GetByFarmID(ctx, farmID, strings.TrimSpace(buyer))
+}
+
+func (s *milkDeliveryService) UpdatePriceTable(ctx context.Context, table *models.MilkPriceTable, farmID uint) error {
+	table.FarmID = farmID
+	if err := validateMilkPriceTable(table); err != nil {
+		return err
+	}
+	return s.priceTableRepo.Update(ctx, table)
+}
+
+func (s *milkDeliveryService) DeletePriceTable(ctx context.Context, id uint, farmID uint) error {
+	return s.priceTableRepo.Delete(ctx, id, farmID)
+}
+
+func validateMilkDelivery(delivery *models.MilkDelivery) error {
+	delivery.Buyer = strings.TrimSpace(delivery.Buyer)
+	delivery.Tank = strings.TrimSpace(delivery.Tank)
+
+	if delivery.Buyer == "" {
+		return errors.New("buyer is required")
+	}
+	if delivery.DeliveryDate.IsZero() {
+		return errors.New("delivery date is required")
+	}
+	if delivery.DeliveryDate.After(time.Now()) {
+		return errors.New("delivery date cannot be in the future")
+	}
+	if delivery.Liters <= 0 {
+		return errors.New("liters must be greater than zero")
+	}
+	return nil
+}
+
+func (s *milkDeliveryService) CreateDelivery(ctx context.Context, delivery *models.MilkDelivery, farmID uint) error {
+	if farmID == 0 {
+		return errors.New("farm ID is required")
+	}
+	delivery.FarmID = farmID
+	if err := validateMilkDelivery(delivery); err != nil {
+		return err
+	}
+	return s.deliveryRepo.Create(ctx, delivery)
+}
+
+func (s *milkDeliveryService) GetDeliveryByID(ctx context.Context, id uint, farmID uint) (*models.MilkDelivery, error) {
+	return s.deliveryRepo.GetByID(ctx, id, farmID)
+}
+
+func (s *milkDeliveryService) GetDeliveries(ctx context.Context, farmID uint, filter repository.MilkDeliveryFilter) ([]*models.MilkDelivery, error) {
+	filter.Buyer = strings.TrimSpace(filter.Buyer)
+	return s.deliveryRepo.GetByFarmID(ctx, farmID, filter)
+}
+
+func (s *milkDeliveryService) UpdateDelivery(ctx context.Context, delivery *models.MilkDelivery, farmID uint) error {
+	delivery.FarmID = farmID
+	if err := validateMilkDelivery(delivery); err != nil {
+		return err
+	}
+	return s.deliveryRepo.Update(ctx, delivery)
+}
+
+func (s *milkDeliveryService) DeleteDelivery(ctx context.Context, id uint, farmID uint) error {
+	return s.deliveryRepo.Delete(ctx, id, farmID)
+}
+
+func (s *milkDeliveryService) CreatePayment(ctx context.Context, payment *models.MilkPayment, farmID uint) error {
+	if farmID == 0 {
+		return errors.New("farm ID is required")
+	}
+	payment.FarmID = farmID
+	payment.Buyer = strings.TrimSpace(payment.Buyer)
+
+	if payment.Buyer == "" {
+		return errors.New("buyer is required")
+	}
+	if payment.Month.IsZero() {
+		return errors.New("month is required")
+	}
+	if payment.Amount <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	if payment.PaymentDate.IsZero() {
+		return errors.New("payment date is required")
+	}
+
+	payment.Month = monthStart(payment.Month)
+	if err := s.deliveryRepo.CreatePayment(ctx, payment); err != nil {
+		return err
+	}
+	bumpFarmCacheVersion(s.cache, CacheScopePnL, farmID)
+	return nil
+}
+
+func (s *milkDeliveryService) GetPayments(ctx context.Context, farmID uint, buyer string, month *time.Time) ([]*models.MilkPayment, error) {
+	if month != nil {
+		start := monthStart(*month)
+		month = &start
+	}
+	return s.deliveryRepo.GetPayments(ctx, farmID, strings.TrimSpace(buyer), month)
+}
+
+func (s *milkDeliveryService) DeletePayment(ctx context.Context, id uint, farmID uint) error {
+	if err := s.deliveryRepo.DeletePayment(ctx, id, farmID); err != nil {
+		return err
+	}
+	bumpFarmCacheVersion(s.cache, CacheScopePnL, farmID)
+	return nil
+}
+
+func (s *milkDeliveryService) GetStatement(ctx context.Context, farmID uint, buyer string, month time.Time) (*MilkStatement, error) {
+	buyer = strings.TrimSpace(buyer)
+	if farmID == 0 {
+		return nil, errors.New("farm ID is required")
+	}
+	if buyer == "" {
+		return nil, errors.New("buyer is required")
+	}
+
+	start := monthStart(month)
+	end := start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+
+	table, err := s.priceTableRepo.FindValid(ctx, farmID, buyer, start)
+	if err != nil {
+		return nil, err
+	}
+	if table == nil {
+		return nil, errors.New(ErrMilkPriceTableNotFoundForMonth)
+	}
+
+	deliveries, err := s.deliveryRepo.GetByFarmID(ctx, farmID, repository.MilkDeliveryFilter{Buyer: buyer, StartDate: &start, EndDate: &end})
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := s.milkRepo.FindByFarmIDWithDateRange(farmID, &start, &end)
+	if err != nil {
+		return nil, err
+	}
+
+	tests, err := s.qualityRepo.GetByFarmID(ctx, farmID, repository.MilkQualityFilter{TankOnly: true, StartDate: &start, EndDate: &end})
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.deliveryRepo.GetPayments(ctx, farmID, buyer, &start)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := buildMilkStatement(table, deliveries, collections, buyerTankTests(tests, deliveries), payments)
+	statement.Buyer = buyer
+	statement.Month = start
+	return statement, nil
+}
+
+func buyerTankTests(tests []*models.MilkQualityTest, deliveries []*models.MilkDelivery) []*models.MilkQualityTest {
+	tanks := make(map[string]bool)
+	for _, delivery := range deliveries {
+		if delivery.Tank != "" {
+			tanks[strings.ToLower(delivery.Tank)] = true
+		}
+	}
+	if len(tanks) == 0 {
+		return tests
+	}
+
+	var filtered []*models.MilkQualityTest
+	for _, test := range tests {
+		if tanks[strings.ToLower(strings.TrimSpace(test.Tank))] {
+			filtered = append(filtered, test)
+		}
+	}
+	return filtered
+}
+
+type geometricAccumulator struct {
+	logSum float64
+	count  int
+}
+
+func (a *geometricAccumulator) add(value float64) {
+	a.logSum += math.Log(math.Max(value, 1))
+	a.count++
+}
+
+func (a *geometricAccumulator) mean() *float64 {
+	if a.count == 0 {
+		return nil
+	}
+	mean := math.Exp(a.logSum / float64(a.count))
+	return &mean
+}
+
+func buildMilkStatement(table *models.MilkPriceTable, deliveries []*models.MilkDelivery, collections []models.MilkCollection, tests []*models.MilkQualityTest, payments []*models.MilkPayment) *MilkStatement {
+	statement := &MilkStatement{
+		PriceTable: table,
+		Deliveries: len(deliveries),
+		BasePrice:  table.BasePrice,
+		Payments:   payments,
+	}
+
+	for _, delivery := range deliveries {
+		statement.DeliveredLiters += delivery.Liters
+	}
+	for _, collection := range collections {
+		statement.CollectedLiters += collection.Liters
+	}
+
+	var fat, protein kpiAccumulator
+	var scc, cbt geometricAccumulator
+	for _, test := range tests {
+		statement.QualityTests++
+		if test.FatPercent != nil {
+			fat.add(*test.FatPercent)
+		}
+		if test.ProteinPercent != nil {
+			protein.add(*test.ProteinPercent)
+		}
+		if test.SomaticCellCount != nil {
+			scc.add(*test.SomaticCellCount)
+		}
+		if test.BacterialCount != nil {
+			cbt.add(*test.BacterialCount)
+		}
+	}
+	statement.FatPercent = fat.metric().Value
+	statement.ProteinPercent = protein.metric().Value
+	statement.SomaticCellCount = scc.mean()
+	statement.BacterialCount = cbt.mean()
+
+	statement.VolumeBonus = table.VolumeBonus(statement.DeliveredLiters)
+	unitPrice := statement.BasePrice + statement.VolumeBonus
+
+	values := map[string]*float64{
+		models.MilkPriceParameterFat:     statement.FatPercent,
+		models.MilkPriceParameterProtein: statement.ProteinPercent,
+		models.MilkPriceParameterSCC:     statement.SomaticCellCount,
+		models.MilkPriceParameterCBT:     statement.BacterialCount,
+	}
+	for _, parameter := range models.MilkPriceParameters {
+		adjustment := MilkStatementAdjustment{Parameter: parameter, Value: values[parameter]}
+		adjustment.Tier = table.QualityTier(parameter, adjustment.Value)
+		if adjustment.Tier != nil {
+			adjustment.Adjustment = adjustment.Tier.Adjustment
+		}
+		unitPrice += adjustment.Adjustment
+		statement.Adjustments = append(statement.Adjustments, adjustment)
+	}
+
+	statement.UnitPrice = math.Max(unitPrice, 0)
+	statement.EstimatedAmount = statement.UnitPrice * statement.DeliveredLiters
+
+	for _, payment := range payments {
+		statement.ReceivedAmount += payment.Amount
+	}
+	if len(payments) > 0 {
+		statement.Difference = statement.ReceivedAmount - statement.EstimatedAmount
+	}
+
+	return statement
+}
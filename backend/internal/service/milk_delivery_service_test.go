@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type versionCache struct {
+	fakeCache
+	increments map[string]uint64
+}
+
+func (c *versionCache) Increment(key string, delta uint64) (uint64, error) {
+	c.increments[key] += delta
+	return c.increments[key], nil
+}
+
+type fakeMilkDeliveryRepository struct {
+	repository.MilkDeliveryRepository
+	payments map[uint]*models.MilkPayment
+	nextID   uint
+}
+
+func (r *fakeMilkDeliveryRepository) CreatePayment(ctx context.Context, payment *models.MilkPayment) error {
+	r.nextID++
+	payment.ID = r.nextID
+	r.payments[payment.ID] = payment
+	return nil
+}
+
+func (r *fakeMilkDeliveryRepository) DeletePayment(ctx context.Context, id uint, farmID uint) error {
+	payment, ok := r.payments[id]
+	if !ok || payment.FarmID != farmID {
+		return fmt.Errorf("%s", repository.ErrMilkPaymentNotFoundOrNotBelongsToFarm)
+	}
+	delete(r.payments, id)
+	return nil
+}
+
+func TestMilkPaymentsInvalidateProfitAndLoss(t *testing.T) {
+	cacheClient := &versionCache{increments: map[string]uint64{}}
+	deliveries := &fakeMilkDeliveryRepository{payments: map[uint]*models.MilkPayment{}}
+	service := NewMilkDeliveryService(nil, deliveries, nil, nil, cacheClient)
+	key := fmt.Sprintf(CacheKeyFarmVersion, CacheScopePnL, ownerFarmID)
+
+	payment := &models.MilkPayment{Buyer: "Cooperativa", Month: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), Amount: 1000, PaymentDate: time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)}
+	if err := service.CreatePayment(context.Background(), payment, ownerFarmID); err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	if cacheClient.increments[key] != 1 {
+		t.Fatalf("P&L version = %d after payment created, want 1", cacheClient.increments[key])
+	}
+
+	if err := service.DeletePayment(context.Background(), payment.ID, otherFarmID); err == nil {
+		t.Fatal("DeletePayment from other farm succeeded")
+	}
+	if cacheClient.increments[key] != 1 {
+		t.Fatalf("P&L version = %d after rejected delete, want 1", cacheClient.increments[key])
+	}
+
+	if err := service.DeletePayment(context.Background(), payment.ID, ownerFarmID); err != nil {
+		t.Fatalf("DeletePayment: %v", err)
+	}
+	if cacheClient.increments[key] != 2 {
+		t.Fatalf("P&L version = %d after payment deleted, want 2", cacheClient.increments[key])
+	}
+}
+
+func tankTest(tank string, scc, cbt float64) *models.MilkQualityTest {
+	return &models.MilkQualityTest{Tank: tank, SomaticCellCount: &scc, BacterialCount: &cbt}
+}
+
+func TestBuildMilkStatementUsesGeometricMeanForCounts(t *testing.T) {
+	lower, upper := 400.0, 1000.0
+	table := &models.MilkPriceTable{
+		BasePrice: 2,
+		QualityTiers: []models.MilkPriceQualityTier{
+			{Parameter: models.MilkPriceParameterSCC, Max: &lower, Adjustment: 0.05},
+			{Parameter: models.MilkPriceParameterSCC, Min: &lower, Max: &upper, Adjustment: -0.05},
+		},
+	}
+	tests := []*models.MilkQualityTest{tankTest("", 100, 10), tankTest("", 900, 1000)}
+
+	statement := buildMilkStatement(table, nil, nil, tests, nil)
+
+	if statement.SomaticCellCount == nil || math.Abs(*statement.SomaticCellCount-300) > 1e-9 {
+		t.Fatalf("somatic cell count = %v, want 300", statement.SomaticCellCount)
+	}
+	if statement.BacterialCount == nil || math.Abs(*statement.BacterialCount-100) > 1e-9 {
+		t.Fatalf("bacterial count = %v, want 100", statement.BacterialCount)
+	}
+	if statement.UnitPrice != 2.05 {
+		t.Fatalf("unit price = %v, want 2.05", statement.UnitPrice)
+	}
+}
+
+func TestBuyerTankTests(t *testing.T) {
+	tests := []*models.MilkQualityTest{tankTest("Tanque 1", 200, 50), tankTest("Tanque 2", 800, 400), tankTest("", 300, 60)}
+
+	deliveries := []*models.MilkDelivery{{Buyer: "Cooperativa", Tank: "tanque 1"}, {Buyer: "Cooperativa"}}
+	filtered := buyerTankTests(tests, deliveries)
+	if len(filtered) != 1 || filtered[0].Tank != "Tanque 1" {
+		t.Fatalf("buyerTankTests with delivery tanks = %v, want only Tanque 1", filtered)
+	}
+
+	untracked := []*models.MilkDelivery{{Buyer: "Cooperativa"}}
+	if got := buyerTankTests(tests, untracked); len(got) != len(tests) {
+		t.Fatalf("buyerTankTests without delivery tanks returned %d tests, want %d", len(got), len(tests))
+	}
+}
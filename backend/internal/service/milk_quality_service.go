@@ -0,0 +1,522 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	MilkQualityTrendMaxMonths = 24
+	MilkQualityImportMaxRows  = 5000
+	MastitisChronicTests      = 2
+)
+
+type MilkQualityImportError struct {
+	Line    int
+	Message string
+}
+
+type MilkQualityImportResult struct {
+	Imported int
+	Errors   []MilkQualityImportError
+}
+
+type MastitisFlag struct {
+	Animal           *models.Animal
+	LatestTest       *models.MilkQualityTest
+	ConsecutiveTests int
+	Chronic          bool
+}
+
+type MilkQualityTrendPoint struct {
+	Month            string
+	Tests            int
+	FatPercent       *float64
+	ProteinPercent   *float64
+	SomaticCellCount *float64
+	BacterialCount   *float64
+	HighSCCTests     int
+}
+
+type MilkQualityService interface {
+	CreateTest(ctx context.Context, test *models.MilkQualityTest, farmID uint) error
+	GetTestByID(ctx context.Context, id uint, farmID uint) (*models.MilkQualityTest, error)
+	GetTests(ctx context.Context, farmID uint, filter repository.MilkQualityFilter) ([]*models.MilkQualityTest, error)
+	UpdateTest(ctx context.Context, test *models.MilkQualityTest, farmID uint) error
+	DeleteTest(ctx context.Context, id uint, farmID uint) error
+	ImportCSV(ctx context.Context, farmID uint, reader io.Reader) (*MilkQualityImportResult, error)
+	GetMastitisFlags(ctx context.Context, farmID uint) ([]MastitisFlag, error)
+	GetTrends(ctx context.Context, farmID uint, tankOnly bool, startDate, endDate time.Time) ([]MilkQualityTrendPoint, error)
+}
+
+type milkQualityService struct {
+	qualityRepo repository.MilkQualityRepository
+	animalRepo  repository.AnimalRepositoryInterface
+	milkRepo    repository.MilkCollectionRepositoryInterface
+}
+
+func NewMilkQualityService(qualityRepo repository.MilkQualityRepository, animalRepo repository.AnimalRepositoryInterface, milkRepo repository.MilkCollectionRepositoryInterface) MilkQualityService {
+	return &milkQualityService{
+		qualityRepo: qualityRepo,
+		animalRepo:  animalRepo,
+		milkRepo:    milkRepo,
+	}
+}
+
+func validateMilkQualityTest(test *models.MilkQualityTest) error {
+	test.Tank = strings.TrimSpace(test.Tank)
+	test.Laboratory = strings.TrimSpace(test.Laboratory)
+
+	if test.SampleDate.IsZero() {
+		return errors.New("sample date is required")
+	}
+	if test.SampleDate.After(time.Now()) {
+		return errors.New("sample date cannot be in the future")
+	}
+	if test.AnimalID == nil && test.Tank == "" {
+		return errors.New("animal or tank is required")
+	}
+	if test.FatPercent == nil && test.ProteinPercent == nil && test.SomaticCellCount == nil && test.BacterialCount == nil {
+		return errors.New("at least one result is required")
+	}
+	if test.FatPercent != nil && (*test.FatPercent < 0 || *test.FatPercent > 100) {
+		return errors.New("fat percent must be between 0 and 100")
+	}
+	if test.ProteinPercent != nil && (*test.ProteinPercent < 0 || *test.ProteinPercent > 100) {
+		return errors.New("protein percent must be between 0 and 100")
+	}
+	if test.SomaticCellCount != nil && *test.SomaticCellCount < 0 {
+		return errors.New("somatic cell count cannot be negative")
+	}
+	if test.BacterialCount != nil && *test.BacterialCount < 0 {
+		return errors.New("bacterial count cannot be negative")
+	}
+	return nil
+}
+
+func (s *milkQualityService) prepareTest(test *models.MilkQualityTest, farmID uint) error {
+	if farmID == 0 {
+		return errors.New("farm ID is required")
+	}
+	test.FarmID = farmID
+
+	if test.MilkCollectionID != nil {
+		collection, err := s.milkRepo.FindByID(*test.MilkCollectionID, farmID)
+		if err != nil || collection == nil {
+			return errors.New(ErrMilkCollectionNotFoundOrNotBelongsToFarm)
+		}
+		if test.AnimalID != nil && *test.AnimalID != collection.AnimalID {
+			return errors.New("milk collection belongs to another animal")
+		}
+		animalID := collection.AnimalID
+		test.AnimalID = &animalID
+		if test.SampleDate.IsZero() {
+			test.SampleDate = collection.Date
+		}
+	}
+
+	if err := validateMilkQualityTest(test); err != nil {
+		return err
+	}
+
+	if test.AnimalID != nil {
+		if err := checkAnimalInFarm(s.animalRepo, *test.AnimalID, farmID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *milkQualityService) CreateTest(ctx context.Context, test *models.MilkQualityTest, farmID uint) error {
+	if err := s.prepareTest(test, farmID); err != nil {
+		return err
+	}
+	if test.Source == "" {
+		test.Source = models.MilkQualitySourceManual
+	}
+	return s.qualityRepo.Create(ctx, []*models.MilkQualityTest{test})
+}
+
+func (s *milkQualityService) GetTestByID(ctx context.Context, id uint, farmID uint) (*models.MilkQualityTest, error) {
+	return s.qualityRepo.GetByID(ctx, id, farmID)
+}
+
+func (s *milkQualityService) GetTests(ctx context.Context, farmID uint, filter repository.MilkQualityFilter) ([]*models.MilkQualityTest, error) {
+	if farmID == 0 {
+		return nil, errors.New("farm ID is required")
+	}
+	if filter.AnimalID != nil {
+		if err := checkAnimalInFarm(s.animalRepo, *filter.AnimalID, farmID); err != nil {
+			return nil, err
+		}
+	}
+	return s.qualityRepo.GetByFarmID(ctx, farmID, filter)
+}
+
+func (s *milkQualityService) UpdateTest(ctx context.Context, test *models.MilkQualityTest, farmID uint) error {
+	if _, err := s.qualityRepo.GetByID(ctx, test.ID, farmID); err != nil {
+		return err
+	}
+	if err := s.prepareTest(test, farmID); err != nil {
+		return err
+	}
+	return s.qualityRepo.Update(ctx, test)
+}
+
+func (s *milkQualityService) DeleteTest(ctx context.Context, id uint, farmID uint) error {
+	return s.qualityRepo.Delete(ctx, id, farmID)
+}
+
+var milkQualityImportColumns = map[string][]string{
+	"date":       {"date", "data", "data_coleta", "sample_date"},
+	"ear_tag":    {"ear_tag", "brinco", "animal"},
+	"tank":       {"tank", "tanque"},
+	"fat":        {"fat", "gordura", "fat_percent"},
+	"protein":    {"protein", "proteina", "proteína", "protein_percent"},
+	"scc":        {"scc", "ccs"},
+	"cbt":        {"cbt", "bacterial_count", "ctb"},
+	"laboratory": {"laboratory", "laboratorio", "laboratório"},
+}
+
+func (s *milkQualityService) ImportCSV(ctx context.Context, farmID uint, reader io.Reader) (*MilkQualityImportResult, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	csvReader := csv.NewReader(bytes.NewReader(data))
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	semicolon := strings.Contains(strings.SplitN(string(data), "\n", 2)[0], ";")
+	if semicolon {
+		csvReader.Comma = ';'
+	}
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, errors.New("file must have a header row")
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for column, aliases := range milkQualityImportColumns {
+			for _, alias := range aliases {
+				if name == alias {
+					columns[column] = i
+				}
+			}
+		}
+	}
+	if _, ok := columns["date"]; !ok {
+		return nil, errors.New("date column is required")
+	}
+	_, hasEarTag := columns["ear_tag"]
+	_, hasTank := columns["tank"]
+	if !hasEarTag && !hasTank {
+		return nil, errors.New("ear_tag or tank column is required")
+	}
+
+	result := &MilkQualityImportResult{}
+	var tests []*models.MilkQualityTest
+	animalsByTag := make(map[int]*models.Animal)
+	line := 1
+
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			result.Errors = append(result.Errors, MilkQualityImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		if len(tests)+len(result.Errors) >= MilkQualityImportMaxRows {
+			return nil, fmt.Errorf("file cannot have more than %d rows", MilkQualityImportMaxRows)
+		}
+
+		field := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		test, err := parseMilkQualityRow(field)
+		if err == nil {
+			test.FarmID = farmID
+			test.Source = models.MilkQualitySourceImport
+			if tag := field("ear_tag"); tag != "" {
+				test.AnimalID, err = s.animalIDByEarTag(farmID, tag, animalsByTag)
+			}
+		}
+		if err == nil {
+			err = validateMilkQualityTest(test)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, MilkQualityImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		tests = append(tests, test)
+	}
+
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+	if len(tests) == 0 {
+		return nil, errors.New("file has no rows")
+	}
+
+	if err := s.qualityRepo.Create(ctx, tests); err != nil {
+		return nil, err
+	}
+	result.Imported = len(tests)
+	return result, nil
+}
+
+func (s *milkQualityService) animalIDByEarTag(farmID uint, value string, cache map[int]*models.Animal) (*uint, error) {
+	tag, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ear tag %q", value)
+	}
+
+	animal, ok := cache[tag]
+	if !ok {
+		animal, err = s.animalRepo.FindByEarTagNumber(farmID, tag)
+		if err != nil {
+			animal = nil
+		}
+		cache[tag] = animal
+	}
+	if animal == nil {
+		return nil, fmt.Errorf("animal with ear tag %d not found", tag)
+	}
+
+	id := animal.ID
+	return &id, nil
+}
+
+func parseMilkQualityRow(field func(string) string) (*models.MilkQualityTest, error) {
+	date, err := parseImportDate(field("date"))
+	if err != nil {
+		return nil, err
+	}
+
+	test := &models.MilkQualityTest{
+		SampleDate: date,
+		Tank:       field("tank"),
+		Laboratory: field("laboratory"),
+	}
+
+	targets := []struct {
+		column string
+		value  **float64
+	}{
+		{"fat", &test.FatPercent},
+		{"protein", &test.ProteinPercent},
+		{"scc", &test.SomaticCellCount},
+		{"cbt", &test.BacterialCount},
+	}
+	for _, target := range targets {
+		raw := field(target.column)
+		if raw == "" {
+			continue
+		}
+		value, err := parseImportNumber(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s for %s", err, target.column)
+		}
+		*target.value = &value
+	}
+
+	return test, nil
+}
+
+func parseImportNumber(raw string) (float64, error) {
+	value := strings.ReplaceAll(raw, " ", "")
+	lastComma, lastDot := strings.LastIndex(value, ","), strings.LastIndex(value, ".")
+
+	var decimal, thousands string
+	switch {
+	case lastComma >= 0 && lastDot >= 0:
+		decimal, thousands = ",", "."
+		if lastDot > lastComma {
+			decimal, thousands = ".", ","
+		}
+	case lastComma >= 0 || lastDot >= 0:
+		separator := ","
+		if lastDot >= 0 {
+			separator = "."
+		}
+		integer, fraction, _ := strings.Cut(value, separator)
+		switch {
+		case strings.Count(value, separator) > 1:
+			thousands = separator
+		case len(fraction) == 3 && len(integer) <= 3 && integer != "" && integer != "0":
+			return 0, fmt.Errorf("ambiguous value %q, use a comma or dot only as decimal separator or remove the thousands separator", raw)
+		default:
+			decimal = separator
+		}
+	}
+
+	integer, fraction := value, ""
+	if decimal != "" {
+		index := strings.LastIndex(value, decimal)
+		integer, fraction = value[:index], value[index+1:]
+	}
+	if thousands != "" {
+		groups := strings.Split(integer, thousands)
+		for i, group := range groups {
+			if (i == 0 && (group == "" || len(group) > 3)) || (i > 0 && len(group) != 3) {
+				return 0, fmt.Errorf("invalid value %q", raw)
+			}
+		}
+		integer = strings.Join(groups, "")
+	}
+
+	number := integer
+	if decimal != "" {
+		number += "." + fraction
+	}
+	parsed, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", raw)
+	}
+	return parsed, nil
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "02/01/2006"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD or DD/MM/YYYY", value)
+}
+
+func (s *milkQualityService) GetMastitisFlags(ctx context.Context, farmID uint) ([]MastitisFlag, error) {
+	tests, err := s.qualityRepo.GetByFarmID(ctx, farmID, repository.MilkQualityFilter{AnimalOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	testsByAnimal := make(map[uint][]*models.MilkQualityTest)
+	var animalIDs []uint
+	for _, test := range tests {
+		if test.SomaticCellCount == nil {
+			continue
+		}
+		if _, ok := testsByAnimal[*test.AnimalID]; !ok {
+			animalIDs = append(animalIDs, *test.AnimalID)
+		}
+		testsByAnimal[*test.AnimalID] = append(testsByAnimal[*test.AnimalID], test)
+	}
+
+	var flags []MastitisFlag
+	for _, animalID := range animalIDs {
+		animalTests := testsByAnimal[animalID]
+		latest := animalTests[0]
+		if !latest.PossibleMastitis() || latest.Animal == nil || latest.Animal.Status != models.AnimalStatusActive {
+			continue
+		}
+
+		consecutive := 0
+		for _, test := range animalTests {
+			if !test.PossibleMastitis() {
+				break
+			}
+			consecutive++
+		}
+
+		flags = append(flags, MastitisFlag{
+			Animal:           latest.Animal,
+			LatestTest:       latest,
+			ConsecutiveTests: consecutive,
+			Chronic:          consecutive >= MastitisChronicTests,
+		})
+	}
+
+	sort.SliceStable(flags, func(i, j int) bool {
+		return *flags[i].LatestTest.SomaticCellCount > *flags[j].LatestTest.SomaticCellCount
+	})
+	return flags, nil
+}
+
+func (s *milkQualityService) GetTrends(ctx context.Context, farmID uint, tankOnly bool, startDate, endDate time.Time) ([]MilkQualityTrendPoint, error) {
+	if farmID == 0 {
+		return nil, errors.New("farm ID is required")
+	}
+	if startDate.After(endDate) {
+		return nil, errors.New("start date cannot be after end date")
+	}
+	if startDate.AddDate(0, MilkQualityTrendMaxMonths, 0).Before(endDate) {
+		return nil, fmt.Errorf("period cannot exceed %d months", MilkQualityTrendMaxMonths)
+	}
+
+	filter := repository.MilkQualityFilter{TankOnly: tankOnly, AnimalOnly: !tankOnly, StartDate: &startDate, EndDate: &endDate}
+	tests, err := s.qualityRepo.GetByFarmID(ctx, farmID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	type monthAccumulator struct {
+		tests                  int
+		highSCC                int
+		fat, protein, scc, cbt kpiAccumulator
+	}
+	months := make(map[string]*monthAccumulator)
+
+	for _, test := range tests {
+		month := test.SampleDate.Format("2006-01")
+		accumulator, ok := months[month]
+		if !ok {
+			accumulator = &monthAccumulator{}
+			months[month] = accumulator
+		}
+		accumulator.tests++
+		if test.FatPercent != nil {
+			accumulator.fat.add(*test.FatPercent)
+		}
+		if test.ProteinPercent != nil {
+			accumulator.protein.add(*test.ProteinPercent)
+		}
+		if test.SomaticCellCount != nil {
+			accumulator.scc.add(*test.SomaticCellCount)
+		}
+		if test.BacterialCount != nil {
+			accumulator.cbt.add(*test.BacterialCount)
+		}
+		if test.PossibleMastitis() {
+			accumulator.highSCC++
+		}
+	}
+
+	var points []MilkQualityTrendPoint
+	for month := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(endDate); month = month.AddDate(0, 1, 0) {
+		key := month.Format("2006-01")
+		point := MilkQualityTrendPoint{Month: key}
+		if accumulator, ok := months[key]; ok {
+			point.Tests = accumulator.tests
+			point.HighSCCTests = accumulator.highSCC
+			point.FatPercent = accumulator.fat.metric().Value
+			point.ProteinPercent = accumulator.protein.metric().Value
+			point.SomaticCellCount = accumulator.scc.metric().Value
+			point.BacterialCount = accumulator.cbt.metric().Value
+		}
+		points = append(points, point)
+	}
+
+	return points, nil
+}
@@ -0,0 +1,42 @@
+package service
+
+import "testing"
+
+func TestParseImportNumber(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    float64
+		wantErr bool
+	}{
+		{raw: "3.5", want: 3.5},
+		{raw: "3,5", want: 3.5},
+		{raw: "3.45", want: 3.45},
+		{raw: "0.125", want: 0.125},
+		{raw: "250000", want: 250000},
+		{raw: "1.234,5", want: 1234.5},
+		{raw: "1,234.5", want: 1234.5},
+		{raw: "1.250.000", want: 1250000},
+		{raw: "1,250,000", want: 1250000},
+		{raw: "1 250 000", want: 1250000},
+		{raw: "250.000", wantErr: true},
+		{raw: "1,234", wantErr: true},
+		{raw: "12.34.5", wantErr: true},
+		{raw: "1.23,5", wantErr: true},
+		{raw: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := parseImportNumber(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseImportNumber(%q) = %v, want error", tt.raw, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("parseImportNumber(%q) = %v, %v; want %v", tt.raw, got, err, tt.want)
+			}
+		})
+	}
+}
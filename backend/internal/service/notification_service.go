@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	NotificationDefaultLimit = 50
+	NotificationMaxLimit     = 200
+)
+
+type NotificationService interface {
+	GetNotifications(ctx context.Context, farmID uint, unreadOnly bool, limit int) ([]*models.Notification, error)
+	CountUnread(ctx context.Context, farmID uint) (int64, error)
+	MarkAsRead(ctx context.Context, id uint, farmID uint) error
+	MarkAllAsRead(ctx context.Context, farmID uint) (int64, error)
+}
+
+type notificationService struct {
+	repository repository.NotificationRepository
+}
+
+func NewNotificationService(repository repository.NotificationRepository) NotificationService {
+	return &notificationService{repository: repository}
+}
+
+func (s *notificationService) GetNotifications(ctx context.Context, farmID uint, unreadOnly bool, limit int) ([]*models.Notification, error) {
+	if limit == 0 {
+		limit = NotificationDefaultLimit
+	}
+	if limit < 1 || limit > NotificationMaxLimit {
+		return nil, errors.New("limit must be between 1 and 200")
+	}
+
+	return s.repository.GetByFarmID(ctx, farmID, unreadOnly, limit)
+}
+
+func (s *notificationService) CountUnread(ctx context.Context, farmID uint) (int64, error) {
+	return s.repository.CountUnread(ctx, farmID)
+}
+
+func (s *notificationService) MarkAsRead(ctx context.Context, id uint, farmID uint) error {
+	return s.repository.MarkAsRead(ctx, id, farmID, time.Now())
+}
+
+func (s *notificationService) MarkAllAsRead(ctx context.Context, farmID uint) (int64, error) {
+	return s.repository.MarkAllAsRead(ctx, farmID, time.Now())
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/utils"
+)
+
+const (
+	pdfReportMargin       = 40.0
+	pdfReportRowHeight    = 16.0
+	pdfReportFontSize     = 8.5
+	pdfReportFooterTop    = utils.PDFPageHeight - 45
+	pdfReportLogoMaxWidth = 90.0
+	pdfReportLogoHeight   = 50.0
+)
+
+type PDFReportService interface {
+	HerdInventory(ctx context.Context, farmID uint, w io.Writer) error
+	SalesStatement(ctx context.Context, farmID uint, startDate, endDate time.Time, w io.Writer) error
+	ReproductionCalendar(ctx context.Context, farmID uint, startDate, endDate time.Time, w io.Writer) error
+}
+
+type pdfReportService struct {
+	animalService       *AnimalService
+	saleService         SaleService
+	reproductionService *ReproductionService
+	farmService         *FarmService
+}
+
+func NewPDFReportService(animalService *AnimalService, saleService SaleService, reproductionService *ReproductionService, farmService *FarmService) PDFReportService {
+	return &pdfReportService{
+		animalService:       animalService,
+		saleService:         saleService,
+		reproductionService: reproductionService,
+		farmService:         farmService,
+	}
+}
+
+type pdfReportColumn struct {
+	title      string
+	width      float64
+	alignRight bool
+}
+
+type pdfReport struct {
+	doc         *utils.PDFDocument
+	logo        int
+	hasLogo     bool
+	companyName string
+	title       string
+	subtitle    string
+	columns     []pdfReportColumn
+	y           float64
+}
+
+type reproductionCalendarEntry struct {
+	date   time.Time
+	event  string
+	animal models.Animal
+	phase  models.ReproductionPhase
+}
+
+func (s *pdfReportService) HerdInventory(ctx context.Context, farmID uint, w io.Writer) error {
+	animals, err := s.animalService.GetAnimalsByFarmID(farmID)
+	if err != nil {
+		return err
+	}
+	sort.Slice(animals, func(i, j int) bool {
+		return animals[i].EarTagNumberLocal < animals[j].EarTagNumberLocal
+	})
+
+	report, err := s.newReport(farmID, "Inventário do Rebanho", fmt.Sprintf("Posição em %s", time.Now().Format(alertDateFormat)), []pdfReportColumn{
+		{title: "Brinco", width: 50},
+		{title: "Nome", width: 120},
+		{title: "Sexo", width: 45},
+		{title: "Raça", width: 85},
+		{title: "Espécie", width: 60},
+		{title: "Nascimento", width: 65},
+		{title: "Lote", width: 40},
+		{title: "Status", width: 50},
+	})
+	if err != nil {
+		return err
+	}
+
+	statusCount := make(map[int]int)
+	females := 0
+	for _, animal := range animals {
+		statusCount[animal.Status]++
+		if animal.Sex == models.AnimalSexFemale {
+			females++
+		}
+		batch := "-"
+		if animal.CurrentBatch != 0 {
+			batch = strconv.Itoa(animal.CurrentBatch)
+		}
+		report.row([]string{
+			strconv.Itoa(animal.EarTagNumberLocal),
+			animal.AnimalName,
+			pdfReportSex(animal.Sex),
+			animal.Breed,
+			models.GetAnimalTypeName(animal.AnimalType),
+			pdfReportDate(animal.BirthDate),
+			batch,
+			models.GetStatusName(animal.Status),
+		})
+	}
+
+	report.summary([]string{
+		fmt.Sprintf("Total de animais: %d", len(animals)),
+		fmt.Sprintf("%s: %d | %s: %d | %s: %d",
+			models.GetStatusName(models.AnimalStatusActive), statusCount[models.AnimalStatusActive],
+			models.GetStatusName(models.AnimalStatusSold), statusCount[models.AnimalStatusSold],
+			models.GetStatusName(models.AnimalStatusDeceased), statusCount[models.AnimalStatusDeceased]),
+		fmt.Sprintf("Fêmeas: %d | Machos: %d", females, len(animals)-females),
+	})
+
+	return report.write(w)
+}
+
+func (s *pdfReportService) SalesStatement(ctx context.Context, farmID uint, startDate, endDate time.Time, w io.Writer) error {
+	sales, err := s.saleService.GetSalesByDateRange(ctx, farmID, startDate, endDate)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(sales, func(i, j int) bool {
+		return sales[i].SaleDate.Before(sales[j].SaleDate)
+	})
+
+	report, err := s.newReport(farmID, "Extrato de Vendas", pdfReportPeriod(startDate, endDate), []pdfReportColumn{
+		{title: "Data", width: 70},
+		{title: "Brinco", width: 55},
+		{title: "Animal", width: 130},
+		{title: "Comprador", width: 170},
+		{title: "Valor", width: 90, alignRight: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	total := 0.0
+	for _, sale := range sales {
+		total += sale.Price
+		report.row([]string{
+			sale.SaleDate.Format(alertDateFormat),
+			strconv.Itoa(sale.Animal.EarTagNumberLocal),
+			sale.Animal.AnimalName,
+			sale.BuyerName,
+			pdfReportMoney(sale.Price),
+		})
+	}
+
+	report.summary([]string{
+		fmt.Sprintf("Total de vendas: %d", len(sales)),
+		fmt.Sprintf("Valor total: %s", pdfReportMoney(total)),
+	})
+
+	return report.write(w)
+}
+
+func (s *pdfReportService) ReproductionCalendar(ctx context.Context, farmID uint, startDate, endDate time.Time, w io.Writer) error {
+	if startDate.After(endDate) {
+		return errors.New(ErrInvalidReportPeriod)
+	}
+
+	reproductions, err := s.reproductionService.GetReproductionsByFarmID(farmID)
+	if err != nil {
+		return err
+	}
+
+	var entries []reproductionCalendarEntry
+	add := func(reproduction models.Reproduction, date time.Time, event string) {
+		if date.Before(startDate) || date.After(endDate) {
+			return
+		}
+		entries = append(entries, reproductionCalendarEntry{date: date, event: event, animal: reproduction.Animal, phase: reproduction.CurrentPhase})
+	}
+	for _, reproduction := range reproductions {
+		switch reproduction.CurrentPhase {
+		case models.PhaseVazias:
+			if reproduction.InseminationDate != nil {
+				add(reproduction, reproduction.InseminationDate.AddDate(0, 0, AlertPregnancyCheckDays), "Diagnóstico de gestação")
+			}
+		case models.PhasePrenhas, models.PhaseSecando:
+			if reproduction.ExpectedBirthDate == nil {
+				break
+			}
+			if reproduction.CurrentPhase == models.PhasePrenhas {
+				add(reproduction, reproduction.ExpectedBirthDate.AddDate(0, 0, -AlertDryOffDays), "Secagem")
+			}
+			add(reproduction, *reproduction.ExpectedBirthDate, "Parto previsto")
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].date.Equal(entries[j].date) {
+			return entries[i].date.Before(entries[j].date)
+		}
+		return entries[i].animal.EarTagNumberLocal < entries[j].animal.EarTagNumberLocal
+	})
+
+	report, err := s.newReport(farmID, "Calendário Reprodutivo", pdfReportPeriod(startDate, endDate), []pdfReportColumn{
+		{title: "Data", width: 70},
+		{title: "Evento", width: 130},
+		{title: "Brinco", width: 55},
+		{title: "Animal", width: 150},
+		{title: "Fase atual", width: 110},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		report.row([]string{
+			entry.date.Format(alertDateFormat),
+			entry.event,
+			strconv.Itoa(entry.animal.EarTagNumberLocal),
+			entry.animal.AnimalName,
+			entry.phase.String(),
+		})
+	}
+
+	report.summary([]string{
+		fmt.Sprintf("Total de eventos: %d", len(entries)),
+		fmt.Sprintf("Diagnóstico previsto %d dias após a inseminação e secagem %d dias antes do parto.", AlertPregnancyCheckDays, AlertDryOffDays),
+	})
+
+	return report.write(w)
+}
+
+func (s *pdfReportService) newReport(farmID uint, title, subtitle string, columns []pdfReportColumn) (*pdfReport, error) {
+	farm, err := s.farmService.GetFarmByID(farmID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.farmService.LoadCompanyData(farm); err != nil {
+		return nil, err
+	}
+
+	report := &pdfReport{
+		doc:         utils.NewPDFDocument(),
+		companyName: farm.Company.CompanyName,
+		title:       title,
+		subtitle:    subtitle,
+		columns:     columns,
+	}
+	if logo, ok := decodeBase64Image(farm.Logo); ok {
+		if handle, err := report.doc.AddImage(logo); err == nil {
+			report.logo = handle
+			report.hasLogo = true
+		}
+	}
+	report.newPage()
+	return report, nil
+}
+
+func (r *pdfReport) newPage() {
+	r.doc.AddPage()
+
+	textX := pdfReportMargin
+	if r.hasLogo {
+		width, height := r.doc.ImageSize(r.logo)
+		logoWidth := pdfReportLogoHeight * float64(width) / float64(height)
+		logoHeight := pdfReportLogoHeight
+		if logoWidth > pdfReportLogoMaxWidth {
+			logoHeight = logoHeight * pdfReportLogoMaxWidth / logoWidth
+			logoWidth = pdfReportLogoMaxWidth
+		}
+		r.doc.DrawImage(r.logo, pdfReportMargin, pdfReportMargin, logoWidth, logoHeight)
+		textX += logoWidth + 12
+	}
+
+	r.doc.Text(textX, pdfReportMargin+14, 14, true, r.companyName)
+	r.doc.Text(textX, pdfReportMargin+32, 12, true, r.title)
+	r.doc.Text(textX, pdfReportMargin+46, 9, false, r.subtitle)
+	r.doc.Line(pdfReportMargin, pdfReportMargin+58, utils.PDFPageWidth-pdfReportMargin, pdfReportMargin+58, 1)
+
+	r.y = pdfReportMargin + 70
+	r.doc.FillRect(pdfReportMargin, r.y, utils.PDFPageWidth-2*pdfReportMargin, pdfReportRowHeight, 0.88)
+	r.cells(r.columnTitles(), true)
+	r.y += pdfReportRowHeight
+}
+
+func (r *pdfReport) columnTitles() []string {
+	titles := make([]string, len(r.columns))
+	for i, column := range r.columns {
+		titles[i] = column.title
+	}
+	return titles
+}
+
+func (r *pdfReport) cells(values []string, bold bool) {
+	x := pdfReportMargin
+	for i, column := range r.columns {
+		text := pdfReportFit(values[i], column.width-8, bold)
+		textX := x + 4
+		if column.alignRight {
+			textX = x + column.width - 4 - utils.PDFTextWidth(text, pdfReportFontSize, bold)
+		}
+		r.doc.Text(textX, r.y+11, pdfReportFontSize, bold, text)
+		x += column.width
+	}
+}
+
+func (r *pdfReport) row(values []string) {
+	if r.y+pdfReportRowHeight > pdfReportFooterTop-10 {
+		r.newPage()
+	}
+	r.cells(values, false)
+	r.y += pdfReportRowHeight
+	r.doc.Line(pdfReportMargin, r.y, utils.PDFPageWidth-pdfReportMargin, r.y, 0.3)
+}
+
+func (r *pdfReport) summary(lines []string) {
+	if r.y+20+float64(len(lines))*14 > pdfReportFooterTop-10 {
+		r.newPage()
+	}
+	r.y += 20
+	for _, line := range lines {
+		r.doc.Text(pdfReportMargin, r.y, 9, true, line)
+		r.y += 14
+	}
+}
+
+func (r *pdfReport) write(w io.Writer) error {
+	generatedAt := "Gerado em " + time.Now().Format(alertDateFormat+" 15:04")
+	pages := r.doc.PageCount()
+	for i := 0; i < pages; i++ {
+		r.doc.SetPage(i)
+		r.doc.Line(pdfReportMargin, pdfReportFooterTop, utils.PDFPageWidth-pdfReportMargin, pdfReportFooterTop, 0.5)
+		r.doc.Text(pdfReportMargin, pdfReportFooterTop+14, 8, false, generatedAt)
+		pageLabel := fmt.Sprintf("Página %d de %d", i+1, pages)
+		r.doc.Text(utils.PDFPageWidth-pdfReportMargin-utils.PDFTextWidth(pageLabel, 8, false), pdfReportFooterTop+14, 8, false, pageLabel)
+	}
+	return r.doc.Write(w)
+}
+
+func pdfReportFit(text string, width float64, bold bool) string {
+	if utils.PDFTextWidth(text, pdfReportFontSize, bold) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && utils.PDFTextWidth(string(runes)+"...", pdfReportFontSize, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func pdfReportSex(sex int) string {
+	if sex == models.AnimalSexMale {
+		return "Macho"
+	}
+	return "Fêmea"
+}
+
+func pdfReportDate(date *time.Time) string {
+	if date == nil {
+		return "-"
+	}
+	return date.Format(alertDateFormat)
+}
+
+func pdfReportPeriod(startDate, endDate time.Time) string {
+	return fmt.Sprintf("Período: %s a %s", startDate.Format(alertDateFormat), endDate.Format(alertDateFormat))
+}
+
+func pdfReportMoney(value float64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	formatted := strconv.FormatFloat(value, 'f', 2, 64)
+	integer, decimals, _ := strings.Cut(formatted, ".")
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return fmt.Sprintf("%sR$ %s,%s", sign, grouped.String(), decimals)
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	PedigreeDefaultGenerations = 3
+	PedigreeMaxGenerations     = 10
+)
+
+type PedigreeNode struct {
+	Animal                *models.Animal
+	Generation            int
+	InbreedingCoefficient float64
+	Father                *PedigreeNode
+	Mother                *PedigreeNode
+}
+
+type AnimalPedigree struct {
+	AnimalID    uint
+	Generations int
+	Root        *PedigreeNode
+}
+
+type DescendantNode struct {
+	Animal     *models.Animal
+	Generation int
+	Offspring  []*DescendantNode
+}
+
+type AnimalDescendants struct {
+	AnimalID    uint
+	Generations int
+	Total       int
+	Offspring   []*DescendantNode
+}
+
+type MatingInbreeding struct {
+	Sire                  *models.Animal
+	Dam                   *models.Animal
+	InbreedingCoefficient float64
+	CommonAncestors       []*models.Animal
+}
+
+type PedigreeService interface {
+	GetPedigree(ctx context.Context, animalID, farmID uint, generations int) (*AnimalPedigree, error)
+	GetDescendants(ctx context.Context, animalID, farmID uint, generations int) (*AnimalDescendants, error)
+	GetMatingInbreeding(ctx context.Context, sireID, damID, farmID uint) (*MatingInbreeding, error)
+}
+
+type pedigreeService struct {
+	animalRepo repository.AnimalRepositoryInterface
+}
+
+func NewPedigreeService(animalRepo repository.AnimalRepositoryInterface) PedigreeService {
+	return &pedigreeService{animalRepo: animalRepo}
+}
+
+func validateGenerations(generations int) (int, error) {
+	if generations == 0 {
+		return PedigreeDefaultGenerations, nil
+	}
+	if generations < 1 || generations > PedigreeMaxGenerations {
+		return 0, errors.New("generations must be between 1 and 10")
+	}
+	return generations, nil
+}
+
+func (s *pedigreeService) loadFarmPedigree(farmID uint) (*pedigreeIndex, error) {
+	animals, err := s.animalRepo.FindByFarmID(farmID)
+	if err != nil {
+		return nil, err
+	}
+	return newPedigreeIndex(animals), nil
+}
+
+func (s *pedigreeService) GetPedigree(ctx context.Context, animalID, farmID uint, generations int) (*AnimalPedigree, error) {
+	generations, err := validateGenerations(generations)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.loadFarmPedigree(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	if index.animals[animalID] == nil {
+		return nil, errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+
+	return &AnimalPedigree{
+		AnimalID:    animalID,
+		Generations: generations,
+		Root:        index.ancestorTree(animalID, 0, generations),
+	}, nil
+}
+
+func (s *pedigreeService) GetDescendants(ctx context.Context, animalID, farmID uint, generations int) (*AnimalDescendants, error) {
+	generations, err := validateGenerations(generations)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.loadFarmPedigree(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	if index.animals[animalID] == nil {
+		return nil, errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+
+	visited := map[uint]bool{animalID: true}
+	offspring := index.descendantTree(animalID, 1, generations, visited)
+
+	return &AnimalDescendants{
+		AnimalID:    animalID,
+		Generations: generations,
+		Total:       len(visited) - 1,
+		Offspring:   offspring,
+	}, nil
+}
+
+func (s *pedigreeService) GetMatingInbreeding(ctx context.Context, sireID, damID, farmID uint) (*MatingInbreeding, error) {
+	if sireID == 0 || damID == 0 {
+		return nil, errors.New("sire ID and dam ID are required")
+	}
+	if sireID == damID {
+		return nil, errors.New("sire and dam must be different animals")
+	}
+
+	index, err := s.loadFarmPedigree(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	sire := index.animals[sireID]
+	dam := index.animals[damID]
+	if sire == nil || dam == nil {
+		return nil, errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+	if sire.Sex != models.AnimalSexMale {
+		return nil, errors.New("sire must be a male animal")
+	}
+	if dam.Sex != models.AnimalSexFemale {
+		return nil, errors.New("dam must be a female animal")
+	}
+
+	return index.mating(sireID, damID), nil
+}
+
+type pedigreeIndex struct {
+	animals  map[uint]*models.Animal
+	sire     map[uint]uint
+	dam      map[uint]uint
+	children map[uint][]uint
+	depth    map[uint]int
+	kinship  map[[2]uint]float64
+}
+
+func newPedigreeIndex(animals []models.Animal) *pedigreeIndex {
+	index := &pedigreeIndex{
+		animals:  make(map[uint]*models.Animal, len(animals)),
+		sire:     make(map[uint]uint, len(animals)),
+		dam:      make(map[uint]uint, len(animals)),
+		children: make(map[uint][]uint),
+		depth:    make(map[uint]int, len(animals)),
+		kinship:  make(map[[2]uint]float64),
+	}
+
+	for i := range animals {
+		index.animals[animals[i].ID] = &animals[i]
+	}
+
+	for id, animal := range index.animals {
+		if animal.FatherID != nil && index.animals[*animal.FatherID] != nil && *animal.FatherID != id {
+			index.sire[id] = *animal.FatherID
+		}
+		if animal.MotherID != nil && index.animals[*animal.MotherID] != nil && *animal.MotherID != id {
+			index.dam[id] = *animal.MotherID
+		}
+	}
+
+	visiting := make(map[uint]bool)
+	for id := range index.animals {
+		index.computeDepth(id, visiting)
+	}
+
+	for id := range index.animals {
+		if sireID := index.sire[id]; sireID != 0 {
+			index.children[sireID] = append(index.children[sireID], id)
+		}
+		if damID := index.dam[id]; damID != 0 {
+			index.children[damID] = append(index.children[damID], id)
+		}
+	}
+	for id := range index.children {
+		sort.Slice(index.children[id], func(i, j int) bool {
+			return index.children[id][i] < index.children[id][j]
+		})
+	}
+
+	return index
+}
+
+func (p *pedigreeIndex) computeDepth(id uint, visiting map[uint]bool) int {
+	if depth, ok := p.depth[id]; ok {
+		return depth
+	}
+
+	visiting[id] = true
+	depth := 0
+	for _, parents := range []map[uint]uint{p.sire, p.dam} {
+		parentID := parents[id]
+		if parentID == 0 {
+			continue
+		}
+		if visiting[parentID] {
+			delete(parents, id)
+			continue
+		}
+		if parentDepth := p.computeDepth(parentID, visiting) + 1; parentDepth > depth {
+			depth = parentDepth
+		}
+	}
+	delete(visiting, id)
+
+	p.depth[id] = depth
+	return depth
+}
+
+func (p *pedigreeIndex) coancestry(a, b uint) float64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	key := [2]uint{a, b}
+	if a > b {
+		key = [2]uint{b, a}
+	}
+	if value, ok := p.kinship[key]; ok {
+		return value
+	}
+
+	var value float64
+	if a == b {
+		value = 0.5 * (1 + p.inbreeding(a))
+	} else {
+		if p.depth[a] < p.depth[b] {
+			a, b = b, a
+		}
+		value = 0.5 * (p.coancestry(p.sire[a], b) + p.coancestry(p.dam[a], b))
+	}
+
+	p.kinship[key] = value
+	return value
+}
+
+func (p *pedigreeIndex) inbreeding(id uint) float64 {
+	return p.coancestry(p.sire[id], p.dam[id])
+}
+
+func (p *pedigreeIndex) ancestors(id uint) map[uint]bool {
+	result := make(map[uint]bool)
+	stack := []uint{p.sire[id], p.dam[id]}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == 0 || result[current] {
+			continue
+		}
+		result[current] = true
+		stack = append(stack, p.sire[current], p.dam[current])
+	}
+	return result
+}
+
+func (p *pedigreeIndex) ancestorTree(id uint, generation, maxGenerations int) *PedigreeNode {
+	if id == 0 {
+		return nil
+	}
+
+	node := &PedigreeNode{
+		Animal:                p.animals[id],
+		Generation:            generation,
+		InbreedingCoefficient: p.inbreeding(id),
+	}
+
+	if generation < maxGenerations {
+		node.Father = p.ancestorTree(p.sire[id], generation+1, maxGenerations)
+		node.Mother = p.ancestorTree(p.dam[id], generation+1, maxGenerations)
+	}
+
+	return node
+}
+
+func (p *pedigreeIndex) descendantTree(id uint, generation, maxGenerations int, visited map[uint]bool) []*DescendantNode {
+	if generation > maxGenerations {
+		return nil
+	}
+
+	var nodes []*DescendantNode
+	for _, childID := range p.children[id] {
+		if visited[childID] {
+			continue
+		}
+		visited[childID] = true
+
+		nodes = append(nodes, &DescendantNode{
+			Animal:     p.animals[childID],
+			Generation: generation,
+			Offspring:  p.descendantTree(childID, generation+1, maxGenerations, visited),
+		})
+	}
+
+	return nodes
+}
+
+func (p *pedigreeIndex) lineage(id uint) map[uint]bool {
+	result := p.ancestors(id)
+	result[id] = true
+	return result
+}
+
+func (p *pedigreeIndex) commonAncestors(lineA, lineB map[uint]bool) []*models.Animal {
+	var common []*models.Animal
+	for id := range lineA {
+		if lineB[id] {
+			common = append(common, p.animals[id])
+		}
+	}
+	sort.Slice(common, func(i, j int) bool {
+		return common[i].ID < common[j].ID
+	})
+	return common
+}
+
+func (p *pedigreeIndex) mating(sireID, damID uint) *MatingInbreeding {
+	return &MatingInbreeding{
+		Sire:                  p.animals[sireID],
+		Dam:                   p.animals[damID],
+		InbreedingCoefficient: p.coancestry(sireID, damID),
+		CommonAncestors:       p.commonAncestors(p.lineage(sireID), p.lineage(damID)),
+	}
+}
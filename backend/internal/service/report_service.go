@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/cache"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	PnLRevenueCategory = "vendas"
+	PnLMilkCategory    = "leite"
+	PnLDebtsCategory   = "dividas"
+	PnLMaxMonths       = 24
+)
+
+type PnLCategory struct {
+	Category string  `json:"category"`
+	Type     string  `json:"type"`
+	Total    float64 `json:"total"`
+}
+
+type MonthlyPnL struct {
+	Month      string        `json:"month"`
+	Year       int           `json:"year"`
+	Revenue    float64       `json:"revenue"`
+	Costs      float64       `json:"costs"`
+	Debts      float64       `json:"debts"`
+	NetResult  float64       `json:"net_result"`
+	Margin     *float64      `json:"margin"`
+	Categories []PnLCategory `json:"categories"`
+}
+
+type ProfitAndLossReport struct {
+	FarmID       uint          `json:"farm_id"`
+	StartDate    string        `json:"start_date"`
+	EndDate      string        `json:"end_date"`
+	TotalRevenue float64       `json:"total_revenue"`
+	TotalCosts   float64       `json:"total_costs"`
+	TotalDebts   float64       `json:"total_debts"`
+	NetResult    float64       `json:"net_result"`
+	Margin       *float64      `json:"margin"`
+	Months       []MonthlyPnL  `json:"months"`
+	Categories   []PnLCategory `json:"categories"`
+}
+
+type ReportService interface {
+	GetProfitAndLoss(ctx context.Context, farmID uint, startDate, endDate time.Time) (*ProfitAndLossReport, error)
+}
+
+type reportService struct {
+	reportRepo repository.ReportRepository
+	cache      cache.CacheInterface
+}
+
+func NewReportService(reportRepo repository.ReportRepository, cacheClient cache.CacheInterface) ReportService {
+	return &reportService{
+		reportRepo: reportRepo,
+		cache:      cacheClient,
+	}
+}
+
+func (s *reportService) GetProfitAndLoss(ctx context.Context, farmID uint, startDate, endDate time.Time) (*ProfitAndLossReport, error) {
+	if farmID == 0 {
+		return nil, errors.New("farm ID is required")
+	}
+	if startDate.After(endDate) {
+		return nil, errors.New("start date cannot be after end date")
+	}
+
+	periodStart := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, startDate.Location())
+	periodEnd := time.Date(endDate.Year(), endDate.Month(), 1, 0, 0, 0, 0, endDate.Location()).AddDate(0, 1, 0)
+	months := (periodEnd.Year()-periodStart.Year())*12 + int(periodEnd.Month()) - int(periodStart.Month())
+	if months > PnLMaxMonths {
+		return nil, fmt.Errorf("period cannot exceed %d months", PnLMaxMonths)
+	}
+
+	version := farmCacheVersion(s.cache, CacheScopePnL, farmID)
+	cacheKey := fmt.Sprintf(CacheKeyPnL, farmID, version, periodStart.Format("2006-01"), periodEnd.Format("2006-01"))
+	var cachedReport ProfitAndLossReport
+
+	err := s.cache.Get(cacheKey, &cachedReport)
+	if err == nil {
+		log.Printf("Cache HIT para DRE da fazenda %d (%s)", farmID, cacheKey)
+		return &cachedReport, nil
+	}
+
+	log.Printf("Cache MISS para DRE da fazenda %d (%s)", farmID, cacheKey)
+
+	revenue, err := s.reportRepo.GetMonthlyRevenue(ctx, farmID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	milkRevenue, err := s.reportRepo.GetMonthlyMilkRevenue(ctx, farmID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	costs, err := s.reportRepo.GetMonthlyCostsByCategory(ctx, farmID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	debts, err := s.reportRepo.GetMonthlyDebts(ctx, farmID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildProfitAndLoss(periodStart, months, revenue, milkRevenue, costs, debts)
+	report.FarmID = farmID
+	report.StartDate = periodStart.Format("2006-01-02")
+	report.EndDate = periodEnd.AddDate(0, 0, -1).Format("2006-01-02")
+
+	if err := s.cache.Set(cacheKey, report, 900); err != nil {
+		log.Printf("Erro ao salvar no cache (não crítico): %v", err)
+	}
+
+	return report, nil
+}
+
+func monthKey(year, month int) string {
+	return fmt.Sprintf("%d-%d", year, month)
+}
+
+func buildProfitAndLoss(periodStart time.Time, months int, revenue, milkRevenue []repository.MonthlyAmount, costs []repository.MonthlyCategoryAmount, debts []repository.MonthlyAmount) *ProfitAndLossReport {
+	revenueByMonth := make(map[string]float64)
+	for _, r := range revenue {
+		revenueByMonth[monthKey(r.Year, r.Month)] += r.Total
+	}
+	milkRevenueByMonth := make(map[string]float64)
+	for _, m := range milkRevenue {
+		milkRevenueByMonth[monthKey(m.Year, m.Month)] += m.Total
+	}
+	debtsByMonth := make(map[string]float64)
+	for _, d := range debts {
+		debtsByMonth[monthKey(d.Year, d.Month)] += d.Total
+	}
+	costsByMonth := make(map[string][]repository.MonthlyCategoryAmount)
+	for _, c := range costs {
+		key := monthKey(c.Year, c.Month)
+		costsByMonth[key] = append(costsByMonth[key], c)
+	}
+
+	report := &ProfitAndLossReport{Months: make([]MonthlyPnL, 0, months)}
+	categoryTotals := make(map[string]float64)
+	salesTotal, milkTotal := 0.0, 0.0
+
+	for i := 0; i < months; i++ {
+		current := periodStart.AddDate(0, i, 0)
+		key := monthKey(current.Year(), int(current.Month()))
+
+		monthly := MonthlyPnL{
+			Month:      repository.MonthNames[current.Month()-1],
+			Year:       current.Year(),
+			Revenue:    revenueByMonth[key] + milkRevenueByMonth[key],
+			Debts:      debtsByMonth[key],
+			Categories: []PnLCategory{},
+		}
+		if revenueByMonth[key] > 0 {
+			monthly.Categories = append(monthly.Categories, PnLCategory{Category: PnLRevenueCategory, Type: "revenue", Total: revenueByMonth[key]})
+		}
+		if milkRevenueByMonth[key] > 0 {
+			monthly.Categories = append(monthly.Categories, PnLCategory{Category: PnLMilkCategory, Type: "revenue", Total: milkRevenueByMonth[key]})
+		}
+		salesTotal += revenueByMonth[key]
+		milkTotal += milkRevenueByMonth[key]
+		for _, c := range costsByMonth[key] {
+			monthly.Costs += c.Total
+			monthly.Categories = append(monthly.Categories, PnLCategory{Category: c.Category, Type: "cost", Total: c.Total})
+			categoryTotals[c.Category] += c.Total
+		}
+		if monthly.Debts > 0 {
+			monthly.Categories = append(monthly.Categories, PnLCategory{Category: PnLDebtsCategory, Type: "debt", Total: monthly.Debts})
+		}
+		monthly.NetResult = monthly.Revenue - monthly.Costs
+		monthly.Margin = margin(monthly.NetResult, monthly.Revenue)
+
+		report.TotalRevenue += monthly.Revenue
+		report.TotalCosts += monthly.Costs
+		report.TotalDebts += monthly.Debts
+		report.Months = append(report.Months, monthly)
+	}
+
+	report.NetResult = report.TotalRevenue - report.TotalCosts
+	report.Margin = margin(report.NetResult, report.TotalRevenue)
+
+	report.Categories = []PnLCategory{{Category: PnLRevenueCategory, Type: "revenue", Total: salesTotal}}
+	if milkTotal > 0 {
+		report.Categories = append(report.Categories, PnLCategory{Category: PnLMilkCategory, Type: "revenue", Total: milkTotal})
+	}
+	costCategories := make([]PnLCategory, 0, len(categoryTotals))
+	for category, total := range categoryTotals {
+		costCategories = append(costCategories, PnLCategory{Category: category, Type: "cost", Total: total})
+	}
+	sort.Slice(costCategories, func(i, j int) bool {
+		return costCategories[i].Total > costCategories[j].Total
+	})
+	report.Categories = append(report.Categories, costCategories...)
+	report.Categories = append(report.Categories, PnLCategory{Category: PnLDebtsCategory, Type: "debt", Total: report.TotalDebts})
+
+	return report
+}
+
+func margin(netResult, revenue float64) *float64 {
+	if revenue <= 0 {
+		return nil
+	}
+	value := math.Round(netResult/revenue*10000) / 100
+	return &value
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/fazendapro/FazendaPro-api/internal/models"
@@ -10,21 +12,77 @@ import (
 )
 
 type ReproductionService struct {
-	repository repository.ReproductionRepositoryInterface
+	repository          repository.ReproductionRepositoryInterface
+	eventRepository     repository.ReproductionEventRepositoryInterface
+	animalRepository    repository.AnimalRepositoryInterface
+	gestationRepository repository.GestationPeriodRepositoryInterface
 }
 
-func NewReproductionService(repository repository.ReproductionRepositoryInterface) *ReproductionService {
-	return &ReproductionService{repository: repository}
+type PhaseTransitionData struct {
+	InseminationDate       *time.Time
+	InseminationType       string
+	PregnancyDate          *time.Time
+	ActualBirthDate        *time.Time
+	LactationStartDate     *time.Time
+	LactationEndDate       *time.Time
+	DryPeriodStartDate     *time.Time
+	VeterinaryConfirmation *bool
+	Observations           *string
 }
 
-func (s *ReproductionService) CreateReproduction(reproduction *models.Reproduction) error {
+type ReproductionUpdate struct {
+	PhaseTransitionData
+	CurrentPhase      *models.ReproductionPhase
+	ExpectedBirthDate *time.Time
+}
+
+type PhaseTransitionError struct {
+	Field   string
+	Message string
+}
+
+func (e *PhaseTransitionError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+type CalvingRecord struct {
+	EventID      uint
+	Date         time.Time
+	CalfID       *uint
+	IntervalDays *int
+	Observations string
+}
+
+type CalvingHistory struct {
+	AnimalID                   uint
+	TotalCalvings              int
+	TotalAbortions             int
+	AgeAtFirstCalvingDays      *int
+	AverageCalvingIntervalDays *float64
+	Calvings                   []CalvingRecord
+}
+
+func NewReproductionService(repository repository.ReproductionRepositoryInterface, eventRepository repository.ReproductionEventRepositoryInterface, animalRepository repository.AnimalRepositoryInterface, gestationRepository repository.GestationPeriodRepositoryInterface) *ReproductionService {
+	return &ReproductionService{
+		repository:          repository,
+		eventRepository:     eventRepository,
+		animalRepository:    animalRepository,
+		gestationRepository: gestationRepository,
+	}
+}
+
+func (s *ReproductionService) CreateReproduction(reproduction *models.Reproduction, farmID uint) error {
 	log.Println("Creating reproduction record", reproduction)
 
 	if reproduction.AnimalID == 0 {
 		return errors.New("ID do animal é obrigatório")
 	}
 
-	existingReproduction, err := s.repository.FindByAnimalID(reproduction.AnimalID)
+	if err := checkAnimalInFarm(s.animalRepository, reproduction.AnimalID, farmID); err != nil {
+		return err
+	}
+
+	existingReproduction, err := s.repository.FindByAnimalID(reproduction.AnimalID, farmID)
 	if err != nil {
 		return err
 	}
@@ -37,142 +95,487 @@ func (s *ReproductionService) CreateReproduction(reproduction *models.Reproducti
 		reproduction.CurrentPhase = models.PhaseVazias
 	}
 
-	now := time.Now()
-	reproduction.CreatedAt = now
-	reproduction.UpdatedAt = now
+	reproduction.CreatedAt = time.Now()
+	events := models.ReproductionEventsFromSnapshot(reproduction, reproduction.CreatedAt)
 
-	return s.repository.Create(reproduction)
+	return s.appendEvents(reproduction, farmID, nil, events)
 }
 
-func (s *ReproductionService) GetReproductionByID(id uint) (*models.Reproduction, error) {
-	return s.repository.FindByID(id)
+func (s *ReproductionService) GetReproductionByID(id, farmID uint) (*models.Reproduction, error) {
+	return s.repository.FindByID(id, farmID)
 }
 
-func (s *ReproductionService) GetReproductionByAnimalID(animalID uint) (*models.Reproduction, error) {
-	return s.repository.FindByAnimalID(animalID)
+func (s *ReproductionService) GetReproductionByAnimalID(animalID, farmID uint) (*models.Reproduction, error) {
+	return s.repository.FindByAnimalID(animalID, farmID)
 }
 
 func (s *ReproductionService) GetReproductionsByFarmID(farmID uint) ([]models.Reproduction, error) {
 	return s.repository.FindByFarmID(farmID)
 }
 
-func (s *ReproductionService) GetReproductionsByPhase(phase models.ReproductionPhase) ([]models.Reproduction, error) {
-	return s.repository.FindByPhase(phase)
+func (s *ReproductionService) GetReproductionsByPhase(farmID uint, phase models.ReproductionPhase) ([]models.Reproduction, error) {
+	return s.repository.FindByPhase(farmID, phase)
 }
 
-func (s *ReproductionService) UpdateReproduction(reproduction *models.Reproduction) error {
-	if reproduction.ID == 0 {
+func (s *ReproductionService) UpdateReproduction(id, farmID uint, update ReproductionUpdate) error {
+	if id == 0 {
 		return errors.New("ID do registro de reprodução é obrigatório para atualização")
 	}
 
-	existingReproduction, err := s.repository.FindByID(reproduction.ID)
+	existingReproduction, err := s.repository.FindByID(id, farmID)
 	if err != nil {
 		return err
 	}
 
 	if existingReproduction == nil {
-		return errors.New("registro de reprodução não encontrado")
+		return errors.New(ErrReproductionNotFoundOrNotBelongsToFarm)
 	}
 
-	reproduction.UpdatedAt = time.Now()
+	if err := checkEventDerivedFields(existingReproduction, update); err != nil {
+		return err
+	}
+
+	if update.Observations != nil {
+		existingReproduction.Observations = *update.Observations
+	}
+	existingReproduction.UpdatedAt = time.Now()
+
+	return s.repository.Update(existingReproduction, farmID)
+}
+
+func checkEventDerivedFields(existing *models.Reproduction, update ReproductionUpdate) error {
+	fields := []struct {
+		name    string
+		changed bool
+	}{
+		{"current_phase", update.CurrentPhase != nil && *update.CurrentPhase != existing.CurrentPhase},
+		{"insemination_date", dateChanged(existing.InseminationDate, update.InseminationDate)},
+		{"insemination_type", update.InseminationType != "" && update.InseminationType != existing.InseminationType},
+		{"pregnancy_date", dateChanged(existing.PregnancyDate, update.PregnancyDate)},
+		{"expected_birth_date", dateChanged(existing.ExpectedBirthDate, update.ExpectedBirthDate)},
+		{"actual_birth_date", dateChanged(existing.ActualBirthDate, update.ActualBirthDate)},
+		{"lactation_start_date", dateChanged(existing.LactationStartDate, update.LactationStartDate)},
+		{"lactation_end_date", dateChanged(existing.LactationEndDate, update.LactationEndDate)},
+		{"dry_period_start_date", dateChanged(existing.DryPeriodStartDate, update.DryPeriodStartDate)},
+		{"veterinary_confirmation", update.VeterinaryConfirmation != nil && *update.VeterinaryConfirmation != existing.VeterinaryConfirmation},
+	}
+
+	for _, field := range fields {
+		if field.changed {
+			return &PhaseTransitionError{Field: field.name, Message: ErrReproductionFieldFromEvents}
+		}
+	}
+	return nil
+}
 
-	return s.repository.Update(reproduction)
+func dateChanged(current, requested *time.Time) bool {
+	if requested == nil {
+		return false
+	}
+	return current == nil || !calendarDay(*current).Equal(calendarDay(*requested))
 }
 
-func (s *ReproductionService) UpdateReproductionPhase(animalID uint, newPhase models.ReproductionPhase, additionalData map[string]interface{}) error {
-	reproduction, err := s.repository.FindByAnimalID(animalID)
+func (s *ReproductionService) UpdateReproductionPhase(animalID, farmID uint, newPhase models.ReproductionPhase, data PhaseTransitionData) error {
+	reproduction, err := s.repository.FindByAnimalID(animalID, farmID)
 	if err != nil {
 		return err
 	}
 
 	if reproduction == nil {
-		return errors.New("registro de reprodução não encontrado para este animal")
+		return errors.New(ErrReproductionNotFoundOrNotBelongsToFarm)
+	}
+
+	history, err := s.eventRepository.FindByAnimalID(animalID, farmID)
+	if err != nil {
+		return err
+	}
+
+	if err := validatePhaseTransition(reproduction.CurrentPhase, newPhase, data, history, time.Now()); err != nil {
+		return err
 	}
 
-	reproduction.CurrentPhase = newPhase
-	now := time.Now()
+	events := phaseChangeEvents(animalID, newPhase, data, history, time.Now())
 
-	s.applyPhaseData(reproduction, newPhase, additionalData, now)
+	if data.Observations != nil {
+		reproduction.Observations = *data.Observations
+		events[len(events)-1].Observations = *data.Observations
+	}
+
+	return s.appendEvents(reproduction, farmID, history, events)
+}
+
+func validatePhaseTransition(current, next models.ReproductionPhase, data PhaseTransitionData, history []models.ReproductionEvent, now time.Time) error {
+	if _, ok := models.AllowedPhaseTransitions[next]; !ok {
+		return &PhaseTransitionError{Field: "new_phase", Message: "fase de reprodução inválida"}
+	}
+
+	if current == next {
+		return &PhaseTransitionError{Field: "new_phase", Message: fmt.Sprintf("o animal já está na fase %s", current)}
+	}
+
+	if !models.CanTransitionPhase(current, next) {
+		return &PhaseTransitionError{Field: "new_phase", Message: fmt.Sprintf("transição de %s para %s não permitida; a partir de %s são permitidas: %s", current, next, current, allowedPhaseNames(current))}
+	}
+
+	field, date := phaseEventDate(next, data)
+	if field != "" && date == nil {
+		return &PhaseTransitionError{Field: field, Message: fmt.Sprintf("obrigatória para a fase %s", next)}
+	}
+
+	if date != nil {
+		if err := validatePhaseDate(field, *date, history, now); err != nil {
+			return err
+		}
+	}
+
+	if next == models.PhasePrenhas {
+		if data.InseminationDate != nil {
+			if data.InseminationDate.After(now) {
+				return &PhaseTransitionError{Field: "insemination_date", Message: "não pode ser uma data futura"}
+			}
+			if data.InseminationDate.After(*date) {
+				return &PhaseTransitionError{Field: "insemination_date", Message: "não pode ser posterior à data da prenhez"}
+			}
+		}
+		if data.InseminationType != "" && data.InseminationType != models.InseminationTypeNatural && data.InseminationType != models.InseminationTypeArtificial {
+			return &PhaseTransitionError{Field: "insemination_type", Message: fmt.Sprintf("deve ser %s ou %s", models.InseminationTypeNatural, models.InseminationTypeArtificial)}
+		}
+	}
+
+	return nil
+}
+
+func validatePhaseDate(field string, date time.Time, history []models.ReproductionEvent, now time.Time) error {
+	if date.After(now) {
+		return &PhaseTransitionError{Field: field, Message: "não pode ser uma data futura"}
+	}
 
-	if observations, ok := additionalData["observations"].(string); ok {
-		reproduction.Observations = observations
+	if len(history) > 0 {
+		latest := history[len(history)-1].Date
+		if date.Before(latest) {
+			return &PhaseTransitionError{Field: field, Message: fmt.Sprintf("não pode ser anterior ao último evento reprodutivo (%s)", latest.Format("2006-01-02"))}
+		}
 	}
 
-	reproduction.UpdatedAt = now
+	return nil
+}
 
-	return s.repository.Update(reproduction)
+func allowedPhaseNames(phase models.ReproductionPhase) string {
+	allowed := models.AllowedPhaseTransitions[phase]
+	names := make([]string, len(allowed))
+	for i, next := range allowed {
+		names[i] = next.String()
+	}
+	return strings.Join(names, ", ")
 }
 
-func (s *ReproductionService) applyPhaseData(reproduction *models.Reproduction, phase models.ReproductionPhase, additionalData map[string]interface{}, now time.Time) {
+func phaseEventDate(phase models.ReproductionPhase, data PhaseTransitionData) (string, *time.Time) {
 	switch phase {
 	case models.PhasePrenhas:
-		s.applyPrenhasPhase(reproduction, additionalData)
+		return "pregnancy_date", data.PregnancyDate
 	case models.PhaseLactacao:
-		s.applyLactacaoPhase(reproduction, additionalData, now)
+		if data.ActualBirthDate == nil {
+			return "actual_birth_date", data.LactationStartDate
+		}
+		return "actual_birth_date", data.ActualBirthDate
 	case models.PhaseSecando:
-		s.applySecandoPhase(reproduction, additionalData, now)
-	case models.PhaseVazias:
-		s.applyVaziasPhase(reproduction)
+		if data.DryPeriodStartDate == nil {
+			return "dry_period_start_date", data.LactationEndDate
+		}
+		return "dry_period_start_date", data.DryPeriodStartDate
+	}
+	return "", nil
+}
+
+func phaseChangeEvents(animalID uint, phase models.ReproductionPhase, data PhaseTransitionData, history []models.ReproductionEvent, now time.Time) []models.ReproductionEvent {
+	var events []models.ReproductionEvent
+
+	if phase == models.PhasePrenhas && data.InseminationDate != nil && !hasEventOn(history, models.ReproductionEventInsemination, *data.InseminationDate) {
+		events = append(events, models.ReproductionEvent{
+			AnimalID:         animalID,
+			Type:             models.ReproductionEventInsemination,
+			Date:             *data.InseminationDate,
+			InseminationType: data.InseminationType,
+		})
+	}
+
+	date := now
+	if _, eventDate := phaseEventDate(phase, data); eventDate != nil {
+		date = *eventDate
+	}
+
+	event := models.PhaseChangeEvent(animalID, phase, date)
+	if phase == models.PhasePrenhas && data.VeterinaryConfirmation != nil {
+		event.VeterinaryConfirmation = *data.VeterinaryConfirmation
+	}
+
+	return append(events, event)
+}
+
+func checkEventTransition(current models.ReproductionPhase, event models.ReproductionEvent) error {
+	target, changesPhase := models.EventTargetPhase(event)
+	if !changesPhase {
+		return nil
+	}
+
+	if target == current {
+		if event.Type == models.ReproductionEventPregnancyCheck && target == models.PhaseVazias {
+			return nil
+		}
+		return &PhaseTransitionError{Field: "type", Message: fmt.Sprintf("evento %s inválido: o animal já está na fase %s", event.Type, current)}
+	}
+
+	if !models.CanTransitionPhase(current, target) {
+		return &PhaseTransitionError{Field: "type", Message: fmt.Sprintf("evento %s leva de %s para %s, transição não permitida; a partir de %s são permitidas: %s", event.Type, current, target, current, allowedPhaseNames(current))}
+	}
+
+	return nil
+}
+
+func checkEventReplay(history []models.ReproductionEvent, event models.ReproductionEvent) error {
+	invalidBefore := make(map[uint]bool)
+	replayEventTransitions(history, func(step models.ReproductionEvent, err error) error {
+		if err != nil {
+			invalidBefore[step.ID] = true
+		}
+		return nil
+	})
+
+	timeline := append(append([]models.ReproductionEvent{}, history...), event)
+	return replayEventTransitions(timeline, func(step models.ReproductionEvent, err error) error {
+		if err == nil || invalidBefore[step.ID] {
+			return nil
+		}
+		if step.ID == 0 {
+			return err
+		}
+		return &PhaseTransitionError{Field: "date", Message: fmt.Sprintf("o evento %s de %s ficaria inválido: %s", step.Type, step.Date.Format("2006-01-02"), err)}
+	})
+}
+
+func replayEventTransitions(events []models.ReproductionEvent, visit func(models.ReproductionEvent, error) error) error {
+	ordered := make([]models.ReproductionEvent, len(events))
+	copy(ordered, events)
+	models.SortReproductionEvents(ordered)
+
+	phase := models.PhaseVazias
+	for _, step := range ordered {
+		if err := visit(step, checkEventTransition(phase, step)); err != nil {
+			return err
+		}
+		if target, changesPhase := models.EventTargetPhase(step); changesPhase {
+			phase = target
+		}
+	}
+	return nil
+}
+
+func hasEventOn(history []models.ReproductionEvent, eventType string, date time.Time) bool {
+	for _, event := range history {
+		if event.Type == eventType && event.Date.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ReproductionService) RecordEvent(event *models.ReproductionEvent, farmID uint) (*models.Reproduction, error) {
+	if err := s.validateEvent(event, farmID); err != nil {
+		return nil, err
+	}
+
+	reproduction, err := s.repository.FindByAnimalID(event.AnimalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reproduction == nil {
+		reproduction = &models.Reproduction{AnimalID: event.AnimalID, CreatedAt: time.Now()}
+	}
+
+	history, err := s.eventRepository.FindByAnimalID(event.AnimalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkEventReplay(history, *event); err != nil {
+		return nil, err
+	}
+
+	events := []models.ReproductionEvent{*event}
+	if err := s.appendEvents(reproduction, farmID, history, events); err != nil {
+		return nil, err
+	}
+	*event = events[0]
+
+	return reproduction, nil
+}
+
+func (s *ReproductionService) validateEvent(event *models.ReproductionEvent, farmID uint) error {
+	if event.AnimalID == 0 {
+		return errors.New("ID do animal é obrigatório")
+	}
+
+	if !models.IsValidReproductionEventType(event.Type) {
+		return errors.New("tipo de evento inválido")
+	}
+
+	if event.Date.IsZero() {
+		return errors.New("data do evento é obrigatória")
+	}
+
+	if event.Date.After(time.Now()) {
+		return errors.New("data do evento não pode ser futura")
+	}
+
+	animal, err := s.animalRepository.FindByIDAndFarmID(event.AnimalID, farmID)
+	if err != nil {
+		return err
+	}
+
+	if animal == nil {
+		return errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+
+	if animal.Sex != models.AnimalSexFemale {
+		return errors.New("eventos reprodutivos são permitidos apenas para fêmeas")
 	}
+
+	switch event.Type {
+	case models.ReproductionEventInsemination:
+		if event.InseminationType != "" && event.InseminationType != models.InseminationTypeNatural && event.InseminationType != models.InseminationTypeArtificial {
+			return errors.New("tipo de inseminação inválido")
+		}
+		if event.SireID != nil {
+			sire, err := s.animalRepository.FindByIDAndFarmID(*event.SireID, farmID)
+			if err != nil {
+				return err
+			}
+			if sire == nil || sire.Sex != models.AnimalSexMale {
+				return errors.New("reprodutor deve ser um macho desta fazenda")
+			}
+		}
+	case models.ReproductionEventPregnancyCheck:
+		if event.PregnancyPositive == nil {
+			return errors.New("resultado do diagnóstico de gestação é obrigatório")
+		}
+	case models.ReproductionEventCalving:
+		if event.CalfID != nil {
+			if err := checkAnimalInFarm(s.animalRepository, *event.CalfID, farmID); err != nil {
+				return errors.New("bezerro não encontrado nesta fazenda")
+			}
+		}
+	}
+
+	return nil
 }
 
-func (s *ReproductionService) applyPrenhasPhase(reproduction *models.Reproduction, additionalData map[string]interface{}) {
-	if pregnancyDate, ok := additionalData["pregnancy_date"].(time.Time); ok {
-		reproduction.PregnancyDate = &pregnancyDate
-		expectedBirth := pregnancyDate.AddDate(0, 0, 283)
-		reproduction.ExpectedBirthDate = &expectedBirth
+func (s *ReproductionService) appendEvents(reproduction *models.Reproduction, farmID uint, history, events []models.ReproductionEvent) error {
+	gestationDays, err := s.gestationDays(reproduction.AnimalID, farmID)
+	if err != nil {
+		return err
 	}
-	if inseminationDate, ok := additionalData["insemination_date"].(time.Time); ok {
-		reproduction.InseminationDate = &inseminationDate
+
+	models.ApplyReproductionEvents(reproduction, append(history, events...), gestationDays)
+	reproduction.UpdatedAt = time.Now()
+
+	return s.eventRepository.AppendEvents(events, reproduction)
+}
+
+func (s *ReproductionService) gestationDays(animalID, farmID uint) (int, error) {
+	animal, err := s.animalRepository.FindByIDAndFarmID(animalID, farmID)
+	if err != nil {
+		return 0, err
 	}
-	if inseminationType, ok := additionalData["insemination_type"].(string); ok {
-		reproduction.InseminationType = inseminationType
+
+	periods, err := s.gestationRepository.FindByFarmID(farmID)
+	if err != nil {
+		return 0, err
 	}
-	if veterinaryConfirmation, ok := additionalData["veterinary_confirmation"].(bool); ok {
-		reproduction.VeterinaryConfirmation = veterinaryConfirmation
+
+	return models.GestationDaysFor(periods, animal), nil
+}
+
+func (s *ReproductionService) GetEventsByAnimalID(animalID, farmID uint) ([]models.ReproductionEvent, error) {
+	if err := checkAnimalInFarm(s.animalRepository, animalID, farmID); err != nil {
+		return nil, err
 	}
+
+	return s.eventRepository.FindByAnimalID(animalID, farmID)
 }
 
-func (s *ReproductionService) applyLactacaoPhase(reproduction *models.Reproduction, additionalData map[string]interface{}, now time.Time) {
-	if lactationStartDate, ok := additionalData["lactation_start_date"].(time.Time); ok {
-		reproduction.LactationStartDate = &lactationStartDate
-	} else {
-		reproduction.LactationStartDate = &now
+func (s *ReproductionService) GetCalvingHistory(animalID, farmID uint) (*CalvingHistory, error) {
+	animal, err := s.animalRepository.FindByIDAndFarmID(animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	if animal == nil {
+		return nil, errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
 	}
-	if actualBirthDate, ok := additionalData["actual_birth_date"].(time.Time); ok {
-		reproduction.ActualBirthDate = &actualBirthDate
+
+	events, err := s.eventRepository.FindByAnimalID(animalID, farmID)
+	if err != nil {
+		return nil, err
 	}
+
+	return buildCalvingHistory(animal, events), nil
 }
 
-func (s *ReproductionService) applySecandoPhase(reproduction *models.Reproduction, additionalData map[string]interface{}, now time.Time) {
-	if dryPeriodStartDate, ok := additionalData["dry_period_start_date"].(time.Time); ok {
-		reproduction.DryPeriodStartDate = &dryPeriodStartDate
-	} else {
-		reproduction.DryPeriodStartDate = &now
+func buildCalvingHistory(animal *models.Animal, events []models.ReproductionEvent) *CalvingHistory {
+	history := &CalvingHistory{
+		AnimalID: animal.ID,
+		Calvings: []CalvingRecord{},
 	}
-	if lactationEndDate, ok := additionalData["lactation_end_date"].(time.Time); ok {
-		reproduction.LactationEndDate = &lactationEndDate
+
+	var totalInterval int
+	for _, event := range events {
+		switch event.Type {
+		case models.ReproductionEventAbortion:
+			history.TotalAbortions++
+		case models.ReproductionEventCalving:
+			record := CalvingRecord{
+				EventID:      event.ID,
+				Date:         event.Date,
+				CalfID:       event.CalfID,
+				Observations: event.Observations,
+			}
+
+			if len(history.Calvings) > 0 {
+				interval := daysBetween(history.Calvings[len(history.Calvings)-1].Date, event.Date)
+				record.IntervalDays = &interval
+				totalInterval += interval
+			} else if animal.BirthDate != nil {
+				age := daysBetween(*animal.BirthDate, event.Date)
+				history.AgeAtFirstCalvingDays = &age
+			}
+
+			history.Calvings = append(history.Calvings, record)
+		}
+	}
+
+	history.TotalCalvings = len(history.Calvings)
+	if history.TotalCalvings > 1 {
+		average := float64(totalInterval) / float64(history.TotalCalvings-1)
+		history.AverageCalvingIntervalDays = &average
 	}
+
+	return history
 }
 
-func (s *ReproductionService) applyVaziasPhase(reproduction *models.Reproduction) {
-	reproduction.PregnancyDate = nil
-	reproduction.ExpectedBirthDate = nil
-	reproduction.ActualBirthDate = nil
-	reproduction.LactationStartDate = nil
-	reproduction.LactationEndDate = nil
-	reproduction.DryPeriodStartDate = nil
+func daysBetween(start, end time.Time) int {
+	return int(end.Sub(start).Hours() / 24)
 }
 
-func (s *ReproductionService) DeleteReproduction(id uint) error {
-	existingReproduction, err := s.repository.FindByID(id)
+func (s *ReproductionService) DeleteReproduction(id, farmID uint) error {
+	existingReproduction, err := s.repository.FindByID(id, farmID)
 	if err != nil {
 		return err
 	}
 
 	if existingReproduction == nil {
-		return errors.New("registro de reprodução não encontrado")
+		return errors.New(ErrReproductionNotFoundOrNotBelongsToFarm)
 	}
 
-	return s.repository.Delete(id)
+	return s.eventRepository.DeleteAnimalHistory(existingReproduction.AnimalID, farmID)
 }
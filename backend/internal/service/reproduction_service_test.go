@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+)
+
+func TestUpdateReproductionRejectsEventDerivedFields(t *testing.T) {
+	animals, _, reproductions := newFarmScopeFixture()
+	pregnancyDate := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	reproductions.reproductions[30].CurrentPhase = models.PhasePrenhas
+	reproductions.reproductions[30].PregnancyDate = &pregnancyDate
+	service := NewReproductionService(reproductions, nil, animals, nil)
+
+	phase := models.PhaseLactacao
+	err := service.UpdateReproduction(30, ownerFarmID, ReproductionUpdate{CurrentPhase: &phase})
+	var transitionErr *PhaseTransitionError
+	if !errors.As(err, &transitionErr) || transitionErr.Field != "current_phase" {
+		t.Fatalf("UpdateReproduction changing phase = %v, want PhaseTransitionError on current_phase", err)
+	}
+
+	movedDate := pregnancyDate.AddDate(0, 0, 3)
+	err = service.UpdateReproduction(30, ownerFarmID, ReproductionUpdate{PhaseTransitionData: PhaseTransitionData{PregnancyDate: &movedDate}})
+	if !errors.As(err, &transitionErr) || transitionErr.Field != "pregnancy_date" {
+		t.Fatalf("UpdateReproduction changing pregnancy date = %v, want PhaseTransitionError on pregnancy_date", err)
+	}
+
+	if observations := reproductions.reproductions[30].Observations; observations != "original" {
+		t.Fatalf("observations = %q after rejected update, want original", observations)
+	}
+}
+
+func TestUpdateReproductionAcceptsUnchangedFields(t *testing.T) {
+	animals, _, reproductions := newFarmScopeFixture()
+	pregnancyDate := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	reproductions.reproductions[30].CurrentPhase = models.PhasePrenhas
+	reproductions.reproductions[30].PregnancyDate = &pregnancyDate
+	service := NewReproductionService(reproductions, nil, animals, nil)
+
+	phase := models.PhasePrenhas
+	sameDay := pregnancyDate.Add(15 * time.Hour)
+	observations := "toque confirmado"
+	update := ReproductionUpdate{
+		PhaseTransitionData: PhaseTransitionData{PregnancyDate: &sameDay, Observations: &observations},
+		CurrentPhase:        &phase,
+	}
+	if err := service.UpdateReproduction(30, ownerFarmID, update); err != nil {
+		t.Fatalf("UpdateReproduction with unchanged fields: %v", err)
+	}
+
+	if got := reproductions.reproductions[30].Observations; got != observations {
+		t.Fatalf("observations = %q, want %q", got, observations)
+	}
+}
+
+func reproductionEvent(id uint, eventType string, date string, positive ...bool) models.ReproductionEvent {
+	parsed, _ := time.Parse("2006-01-02", date)
+	event := models.ReproductionEvent{ID: id, AnimalID: 10, Type: eventType, Date: parsed}
+	if len(positive) > 0 {
+		event.PregnancyPositive = &positive[0]
+	}
+	return event
+}
+
+func TestCheckEventReplay(t *testing.T) {
+	pregnantThenCalved := []models.ReproductionEvent{
+		reproductionEvent(1, models.ReproductionEventInsemination, "2025-01-01"),
+		reproductionEvent(2, models.ReproductionEventPregnancyCheck, "2025-02-01", true),
+		reproductionEvent(3, models.ReproductionEventCalving, "2025-10-01"),
+	}
+	legacyStartingWithCalving := []models.ReproductionEvent{
+		reproductionEvent(1, models.ReproductionEventCalving, "2025-03-01"),
+	}
+
+	tests := []struct {
+		name      string
+		history   []models.ReproductionEvent
+		event     models.ReproductionEvent
+		wantField string
+	}{
+		{"backdated abortion invalidates later calving", pregnantThenCalved, reproductionEvent(0, models.ReproductionEventAbortion, "2025-03-01"), "date"},
+		{"backdated dry-off from empty", pregnantThenCalved, reproductionEvent(0, models.ReproductionEventDryOff, "2024-12-01"), "type"},
+		{"backdated insemination keeps phases", pregnantThenCalved, reproductionEvent(0, models.ReproductionEventInsemination, "2024-12-01"), ""},
+		{"dry-off after calving", pregnantThenCalved, reproductionEvent(0, models.ReproductionEventDryOff, "2026-06-01"), ""},
+		{"legacy invalid step is tolerated", legacyStartingWithCalving, reproductionEvent(0, models.ReproductionEventDryOff, "2025-12-01"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEventReplay(tt.history, tt.event)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("checkEventReplay = %v, want nil", err)
+				}
+				return
+			}
+			var transitionErr *PhaseTransitionError
+			if !errors.As(err, &transitionErr) || transitionErr.Field != tt.wantField {
+				t.Fatalf("checkEventReplay = %v, want PhaseTransitionError on %s", err, tt.wantField)
+			}
+		})
+	}
+}
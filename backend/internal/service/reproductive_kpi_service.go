@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const (
+	ReproductiveKPIMaxMonths    = 24
+	PregnancyRateWindowDays     = 21
+	VoluntaryWaitingPeriodDays  = 50
+	reproductiveKPIDaysPerMonth = 30.4375
+)
+
+type ReproductiveKPIMetric struct {
+	Value      *float64
+	SampleSize int
+}
+
+type PregnancyRateWindow struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Eligible  int
+	Pregnant  int
+	Rate      *float64
+}
+
+type ReproductiveKPIs struct {
+	FarmID                     uint
+	StartDate                  time.Time
+	EndDate                    time.Time
+	CalvingIntervalDays        ReproductiveKPIMetric
+	DaysOpen                   ReproductiveKPIMetric
+	ServicesPerConception      ReproductiveKPIMetric
+	FirstServiceConceptionRate ReproductiveKPIMetric
+	PregnancyRate              ReproductiveKPIMetric
+	AgeAtFirstCalvingMonths    ReproductiveKPIMetric
+	PregnancyRateWindows       []PregnancyRateWindow
+}
+
+type ReproductiveKPIService interface {
+	GetKPIs(ctx context.Context, farmID uint, startDate, endDate time.Time) (*ReproductiveKPIs, error)
+}
+
+type reproductiveKPIService struct {
+	animalRepo repository.AnimalRepositoryInterface
+	eventRepo  repository.ReproductionEventRepositoryInterface
+}
+
+func NewReproductiveKPIService(animalRepo repository.AnimalRepositoryInterface, eventRepo repository.ReproductionEventRepositoryInterface) ReproductiveKPIService {
+	return &reproductiveKPIService{
+		animalRepo: animalRepo,
+		eventRepo:  eventRepo,
+	}
+}
+
+func (s *reproductiveKPIService) GetKPIs(ctx context.Context, farmID uint, startDate, endDate time.Time) (*ReproductiveKPIs, error) {
+	if farmID == 0 {
+		return nil, errors.New("farm ID is required")
+	}
+	if startDate.After(endDate) {
+		return nil, errors.New("start date cannot be after end date")
+	}
+	if startDate.AddDate(0, ReproductiveKPIMaxMonths, 0).Before(endDate) {
+		return nil, fmt.Errorf("period cannot exceed %d months", ReproductiveKPIMaxMonths)
+	}
+
+	animals, err := s.animalRepo.FindByFarmIDAndSex(farmID, models.AnimalSexFemale)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.FindByFarmID(farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsByAnimal := make(map[uint][]models.ReproductionEvent)
+	for _, event := range events {
+		eventsByAnimal[event.AnimalID] = append(eventsByAnimal[event.AnimalID], event)
+	}
+
+	histories := make([]*cowReproductiveHistory, 0, len(animals))
+	for i := range animals {
+		histories = append(histories, newCowReproductiveHistory(&animals[i], eventsByAnimal[animals[i].ID]))
+	}
+
+	periodStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	periodEnd := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, endDate.Location()).AddDate(0, 0, 1)
+
+	return computeReproductiveKPIs(farmID, periodStart, periodEnd, histories), nil
+}
+
+type serviceOutcome struct {
+	date      time.Time
+	conceived bool
+}
+
+type conceptionRecord struct {
+	date     time.Time
+	services int
+	daysOpen *int
+}
+
+type cowReproductiveHistory struct {
+	animal        *models.Animal
+	calvings      []time.Time
+	conceptions   []conceptionRecord
+	pregnancyEnds []time.Time
+	firstServices []serviceOutcome
+}
+
+func newCowReproductiveHistory(animal *models.Animal, events []models.ReproductionEvent) *cowReproductiveHistory {
+	history := &cowReproductiveHistory{animal: animal}
+
+	ordered := make([]models.ReproductionEvent, len(events))
+	copy(ordered, events)
+	models.SortReproductionEvents(ordered)
+
+	var lastCalving, firstService, pendingInsemination *time.Time
+	var services int
+	pregnant := false
+	firstServiceResolved := false
+
+	startCycle := func() {
+		firstService = nil
+		pendingInsemination = nil
+		services = 0
+		firstServiceResolved = false
+	}
+	resolveFirstService := func(conceived bool) {
+		if firstService != nil && !firstServiceResolved {
+			history.firstServices = append(history.firstServices, serviceOutcome{date: *firstService, conceived: conceived})
+			firstServiceResolved = true
+		}
+	}
+
+	for _, event := range ordered {
+		date := event.Date
+
+		switch event.Type {
+		case models.ReproductionEventInsemination:
+			if pregnant {
+				continue
+			}
+			if firstService == nil {
+				firstService = &date
+			} else {
+				resolveFirstService(false)
+			}
+			pendingInsemination = &date
+			services++
+		case models.ReproductionEventPregnancyCheck:
+			positive := event.PregnancyPositive != nil && *event.PregnancyPositive
+			if positive {
+				if pregnant {
+					continue
+				}
+				conception := date
+				if pendingInsemination != nil {
+					conception = *pendingInsemination
+				}
+				record := conceptionRecord{date: conception, services: services}
+				if lastCalving != nil {
+					days := daysBetween(*lastCalving, conception)
+					record.daysOpen = &days
+				}
+				history.conceptions = append(history.conceptions, record)
+				resolveFirstService(firstService != nil && firstService.Equal(conception))
+				pregnant = true
+				continue
+			}
+			if pregnant {
+				history.pregnancyEnds = append(history.pregnancyEnds, date)
+				pregnant = false
+				startCycle()
+				continue
+			}
+			if pendingInsemination != nil {
+				resolveFirstService(false)
+				pendingInsemination = nil
+			}
+		case models.ReproductionEventCalving:
+			history.calvings = append(history.calvings, date)
+			if pregnant {
+				history.pregnancyEnds = append(history.pregnancyEnds, date)
+			}
+			lastCalving = &date
+			pregnant = false
+			startCycle()
+		case models.ReproductionEventAbortion:
+			if pregnant {
+				history.pregnancyEnds = append(history.pregnancyEnds, date)
+			}
+			pregnant = false
+			startCycle()
+		}
+	}
+
+	return history
+}
+
+func (h *cowReproductiveHistory) pregnantAt(date time.Time) bool {
+	var lastConception, lastEnd *time.Time
+	for i := range h.conceptions {
+		if !h.conceptions[i].date.After(date) {
+			lastConception = &h.conceptions[i].date
+		}
+	}
+	if lastConception == nil {
+		return false
+	}
+	for i := range h.pregnancyEnds {
+		if !h.pregnancyEnds[i].After(date) {
+			lastEnd = &h.pregnancyEnds[i]
+		}
+	}
+	return lastEnd == nil || lastEnd.Before(*lastConception)
+}
+
+func (h *cowReproductiveHistory) lastCalvingAt(date time.Time) *time.Time {
+	var last *time.Time
+	for i := range h.calvings {
+		if !h.calvings[i].After(date) {
+			last = &h.calvings[i]
+		}
+	}
+	return last
+}
+
+func (h *cowReproductiveHistory) inHerdAt(date time.Time) bool {
+	if h.animal.Status == models.AnimalStatusActive {
+		return true
+	}
+	return date.Before(h.animal.UpdatedAt)
+}
+
+func (h *cowReproductiveHistory) eligibleAt(date time.Time) bool {
+	lastCalving := h.lastCalvingAt(date)
+	if lastCalving == nil || daysBetween(*lastCalving, date) < VoluntaryWaitingPeriodDays {
+		return false
+	}
+	return h.inHerdAt(date) && !h.pregnantAt(date)
+}
+
+func (h *cowReproductiveHistory) conceivedBetween(start, end time.Time) bool {
+	for _, conception := range h.conceptions {
+		if !conception.date.Before(start) && conception.date.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+type kpiAccumulator struct {
+	sum   float64
+	count int
+}
+
+func (a *kpiAccumulator) add(value float64) {
+	a.sum += value
+	a.count++
+}
+
+func (a *kpiAccumulator) metric() ReproductiveKPIMetric {
+	if a.count == 0 {
+		return ReproductiveKPIMetric{}
+	}
+	average := a.sum / float64(a.count)
+	return ReproductiveKPIMetric{Value: &average, SampleSize: a.count}
+}
+
+func inPeriod(date, start, end time.Time) bool {
+	return !date.Before(start) && date.Before(end)
+}
+
+func percentageOf(part, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	rate := float64(part) / float64(total) * 100
+	return &rate
+}
+
+func computeReproductiveKPIs(farmID uint, startDate, endDate time.Time, histories []*cowReproductiveHistory) *ReproductiveKPIs {
+	var calvingInterval, daysOpen, servicesPerConception, ageAtFirstCalving kpiAccumulator
+	firstServices, firstServiceConceptions := 0, 0
+
+	for _, history := range histories {
+		for i, calving := range history.calvings {
+			if !inPeriod(calving, startDate, endDate) {
+				continue
+			}
+			if i > 0 {
+				calvingInterval.add(float64(daysBetween(history.calvings[i-1], calving)))
+			} else if history.animal.BirthDate != nil && history.animal.BirthDate.Before(calving) {
+				ageAtFirstCalving.add(float64(daysBetween(*history.animal.BirthDate, calving)) / reproductiveKPIDaysPerMonth)
+			}
+		}
+
+		for _, conception := range history.conceptions {
+			if !inPeriod(conception.date, startDate, endDate) {
+				continue
+			}
+			if conception.daysOpen != nil {
+				daysOpen.add(float64(*conception.daysOpen))
+			}
+			if conception.services > 0 {
+				servicesPerConception.add(float64(conception.services))
+			}
+		}
+
+		for _, outcome := range history.firstServices {
+			if !inPeriod(outcome.date, startDate, endDate) {
+				continue
+			}
+			firstServices++
+			if outcome.conceived {
+				firstServiceConceptions++
+			}
+		}
+	}
+
+	kpis := &ReproductiveKPIs{
+		FarmID:                  farmID,
+		StartDate:               startDate,
+		EndDate:                 endDate.AddDate(0, 0, -1),
+		CalvingIntervalDays:     calvingInterval.metric(),
+		DaysOpen:                daysOpen.metric(),
+		ServicesPerConception:   servicesPerConception.metric(),
+		AgeAtFirstCalvingMonths: ageAtFirstCalving.metric(),
+		FirstServiceConceptionRate: ReproductiveKPIMetric{
+			Value:      percentageOf(firstServiceConceptions, firstServices),
+			SampleSize: firstServices,
+		},
+	}
+
+	totalEligible, totalPregnant := 0, 0
+	for windowStart := startDate; !windowStart.AddDate(0, 0, PregnancyRateWindowDays).After(endDate); windowStart = windowStart.AddDate(0, 0, PregnancyRateWindowDays) {
+		windowEnd := windowStart.AddDate(0, 0, PregnancyRateWindowDays)
+		window := PregnancyRateWindow{StartDate: windowStart, EndDate: windowEnd.AddDate(0, 0, -1)}
+
+		for _, history := range histories {
+			if !history.eligibleAt(windowStart) {
+				continue
+			}
+			window.Eligible++
+			if history.conceivedBetween(windowStart, windowEnd) {
+				window.Pregnant++
+			}
+		}
+
+		window.Rate = percentageOf(window.Pregnant, window.Eligible)
+		totalEligible += window.Eligible
+		totalPregnant += window.Pregnant
+		kpis.PregnancyRateWindows = append(kpis.PregnancyRateWindows, window)
+	}
+
+	kpis.PregnancyRate = ReproductiveKPIMetric{
+		Value:      percentageOf(totalPregnant, totalEligible),
+		SampleSize: totalEligible,
+	}
+
+	return kpis
+}
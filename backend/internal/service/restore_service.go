@@ -0,0 +1,839 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
+	"github.com/fazendapro/FazendaPro-api/internal/utils"
+)
+
+type RestoreRowError struct {
+	Entity  string
+	Row     int
+	ID      uint
+	Message string
+}
+
+type RestoreResult struct {
+	DryRun                         bool
+	Version                        int
+	SourceFarmID                   uint
+	CompanyID                      uint
+	FarmID                         uint
+	Counts                         map[string]int
+	Errors                         []RestoreRowError
+	ReproductionEventsFromSnapshot bool
+}
+
+type RestoreService interface {
+	ResolveCompany(ctx context.Context, farmID, userID, companyID uint) (uint, error)
+	RestoreArchive(ctx context.Context, companyID, ownerUserID uint, reader io.Reader, dryRun bool) (*RestoreResult, error)
+}
+
+type restoreService struct {
+	repo    repository.RestoreRepository
+	storage storage.BlobStorage
+}
+
+func NewRestoreService(repo repository.RestoreRepository, blobStorage storage.BlobStorage) RestoreService {
+	return &restoreService{repo: repo, storage: blobStorage}
+}
+
+type restoreTimestamps struct {
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type restoreAnimal struct {
+	ID                   uint    `json:"id"`
+	EarTagNumberLocal    int     `json:"ear_tag_number_local"`
+	EarTagNumberRegister int     `json:"ear_tag_number_register"`
+	AnimalName           string  `json:"animal_name"`
+	Sex                  int     `json:"sex"`
+	Breed                string  `json:"breed"`
+	Type                 string  `json:"type"`
+	BirthDate            *string `json:"birth_date"`
+	FatherID             *uint   `json:"father_id"`
+	MotherID             *uint   `json:"mother_id"`
+	Confinement          bool    `json:"confinement"`
+	AnimalType           int     `json:"animal_type"`
+	Status               int     `json:"status"`
+	Fertilization        bool    `json:"fertilization"`
+	Castrated            bool    `json:"castrated"`
+	Purpose              int     `json:"purpose"`
+	CurrentBatch         int     `json:"current_batch"`
+	Photo                string  `json:"photo"`
+	restoreTimestamps
+}
+
+type restoreMilkCollection struct {
+	ID       uint    `json:"id"`
+	AnimalID uint    `json:"animal_id"`
+	Date     string  `json:"date"`
+	Shift    string  `json:"shift"`
+	Liters   float64 `json:"liters"`
+	restoreTimestamps
+}
+
+type restoreReproduction struct {
+	ID                     uint    `json:"id"`
+	AnimalID               uint    `json:"animal_id"`
+	CurrentPhase           int     `json:"current_phase"`
+	InseminationDate       *string `json:"insemination_date"`
+	InseminationType       string  `json:"insemination_type"`
+	PregnancyDate          *string `json:"pregnancy_date"`
+	ExpectedBirthDate      *string `json:"expected_birth_date"`
+	ActualBirthDate        *string `json:"actual_birth_date"`
+	LactationStartDate     *string `json:"lactation_start_date"`
+	LactationEndDate       *string `json:"lactation_end_date"`
+	DryPeriodStartDate     *string `json:"dry_period_start_date"`
+	VeterinaryConfirmation bool    `json:"veterinary_confirmation"`
+	Observations           string  `json:"observations"`
+	restoreTimestamps
+}
+
+type restoreReproductionEvent struct {
+	ID                     uint   `json:"id"`
+	AnimalID               uint   `json:"animal_id"`
+	Type                   string `json:"type"`
+	Date                   string `json:"date"`
+	InseminationType       string `json:"insemination_type"`
+	SireID                 *uint  `json:"sire_id"`
+	PregnancyPositive      *bool  `json:"pregnancy_positive"`
+	VeterinaryConfirmation bool   `json:"veterinary_confirmation"`
+	CalfID                 *uint  `json:"calf_id"`
+	Observations           string `json:"observations"`
+	restoreTimestamps
+}
+
+type restoreSale struct {
+	ID        uint    `json:"id"`
+	AnimalID  uint    `json:"animal_id"`
+	BuyerName string  `json:"buyer_name"`
+	Price     float64 `json:"price"`
+	SaleDate  string  `json:"sale_date"`
+	Notes     string  `json:"notes"`
+	restoreTimestamps
+}
+
+type restoreExpense struct {
+	ID          uint    `json:"id"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Category    string  `json:"category"`
+	Date        string  `json:"date"`
+	Notes       string  `json:"notes"`
+	restoreTimestamps
+}
+
+type restoreDebt struct {
+	ID               uint    `json:"id"`
+	Person           string  `json:"person"`
+	CounterpartyType string  `json:"counterparty_type"`
+	SaleID           *uint   `json:"sale_id"`
+	ExpenseID        *uint   `json:"expense_id"`
+	Value            float64 `json:"value"`
+	PaidAmount       float64 `json:"paid_amount"`
+	DueDate          *string `json:"due_date"`
+	Status           string  `json:"status"`
+	PaidAt           *string `json:"paid_at"`
+	restoreTimestamps
+}
+
+type restoreDebtPayment struct {
+	ID          uint    `json:"id"`
+	DebtID      uint    `json:"debt_id"`
+	Amount      float64 `json:"amount"`
+	PaymentDate string  `json:"payment_date"`
+	Notes       string  `json:"notes"`
+	restoreTimestamps
+}
+
+type restoreArchive struct {
+	Kind               string                      `json:"kind"`
+	Version            int                         `json:"version"`
+	Farm               exportArchiveFarm           `json:"farm"`
+	Animals            []restoreAnimal             `json:"animals"`
+	MilkCollections    []restoreMilkCollection     `json:"milk_collections"`
+	Reproductions      []restoreReproduction       `json:"reproductions"`
+	ReproductionEvents *[]restoreReproductionEvent `json:"reproduction_events"`
+	Sales              []restoreSale               `json:"sales"`
+	Expenses           []restoreExpense            `json:"expenses"`
+	Debts              []restoreDebt               `json:"debts"`
+	DebtPayments       []restoreDebtPayment        `json:"debt_payments"`
+}
+
+func (s *restoreService) ResolveCompany(ctx context.Context, farmID, userID, companyID uint) (uint, error) {
+	currentCompanyID, err := s.repo.GetFarmCompanyID(ctx, farmID)
+	if err != nil {
+		return 0, err
+	}
+	if companyID == 0 || companyID == currentCompanyID {
+		return currentCompanyID, nil
+	}
+
+	owns, err := s.repo.UserOwnsCompanyFarm(ctx, userID, companyID)
+	if err != nil {
+		return 0, err
+	}
+	if !owns {
+		return 0, errors.New(ErrCompanyAccessDenied)
+	}
+	return companyID, nil
+}
+
+func (s *restoreService) RestoreArchive(ctx context.Context, companyID, ownerUserID uint, reader io.Reader, dryRun bool) (*RestoreResult, error) {
+	var archive restoreArchive
+	if err := json.NewDecoder(reader).Decode(&archive); err != nil {
+		return nil, fmt.Errorf(ErrReadingRestoreArchive, err)
+	}
+	if archive.Kind != ExportArchiveKind || archive.Farm.ID == 0 {
+		return nil, errors.New(ErrInvalidRestoreArchive)
+	}
+	if archive.Version < 1 || archive.Version > ExportArchiveVersion {
+		return nil, fmt.Errorf(ErrUnsupportedRestoreVersion, archive.Version)
+	}
+
+	exists, err := s.repo.CompanyExists(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New(ErrCompanyNotFound)
+	}
+
+	result := &RestoreResult{
+		DryRun:       dryRun,
+		Version:      archive.Version,
+		SourceFarmID: archive.Farm.ID,
+		CompanyID:    companyID,
+		Counts:       make(map[string]int, len(ExportEntities)),
+	}
+	data := buildFarmRestore(&archive, result)
+	data.CompanyID = companyID
+	data.OwnerUserID = ownerUserID
+	data.Logo = archive.Farm.Logo
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	photoKeys, err := s.storePhotos(ctx, data.Animals)
+	if err != nil {
+		return nil, err
+	}
+
+	farmID, err := s.repo.Restore(ctx, data)
+	if err != nil {
+		deleteStoredFiles(ctx, s.storage, photoKeys...)
+		return nil, err
+	}
+	result.FarmID = farmID
+
+	return result, nil
+}
+
+func (s *restoreService) storePhotos(ctx context.Context, animals []models.Animal) ([]string, error) {
+	var keys []string
+	for i := range animals {
+		animal := &animals[i]
+		photo, ok := decodeBase64Image(animal.Photo)
+		animal.Photo = ""
+		if !ok {
+			continue
+		}
+		if _, _, err := utils.DetectImageType(photo); err != nil {
+			continue
+		}
+
+		photoKey, thumbnailKey, err := storeAnimalPhoto(ctx, s.storage, photo)
+		if err != nil {
+			deleteStoredFiles(ctx, s.storage, keys...)
+			return nil, err
+		}
+		animal.PhotoKey = photoKey
+		animal.PhotoThumbnailKey = thumbnailKey
+		keys = append(keys, photoKey, thumbnailKey)
+	}
+	return keys, nil
+}
+
+func buildFarmRestore(archive *restoreArchive, result *RestoreResult) *repository.FarmRestore {
+	data := &repository.FarmRestore{}
+	farmID := archive.Farm.ID
+	addError := func(entity string, row int, id uint, message string) {
+		result.Errors = append(result.Errors, RestoreRowError{Entity: entity, Row: row, ID: id, Message: message})
+	}
+
+	animalSex := make(map[uint]int, len(archive.Animals))
+	earTags := make(map[int]uint, len(archive.Animals))
+	for i, row := range archive.Animals {
+		animal, err := row.model(farmID)
+		if err == nil {
+			err = validateNewAnimal(animal)
+		}
+		if err != nil {
+			addError(ExportEntityAnimals, i+1, row.ID, err.Error())
+			continue
+		}
+		if _, found := animalSex[row.ID]; found || row.ID == 0 {
+			addError(ExportEntityAnimals, i+1, row.ID, "id ausente ou repetido no arquivo")
+			continue
+		}
+		if previous, found := earTags[animal.EarTagNumberLocal]; found {
+			addError(ExportEntityAnimals, i+1, row.ID, fmt.Sprintf("brinco repetido no arquivo (animal %d)", previous))
+			continue
+		}
+		animalSex[row.ID] = animal.Sex
+		earTags[animal.EarTagNumberLocal] = row.ID
+		data.Animals = append(data.Animals, *animal)
+	}
+	checkRestoreParents(data.Animals, animalSex, func(id uint, message string) {
+		addError(ExportEntityAnimals, restoreRowOf(archive.Animals, id), id, message)
+	})
+	result.Counts[ExportEntityAnimals] = len(data.Animals)
+
+	milkShifts := make(map[string]bool, len(archive.MilkCollections))
+	for i, row := range archive.MilkCollections {
+		collection, err := row.model()
+		if err == nil {
+			err = checkRestoreAnimal(animalSex, collection.AnimalID)
+		}
+		if err != nil {
+			addError(ExportEntityMilkCollections, i+1, row.ID, err.Error())
+			continue
+		}
+		key := fmt.Sprintf("%d:%s:%s", collection.AnimalID, collection.Date.Format("2006-01-02"), collection.Shift)
+		if milkShifts[key] {
+			addError(ExportEntityMilkCollections, i+1, row.ID, ErrMilkCollectionAlreadyExists)
+			continue
+		}
+		milkShifts[key] = true
+		data.MilkCollections = append(data.MilkCollections, *collection)
+	}
+	result.Counts[ExportEntityMilkCollections] = len(data.MilkCollections)
+
+	reproductionAnimals := make(map[uint]bool, len(archive.Reproductions))
+	for i, row := range archive.Reproductions {
+		reproduction, err := row.model()
+		if err == nil {
+			err = checkRestoreAnimal(animalSex, reproduction.AnimalID)
+		}
+		if err == nil && reproductionAnimals[reproduction.AnimalID] {
+			err = errors.New("já existe um registro de reprodução para este animal")
+		}
+		if err != nil {
+			addError(ExportEntityReproductions, i+1, row.ID, err.Error())
+			continue
+		}
+		reproductionAnimals[reproduction.AnimalID] = true
+		data.Reproductions = append(data.Reproductions, *reproduction)
+	}
+	result.Counts[ExportEntityReproductions] = len(data.Reproductions)
+
+	if archive.ReproductionEvents == nil {
+		result.ReproductionEventsFromSnapshot = true
+		for i := range data.Reproductions {
+			reproduction := &data.Reproductions[i]
+			fallbackDate := reproduction.CreatedAt
+			if fallbackDate.IsZero() {
+				fallbackDate = time.Now()
+			}
+			data.ReproductionEvents = append(data.ReproductionEvents, models.ReproductionEventsFromSnapshot(reproduction, fallbackDate)...)
+		}
+	} else {
+		for i, row := range *archive.ReproductionEvents {
+			event, err := row.model()
+			if err == nil {
+				err = checkRestoreReproductionEvent(event, animalSex)
+			}
+			if err != nil {
+				addError(ExportEntityReproductionEvents, i+1, row.ID, err.Error())
+				continue
+			}
+			data.ReproductionEvents = append(data.ReproductionEvents, *event)
+		}
+	}
+	result.Counts[ExportEntityReproductionEvents] = len(data.ReproductionEvents)
+
+	sales := make(map[uint]bool, len(archive.Sales))
+	soldAnimals := make(map[uint]bool, len(archive.Sales))
+	for i, row := range archive.Sales {
+		sale, err := row.model(farmID)
+		if err == nil {
+			err = validateNewSale(sale)
+		}
+		if err == nil {
+			err = checkRestoreAnimal(animalSex, sale.AnimalID)
+		}
+		if err == nil && soldAnimals[sale.AnimalID] {
+			err = errors.New("animal is already sold")
+		}
+		if err == nil && (row.ID == 0 || sales[row.ID]) {
+			err = errors.New("id ausente ou repetido no arquivo")
+		}
+		if err != nil {
+			addError(ExportEntitySales, i+1, row.ID, err.Error())
+			continue
+		}
+		sales[row.ID] = true
+		soldAnimals[sale.AnimalID] = true
+		data.Sales = append(data.Sales, *sale)
+	}
+	result.Counts[ExportEntitySales] = len(data.Sales)
+
+	expenses := make(map[uint]bool, len(archive.Expenses))
+	for i, row := range archive.Expenses {
+		expense, err := row.model(farmID)
+		if err == nil {
+			err = validateExpense(expense)
+		}
+		if err == nil && (row.ID == 0 || expenses[row.ID]) {
+			err = errors.New("id ausente ou repetido no arquivo")
+		}
+		if err != nil {
+			addError(ExportEntityExpenses, i+1, row.ID, err.Error())
+			continue
+		}
+		expense.Category = strings.TrimSpace(expense.Category)
+		expenses[row.ID] = true
+		data.Expenses = append(data.Expenses, *expense)
+	}
+	result.Counts[ExportEntityExpenses] = len(data.Expenses)
+
+	debts := make(map[uint]bool, len(archive.Debts))
+	for i, row := range archive.Debts {
+		debt, err := row.model(farmID)
+		if err == nil {
+			err = validateRestoreDebt(debt, sales, expenses)
+		}
+		if err == nil && (row.ID == 0 || debts[row.ID]) {
+			err = errors.New("id ausente ou repetido no arquivo")
+		}
+		if err != nil {
+			addError(ExportEntityDebts, i+1, row.ID, err.Error())
+			continue
+		}
+		debts[row.ID] = true
+		data.Debts = append(data.Debts, *debt)
+	}
+	result.Counts[ExportEntityDebts] = len(data.Debts)
+
+	for i, row := range archive.DebtPayments {
+		payment, err := row.model()
+		if err == nil && payment.Amount <= 0 {
+			err = errors.New("valor do pagamento deve ser maior que zero")
+		}
+		if err == nil && !debts[payment.DebtID] {
+			err = fmt.Errorf("dívida %d não encontrada no arquivo", payment.DebtID)
+		}
+		if err != nil {
+			addError(ExportEntityDebtPayments, i+1, row.ID, err.Error())
+			continue
+		}
+		data.DebtPayments = append(data.DebtPayments, *payment)
+	}
+	result.Counts[ExportEntityDebtPayments] = len(data.DebtPayments)
+
+	return data
+}
+
+func checkRestoreAnimal(animalSex map[uint]int, animalID uint) error {
+	if _, found := animalSex[animalID]; !found {
+		return fmt.Errorf("animal %d não encontrado no arquivo", animalID)
+	}
+	return nil
+}
+
+func checkRestoreReproductionEvent(event *models.ReproductionEvent, animalSex map[uint]int) error {
+	if sex, found := animalSex[event.AnimalID]; !found {
+		return fmt.Errorf("animal %d não encontrado no arquivo", event.AnimalID)
+	} else if sex != models.AnimalSexFemale {
+		return errors.New("eventos reprodutivos são permitidos apenas para fêmeas")
+	}
+	if event.SireID != nil && animalSex[*event.SireID] != models.AnimalSexMale {
+		return fmt.Errorf("reprodutor %d não encontrado no arquivo ou não é macho", *event.SireID)
+	}
+	if event.CalfID != nil {
+		if err := checkRestoreAnimal(animalSex, *event.CalfID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRestoreParents(animals []models.Animal, animalSex map[uint]int, addError func(id uint, message string)) {
+	parents := make(map[uint][]uint, len(animals))
+	for _, animal := range animals {
+		for _, parent := range []struct {
+			id    *uint
+			sex   int
+			label string
+		}{
+			{animal.FatherID, models.AnimalSexMale, "pai"},
+			{animal.MotherID, models.AnimalSexFemale, "mãe"},
+		} {
+			if parent.id == nil {
+				continue
+			}
+			sex, found := animalSex[*parent.id]
+			switch {
+			case *parent.id == animal.ID:
+				addError(animal.ID, fmt.Sprintf("animal não pode ser %s de si mesmo", parent.label))
+			case !found:
+				addError(animal.ID, fmt.Sprintf("%s %d não encontrado no arquivo", parent.label, *parent.id))
+			case sex != parent.sex:
+				addError(animal.ID, fmt.Sprintf("%s %d tem o sexo incompatível", parent.label, *parent.id))
+			default:
+				parents[animal.ID] = append(parents[animal.ID], *parent.id)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[uint]int, len(animals))
+
+	var visit func(id uint) bool
+	visit = func(id uint) bool {
+		if state[id] == visiting {
+			return true
+		}
+		if state[id] == done {
+			return false
+		}
+		state[id] = visiting
+		cycle := false
+		for _, parent := range parents[id] {
+			if visit(parent) {
+				cycle = true
+			}
+		}
+		state[id] = done
+		return cycle
+	}
+
+	for _, animal := range animals {
+		if state[animal.ID] == unvisited && visit(animal.ID) {
+			addError(animal.ID, "ciclo de parentesco no arquivo")
+		}
+	}
+}
+
+func validateRestoreDebt(debt *models.Debt, sales, expenses map[uint]bool) error {
+	if debt.Value <= 0 {
+		return errors.New("valor deve ser maior que zero")
+	}
+	if debt.SaleID != nil && debt.ExpenseID != nil {
+		return errors.New("a dívida deve estar ligada a uma venda ou a uma despesa, não ambas")
+	}
+	if debt.SaleID != nil && !sales[*debt.SaleID] {
+		return fmt.Errorf("venda %d não encontrada no arquivo", *debt.SaleID)
+	}
+	if debt.ExpenseID != nil && !expenses[*debt.ExpenseID] {
+		return fmt.Errorf("despesa %d não encontrada no arquivo", *debt.ExpenseID)
+	}
+	if err := validateDebtCounterpartyType(debt.CounterpartyType); err != nil {
+		return err
+	}
+	if strings.TrimSpace(debt.Person) == "" {
+		return errors.New("nome da pessoa é obrigatório")
+	}
+	if debt.Status != models.DebtStatusOpen && debt.Status != models.DebtStatusPaid {
+		return errors.New("status deve ser 'open' ou 'paid'")
+	}
+	if debt.PaidAmount < 0 || debt.PaidAmount-debt.Value > 0.005 {
+		return errors.New("valor pago excede o valor da dívida")
+	}
+	return nil
+}
+
+func restoreRowOf(animals []restoreAnimal, id uint) int {
+	for i, animal := range animals {
+		if animal.ID == id {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (row restoreAnimal) model(farmID uint) (*models.Animal, error) {
+	birthDate, err := parseRestoreOptionalDate("birth_date", row.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, updatedAt, err := row.restoreTimestamps.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &models.Animal{
+		ID:                   row.ID,
+		FarmID:               farmID,
+		EarTagNumberLocal:    row.EarTagNumberLocal,
+		EarTagNumberRegister: row.EarTagNumberRegister,
+		AnimalName:           row.AnimalName,
+		Sex:                  row.Sex,
+		Breed:                row.Breed,
+		Type:                 row.Type,
+		BirthDate:            birthDate,
+		Photo:                row.Photo,
+		FatherID:             row.FatherID,
+		MotherID:             row.MotherID,
+		Confinement:          row.Confinement,
+		AnimalType:           row.AnimalType,
+		Status:               row.Status,
+		Fertilization:        row.Fertilization,
+		Castrated:            row.Castrated,
+		Purpose:              row.Purpose,
+		CurrentBatch:         row.CurrentBatch,
+		CreatedAt:            createdAt,
+		UpdatedAt:            updatedAt,
+	}, nil
+}
+
+func (row restoreMilkCollection) model() (*models.MilkCollection, error) {
+	date, err := parseRestoreDate("date", row.Date)
+	if err != nil {
+		return nil, err
+	}
+	shift := models.MilkShiftMorning
+	if row.Shift != "" {
+		parsed, ok := models.ParseMilkShift(row.Shift)
+		if !ok {
+			return nil, errors.New(ErrInvalidMilkShift)
+		}
+		shift = parsed
+	}
+	if row.Liters <= 0 {
+		return nil, errors.New("litros devem ser maiores que zero")
+	}
+	createdAt, updatedAt, err := row.restoreTimestamps.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &models.MilkCollection{
+		ID:        row.ID,
+		AnimalID:  row.AnimalID,
+		Date:      date,
+		Shift:     shift,
+		Liters:    row.Liters,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+func (row restoreReproduction) model() (*models.Reproduction, error) {
+	phase := models.ReproductionPhase(row.CurrentPhase)
+	if _, ok := models.AllowedPhaseTransitions[phase]; !ok {
+		return nil, errors.New("fase de reprodução inválida")
+	}
+
+	reproduction := &models.Reproduction{
+		ID:                     row.ID,
+		AnimalID:               row.AnimalID,
+		CurrentPhase:           phase,
+		InseminationType:       row.InseminationType,
+		VeterinaryConfirmation: row.VeterinaryConfirmation,
+		Observations:           row.Observations,
+	}
+	dates := []struct {
+		field  string
+		value  *string
+		target **time.Time
+	}{
+		{"insemination_date", row.InseminationDate, &reproduction.InseminationDate},
+		{"pregnancy_date", row.PregnancyDate, &reproduction.PregnancyDate},
+		{"expected_birth_date", row.ExpectedBirthDate, &reproduction.ExpectedBirthDate},
+		{"actual_birth_date", row.ActualBirthDate, &reproduction.ActualBirthDate},
+		{"lactation_start_date", row.LactationStartDate, &reproduction.LactationStartDate},
+		{"lactation_end_date", row.LactationEndDate, &reproduction.LactationEndDate},
+		{"dry_period_start_date", row.DryPeriodStartDate, &reproduction.DryPeriodStartDate},
+	}
+	for _, date := range dates {
+		parsed, err := parseRestoreOptionalDate(date.field, date.value)
+		if err != nil {
+			return nil, err
+		}
+		*date.target = parsed
+	}
+
+	createdAt, updatedAt, err := row.restoreTimestamps.parse()
+	if err != nil {
+		return nil, err
+	}
+	reproduction.CreatedAt = createdAt
+	reproduction.UpdatedAt = updatedAt
+	return reproduction, nil
+}
+
+func (row restoreReproductionEvent) model() (*models.ReproductionEvent, error) {
+	if !models.IsValidReproductionEventType(row.Type) {
+		return nil, fmt.Errorf("tipo de evento reprodutivo inválido: %q", row.Type)
+	}
+	if row.Type == models.ReproductionEventPregnancyCheck && row.PregnancyPositive == nil {
+		return nil, errors.New("resultado do diagnóstico de gestação é obrigatório")
+	}
+	date, err := parseRestoreDate("date", row.Date)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, _, err := row.restoreTimestamps.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReproductionEvent{
+		ID:                     row.ID,
+		AnimalID:               row.AnimalID,
+		Type:                   row.Type,
+		Date:                   date,
+		InseminationType:       row.InseminationType,
+		SireID:                 row.SireID,
+		PregnancyPositive:      row.PregnancyPositive,
+		VeterinaryConfirmation: row.VeterinaryConfirmation,
+		CalfID:                 row.CalfID,
+		Observations:           row.Observations,
+		CreatedAt:              createdAt,
+	}, nil
+}
+
+func (row restoreSale) model(farmID uint) (*models.Sale, error) {
+	saleDate, err := parseRestoreDate("sale_date", row.SaleDate)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, updatedAt, err := row.restoreTimestamps.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &models.Sale{
+		ID:        row.ID,
+		AnimalID:  row.AnimalID,
+		FarmID:    farmID,
+		BuyerName: row.BuyerName,
+		Price:     row.Price,
+		SaleDate:  saleDate,
+		Notes:     row.Notes,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+func (row restoreExpense) model(farmID uint) (*models.Expense, error) {
+	date, err := parseRestoreDate("date", row.Date)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, updatedAt, err := row.restoreTimestamps.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &models.Expense{
+		ID:          row.ID,
+		FarmID:      farmID,
+		Description: row.Description,
+		Amount:      row.Amount,
+		Category:    row.Category,
+		Date:        date,
+		Notes:       row.Notes,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}, nil
+}
+
+func (row restoreDebt) model(farmID uint) (*models.Debt, error) {
+	dueDate, err := parseRestoreOptionalDate("due_date", row.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	paidAt, err := parseRestoreOptionalDate("paid_at", row.PaidAt)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, updatedAt, err := row.restoreTimestamps.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &models.Debt{
+		ID:               row.ID,
+		FarmID:           farmID,
+		Person:           row.Person,
+		CounterpartyType: row.CounterpartyType,
+		SaleID:           row.SaleID,
+		ExpenseID:        row.ExpenseID,
+		Value:            row.Value,
+		PaidAmount:       row.PaidAmount,
+		DueDate:          dueDate,
+		Status:           row.Status,
+		PaidAt:           paidAt,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+	}, nil
+}
+
+func (row restoreDebtPayment) model() (*models.DebtPayment, error) {
+	paymentDate, err := parseRestoreDate("payment_date", row.PaymentDate)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, updatedAt, err := row.restoreTimestamps.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &models.DebtPayment{
+		ID:          row.ID,
+		DebtID:      row.DebtID,
+		Amount:      row.Amount,
+		PaymentDate: paymentDate,
+		Notes:       row.Notes,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}, nil
+}
+
+func (t restoreTimestamps) parse() (time.Time, time.Time, error) {
+	var parsed [2]time.Time
+	for i, value := range []string{t.CreatedAt, t.UpdatedAt} {
+		if value == "" {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("data e hora inválida: %q", value)
+		}
+		parsed[i] = timestamp
+	}
+	return parsed[0], parsed[1], nil
+}
+
+func parseRestoreDate(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%s é obrigatório", field)
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s inválido: %q, use AAAA-MM-DD", field, value)
+	}
+	return date, nil
+}
+
+func parseRestoreOptionalDate(field string, value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	date, err := parseRestoreDate(field, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type fakeRestoreRepository struct {
+	repository.RestoreRepository
+	restored *repository.FarmRestore
+}
+
+func (r *fakeRestoreRepository) CompanyExists(ctx context.Context, companyID uint) (bool, error) {
+	return true, nil
+}
+
+func (r *fakeRestoreRepository) Restore(ctx context.Context, data *repository.FarmRestore) (uint, error) {
+	r.restored = data
+	return 99, nil
+}
+
+const restoreArchiveAnimals = `"animals": [
+	{"id": 10, "ear_tag_number_local": 101, "animal_name": "Mimosa", "sex": 0, "breed": "Holandesa", "type": "Vaca"},
+	{"id": 11, "ear_tag_number_local": 102, "animal_name": "Bezerra", "sex": 0, "breed": "Holandesa", "type": "Bezerra", "mother_id": 10},
+	{"id": 12, "ear_tag_number_local": 103, "animal_name": "Touro", "sex": 1, "breed": "Gir", "type": "Touro"}
+],
+"reproductions": [
+	{"id": 1, "animal_id": 10, "current_phase": 0, "insemination_date": "2025-01-01", "actual_birth_date": "2025-10-01", "lactation_start_date": "2025-10-01"}
+]`
+
+func restoreTestArchive(extra string) string {
+	return `{"kind": "` + ExportArchiveKind + `", "version": 1, "farm": {"id": 5}, ` + restoreArchiveAnimals + extra + `}`
+}
+
+func TestRestoreArchiveRestoresExportedReproductionEvents(t *testing.T) {
+	repo := &fakeRestoreRepository{}
+	archive := restoreTestArchive(`, "reproduction_events": [
+		{"id": 1, "animal_id": 10, "type": "heat", "date": "2024-12-30"},
+		{"id": 2, "animal_id": 10, "type": "insemination", "date": "2025-01-01", "insemination_type": "natural", "sire_id": 12},
+		{"id": 3, "animal_id": 10, "type": "pregnancy_check", "date": "2025-02-01", "pregnancy_positive": true, "veterinary_confirmation": true},
+		{"id": 4, "animal_id": 10, "type": "calving", "date": "2025-10-01", "calf_id": 11}
+	]`)
+
+	result, err := NewRestoreService(repo, nil).RestoreArchive(context.Background(), 1, 1, strings.NewReader(archive), false)
+	if err != nil {
+		t.Fatalf("RestoreArchive: %v", err)
+	}
+	if len(result.Errors) != 0 || result.ReproductionEventsFromSnapshot {
+		t.Fatalf("errors = %+v, from snapshot = %v; want no errors and archived events", result.Errors, result.ReproductionEventsFromSnapshot)
+	}
+
+	events := repo.restored.ReproductionEvents
+	if len(events) != 4 || result.Counts[ExportEntityReproductionEvents] != 4 {
+		t.Fatalf("restored %d events (count %d), want 4", len(events), result.Counts[ExportEntityReproductionEvents])
+	}
+	if events[0].Type != models.ReproductionEventHeat {
+		t.Fatalf("first event = %+v, want the archived heat event", events[0])
+	}
+	if events[1].SireID == nil || *events[1].SireID != 12 {
+		t.Fatalf("insemination sire = %v, want archive animal 12", events[1].SireID)
+	}
+	if events[2].PregnancyPositive == nil || !*events[2].PregnancyPositive || !events[2].VeterinaryConfirmation {
+		t.Fatalf("pregnancy check = %+v, want confirmed positive", events[2])
+	}
+	if events[3].CalfID == nil || *events[3].CalfID != 11 {
+		t.Fatalf("calving calf = %v, want archive animal 11", events[3].CalfID)
+	}
+}
+
+func TestRestoreArchiveRejectsInvalidReproductionEvents(t *testing.T) {
+	repo := &fakeRestoreRepository{}
+	archive := restoreTestArchive(`, "reproduction_events": [
+		{"id": 1, "animal_id": 10, "type": "insemination", "date": "2025-01-01", "sire_id": 11},
+		{"id": 2, "animal_id": 10, "type": "calving", "date": "2025-10-01", "calf_id": 50},
+		{"id": 3, "animal_id": 12, "type": "heat", "date": "2025-01-01"}
+	]`)
+
+	result, err := NewRestoreService(repo, nil).RestoreArchive(context.Background(), 1, 1, strings.NewReader(archive), false)
+	if err != nil {
+		t.Fatalf("RestoreArchive: %v", err)
+	}
+	if len(result.Errors) != 3 || repo.restored != nil {
+		t.Fatalf("errors = %+v, restored = %v; want 3 errors and nothing restored", result.Errors, repo.restored != nil)
+	}
+	for i, rowErr := range result.Errors {
+		if rowErr.Entity != ExportEntityReproductionEvents || rowErr.Row != i+1 {
+			t.Fatalf("error %d = %+v, want reproduction event row %d", i, rowErr, i+1)
+		}
+	}
+}
+
+func TestRestoreArchiveFallsBackToSnapshotWithoutEvents(t *testing.T) {
+	repo := &fakeRestoreRepository{}
+
+	result, err := NewRestoreService(repo, nil).RestoreArchive(context.Background(), 1, 1, strings.NewReader(restoreTestArchive("")), true)
+	if err != nil {
+		t.Fatalf("RestoreArchive: %v", err)
+	}
+	if !result.ReproductionEventsFromSnapshot {
+		t.Fatal("dry run of an archive without reproduction_events did not report the snapshot fallback")
+	}
+	if result.Counts[ExportEntityReproductionEvents] != 2 {
+		t.Fatalf("reproduction events count = %d, want insemination and calving from the snapshot", result.Counts[ExportEntityReproductionEvents])
+	}
+}
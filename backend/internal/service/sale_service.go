@@ -40,7 +40,7 @@ func NewSaleService(saleRepo repository.SaleRepository, animalRepo repository.An
 	}
 }
 
-func (s *saleService) CreateSale(ctx context.Context, sale *models.Sale) error {
+func validateNewSale(sale *models.Sale) error {
 	if sale.AnimalID == 0 {
 		return errors.New("animal ID is required")
 	}
@@ -56,6 +56,13 @@ func (s *saleService) CreateSale(ctx context.Context, sale *models.Sale) error {
 	if sale.SaleDate.IsZero() {
 		return errors.New("sale date is required")
 	}
+	return nil
+}
+
+func (s *saleService) CreateSale(ctx context.Context, sale *models.Sale) error {
+	if err := validateNewSale(sale); err != nil {
+		return err
+	}
 
 	animal, err := s.animalRepo.FindByID(sale.AnimalID)
 	if err != nil {
@@ -252,4 +259,6 @@ func (s *saleService) invalidateDashboardCache(farmID uint) {
 			log.Printf(ErrInvalidateCache, err)
 		}
 	}
+
+	bumpFarmCacheVersion(s.cache, CacheScopePnL, farmID)
 }
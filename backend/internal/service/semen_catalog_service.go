@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+type SemenCatalogService interface {
+	CreateEntry(ctx context.Context, entry *models.SemenCatalogEntry) error
+	GetEntryByID(ctx context.Context, id uint, farmID uint) (*models.SemenCatalogEntry, error)
+	GetEntriesByFarmID(ctx context.Context, farmID uint, activeOnly bool) ([]*models.SemenCatalogEntry, error)
+	UpdateEntry(ctx context.Context, entry *models.SemenCatalogEntry, farmID uint) error
+	DeleteEntry(ctx context.Context, id uint, farmID uint) error
+}
+
+type semenCatalogService struct {
+	semenRepo  repository.SemenCatalogRepository
+	animalRepo repository.AnimalRepositoryInterface
+}
+
+func NewSemenCatalogService(semenRepo repository.SemenCatalogRepository, animalRepo repository.AnimalRepositoryInterface) SemenCatalogService {
+	return &semenCatalogService{
+		semenRepo:  semenRepo,
+		animalRepo: animalRepo,
+	}
+}
+
+func (s *semenCatalogService) validateEntry(entry *models.SemenCatalogEntry) error {
+	entry.BullName = strings.TrimSpace(entry.BullName)
+	entry.Code = strings.TrimSpace(entry.Code)
+	entry.Breed = strings.TrimSpace(entry.Breed)
+
+	if entry.BullName == "" {
+		return errors.New("bull name is required")
+	}
+	if entry.Code == "" {
+		return errors.New("semen code is required")
+	}
+	if entry.Breed == "" {
+		return errors.New("breed is required")
+	}
+	if entry.DosesAvailable < 0 {
+		return errors.New("doses available cannot be negative")
+	}
+
+	if entry.FatherID != nil {
+		if err := s.checkParent(*entry.FatherID, entry.FarmID, models.AnimalSexMale); err != nil {
+			return errors.New("bull father must be a male animal of this farm")
+		}
+	}
+	if entry.MotherID != nil {
+		if err := s.checkParent(*entry.MotherID, entry.FarmID, models.AnimalSexFemale); err != nil {
+			return errors.New("bull mother must be a female animal of this farm")
+		}
+	}
+
+	return nil
+}
+
+func (s *semenCatalogService) checkParent(animalID, farmID uint, sex int) error {
+	animal, err := s.animalRepo.FindByIDAndFarmID(animalID, farmID)
+	if err != nil {
+		return err
+	}
+	if animal == nil || animal.Sex != sex {
+		return errors.New(ErrAnimalNotFoundOrNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (s *semenCatalogService) CreateEntry(ctx context.Context, entry *models.SemenCatalogEntry) error {
+	if entry.FarmID == 0 {
+		return errors.New("farm ID is required")
+	}
+	if err := s.validateEntry(entry); err != nil {
+		return err
+	}
+
+	return s.semenRepo.Create(ctx, entry)
+}
+
+func (s *semenCatalogService) GetEntryByID(ctx context.Context, id uint, farmID uint) (*models.SemenCatalogEntry, error) {
+	return s.semenRepo.GetByID(ctx, id, farmID)
+}
+
+func (s *semenCatalogService) GetEntriesByFarmID(ctx context.Context, farmID uint, activeOnly bool) ([]*models.SemenCatalogEntry, error) {
+	return s.semenRepo.GetByFarmID(ctx, farmID, activeOnly)
+}
+
+func (s *semenCatalogService) UpdateEntry(ctx context.Context, entry *models.SemenCatalogEntry, farmID uint) error {
+	if entry.ID == 0 {
+		return errors.New("semen catalog entry ID is required")
+	}
+
+	entry.FarmID = farmID
+	if err := s.validateEntry(entry); err != nil {
+		return err
+	}
+
+	return s.semenRepo.Update(ctx, entry)
+}
+
+func (s *semenCatalogService) DeleteEntry(ctx context.Context, id uint, farmID uint) error {
+	return s.semenRepo.Delete(ctx, id, farmID)
+}
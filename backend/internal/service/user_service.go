@@ -65,10 +65,16 @@ func (s *UserService) CreateUser(user *models.User, personData *models.Person) e
 		return errors.New("farm ID is required")
 	}
 
-	if err := s.ensureFarmExists(user.FarmID); err != nil {
+	farmCreated, err := s.ensureFarmExists(user.FarmID)
+	if err != nil {
 		return fmt.Errorf("error ensuring farm exists: %w", err)
 	}
 
+	role := models.FarmRoleReadOnly
+	if farmCreated {
+		role = models.FarmRoleOwner
+	}
+
 	if err := s.repository.CreateWithPerson(user, personData); err != nil {
 		return err
 	}
@@ -77,6 +83,7 @@ func (s *UserService) CreateUser(user *models.User, personData *models.Person) e
 		UserID:    user.ID,
 		FarmID:    user.FarmID,
 		IsPrimary: true,
+		Role:      role,
 	}
 
 	if err := s.repository.CreateUserFarm(userFarm); err != nil {
@@ -117,17 +124,17 @@ func (s *UserService) ValidatePasswordByEmail(email, password string) (bool, err
 	return utils.CheckPasswordHash(password, user.Person.Password), nil
 }
 
-func (s *UserService) ensureFarmExists(farmID uint) error {
+func (s *UserService) ensureFarmExists(farmID uint) (bool, error) {
 	exists, err := s.repository.FarmExists(farmID)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if exists {
-		return nil
+		return false, nil
 	}
 
-	return s.repository.CreateDefaultFarm(farmID)
+	return true, s.repository.CreateDefaultFarm(farmID)
 }
 
 func (s *UserService) GetUserFarms(userID uint) ([]models.Farm, error) {
@@ -150,3 +157,30 @@ func (s *UserService) ShouldAutoSelectFarm(userID uint) (bool, error) {
 
 	return count == 1, nil
 }
+
+func (s *UserService) GetUserFarmRole(userID, farmID uint) (string, error) {
+	return s.repository.GetUserFarmRole(userID, farmID)
+}
+
+func (s *UserService) UpdateUserFarmRole(farmID, userID uint, role string) error {
+	if !models.IsValidFarmRole(role) {
+		return errors.New(ErrInvalidFarmRole)
+	}
+
+	currentRole, err := s.repository.GetUserFarmRole(userID, farmID)
+	if err != nil {
+		return errors.New(ErrUserNotInFarm)
+	}
+
+	if currentRole == models.FarmRoleOwner && role != models.FarmRoleOwner {
+		owners, err := s.repository.CountFarmUsersByRole(farmID, models.FarmRoleOwner)
+		if err != nil {
+			return err
+		}
+		if owners <= 1 {
+			return errors.New("farm must keep at least one owner")
+		}
+	}
+
+	return s.repository.UpdateUserFarmRole(userID, farmID, role)
+}
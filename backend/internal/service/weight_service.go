@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/fazendapro/FazendaPro-api/internal/models"
+	"github.com/fazendapro/FazendaPro-api/internal/repository"
+)
+
+const WeightSummaryLatestCount = 2
+
+type WeightGain struct {
+	Weight    *models.Weight
+	DaysSince int
+	DailyGain *float64
+}
+
+type AnimalWeightSummary struct {
+	AnimalID         uint
+	LatestWeight     *models.Weight
+	AverageDailyGain *float64
+	History          []WeightGain
+}
+
+type WeightService interface {
+	CreateWeight(ctx context.Context, weight *models.Weight, farmID uint) error
+	GetWeightByID(ctx context.Context, id uint, farmID uint) (*models.Weight, error)
+	GetWeightsByFarmID(ctx context.Context, farmID uint) ([]*models.Weight, error)
+	GetAnimalWeightSummary(ctx context.Context, animalID uint, farmID uint) (*AnimalWeightSummary, error)
+	UpdateWeight(ctx context.Context, weight *models.Weight, farmID uint) error
+	DeleteWeight(ctx context.Context, id uint, farmID uint) error
+}
+
+type weightService struct {
+	weightRepo repository.WeightRepository
+	animalRepo repository.AnimalRepositoryInterface
+}
+
+func NewWeightService(weightRepo repository.WeightRepository, animalRepo repository.AnimalRepositoryInterface) WeightService {
+	return &weightService{
+		weightRepo: weightRepo,
+		animalRepo: animalRepo,
+	}
+}
+
+func validateWeight(weight *models.Weight) error {
+	if weight.AnimalWeight <= 0 {
+		return errors.New("weight must be greater than zero")
+	}
+	if weight.Date.IsZero() {
+		return errors.New("weighing date is required")
+	}
+	if weight.Date.After(time.Now()) {
+		return errors.New("weighing date cannot be in the future")
+	}
+	return nil
+}
+
+func (s *weightService) checkAnimalFarm(animalID uint, farmID uint) error {
+	animal, err := s.animalRepo.FindByID(animalID)
+	if err != nil || animal == nil {
+		return errors.New(ErrAnimalNotFound)
+	}
+	if animal.FarmID != farmID {
+		return errors.New(ErrAnimalNotBelongsToFarm)
+	}
+	return nil
+}
+
+func (s *weightService) CreateWeight(ctx context.Context, weight *models.Weight, farmID uint) error {
+	if weight.AnimalID == 0 {
+		return errors.New("animal ID is required")
+	}
+	if farmID == 0 {
+		return errors.New("farm ID is required")
+	}
+	if err := validateWeight(weight); err != nil {
+		return err
+	}
+	if err := s.checkAnimalFarm(weight.AnimalID, farmID); err != nil {
+		return err
+	}
+
+	return s.weightRepo.Create(ctx, weight)
+}
+
+func (s *weightService) GetWeightByID(ctx context.Context, id uint, farmID uint) (*models.Weight, error) {
+	return s.weightRepo.GetByID(ctx, id, farmID)
+}
+
+func (s *weightService) GetWeightsByFarmID(ctx context.Context, farmID uint) ([]*models.Weight, error) {
+	return s.weightRepo.GetByFarmID(ctx, farmID)
+}
+
+func (s *weightService) GetAnimalWeightSummary(ctx context.Context, animalID uint, farmID uint) (*AnimalWeightSummary, error) {
+	if err := s.checkAnimalFarm(animalID, farmID); err != nil {
+		return nil, err
+	}
+
+	weights, err := s.weightRepo.GetByAnimalID(ctx, animalID, farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := buildWeightSummary(weights)
+	summary.AnimalID = animalID
+	return summary, nil
+}
+
+func (s *weightService) UpdateWeight(ctx context.Context, weight *models.Weight, farmID uint) error {
+	if weight.ID == 0 {
+		return errors.New("weight ID is required")
+	}
+	if err := validateWeight(weight); err != nil {
+		return err
+	}
+
+	existingWeight, err := s.weightRepo.GetByID(ctx, weight.ID, farmID)
+	if err != nil || existingWeight == nil {
+		return errors.New(ErrWeightNotFoundOrNotBelongsToFarm)
+	}
+	weight.AnimalID = existingWeight.AnimalID
+
+	return s.weightRepo.Update(ctx, weight, farmID)
+}
+
+func (s *weightService) DeleteWeight(ctx context.Context, id uint, farmID uint) error {
+	return s.weightRepo.Delete(ctx, id, farmID)
+}
+
+func buildWeightSummary(weights []*models.Weight) *AnimalWeightSummary {
+	sorted := make([]*models.Weight, len(weights))
+	copy(sorted, weights)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	summary := &AnimalWeightSummary{History: make([]WeightGain, 0, len(sorted))}
+	for i, weight := range sorted {
+		entry := WeightGain{Weight: weight}
+		if i > 0 {
+			entry.DaysSince, entry.DailyGain = dailyGain(sorted[i-1], weight)
+		}
+		summary.History = append(summary.History, entry)
+	}
+
+	if len(sorted) > 0 {
+		summary.LatestWeight = sorted[len(sorted)-1]
+	}
+	if len(sorted) > 1 {
+		_, summary.AverageDailyGain = dailyGain(sorted[0], sorted[len(sorted)-1])
+	}
+
+	return summary
+}
+
+func dailyGain(previous, current *models.Weight) (int, *float64) {
+	days := int(current.Date.Sub(previous.Date).Hours() / 24)
+	if days <= 0 {
+		return days, nil
+	}
+	gain := (current.AnimalWeight - previous.AnimalWeight) / float64(days)
+	return days, &gain
+}
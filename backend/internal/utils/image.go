@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+const (
+	ImageContentTypeJPEG = "image/jpeg"
+	ImageContentTypePNG  = "image/png"
+	ImageContentTypeGIF  = "image/gif"
+
+	maxImagePixels = 12_000_000
+)
+
+var ErrUnsupportedImage = errors.New("formato de imagem não suportado, use JPEG, PNG ou GIF")
+
+var ErrImageTooLarge = errors.New("imagem muito grande, máximo de 12 megapixels")
+
+var imageExtensions = map[string]string{
+	ImageContentTypeJPEG: ".jpg",
+	ImageContentTypePNG:  ".png",
+	ImageContentTypeGIF:  ".gif",
+}
+
+func DetectImageType(data []byte) (string, string, error) {
+	contentType := http.DetectContentType(data)
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return "", "", ErrUnsupportedImage
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", "", ErrUnsupportedImage
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return "", "", ErrImageTooLarge
+	}
+	return contentType, extension, nil
+}
+
+func MakeThumbnail(data []byte, maxSize int) ([]byte, error) {
+	if _, _, err := DetectImageType(data); err != nil {
+		return nil, err
+	}
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	bounds := source.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), source, bounds.Min, draw.Over)
+
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = max(1, height*maxSize/width)
+			width = maxSize
+		} else {
+			width = max(1, width*maxSize/height)
+			height = maxSize
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, downscale(flat, width, height), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func downscale(source *image.RGBA, width, height int) *image.RGBA {
+	sourceWidth, sourceHeight := source.Bounds().Dx(), source.Bounds().Dy()
+	if sourceWidth == width && sourceHeight == height {
+		return source
+	}
+
+	target := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sourceHeight/height, max((y+1)*sourceHeight/height, y*sourceHeight/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sourceWidth/width, max((x+1)*sourceWidth/width, x*sourceWidth/width+1)
+			var r, g, b, count int
+			for sy := y0; sy < y1; sy++ {
+				offset := sy*source.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += int(source.Pix[offset])
+					g += int(source.Pix[offset+1])
+					b += int(source.Pix[offset+2])
+					offset += 4
+					count++
+				}
+			}
+			i := y*target.Stride + x*4
+			target.Pix[i] = uint8(r / count)
+			target.Pix[i+1] = uint8(g / count)
+			target.Pix[i+2] = uint8(b / count)
+			target.Pix[i+3] = 0xff
+		}
+	}
+	return target
+}
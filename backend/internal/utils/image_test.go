@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func pngHeader(width, height uint32) []byte {
+	var data bytes.Buffer
+	data.WriteString("\x89PNG\r\n\x1a\n")
+
+	chunk := make([]byte, 17)
+	copy(chunk, "IHDR")
+	binary.BigEndian.PutUint32(chunk[4:], width)
+	binary.BigEndian.PutUint32(chunk[8:], height)
+	chunk[12] = 8
+	chunk[13] = 2
+
+	binary.Write(&data, binary.BigEndian, uint32(len(chunk)-4))
+	data.Write(chunk)
+	binary.Write(&data, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+	return data.Bytes()
+}
+
+func TestOversizedImageRejectedBeforeDecode(t *testing.T) {
+	header := pngHeader(4000, 3001)
+
+	if _, _, err := DetectImageType(header); !errors.Is(err, ErrImageTooLarge) {
+		t.Fatalf("DetectImageType of 4000x3001 header = %v, want ErrImageTooLarge", err)
+	}
+	if _, err := MakeThumbnail(header, 320); !errors.Is(err, ErrImageTooLarge) {
+		t.Fatalf("MakeThumbnail of 4000x3001 header = %v, want ErrImageTooLarge before decoding the missing pixel data", err)
+	}
+
+	if _, _, err := DetectImageType(pngHeader(4000, 3000)); err != nil {
+		t.Fatalf("DetectImageType of 4000x3000 header = %v, want accepted", err)
+	}
+}
+
+func TestMakeThumbnailAcceptsSmallImage(t *testing.T) {
+	var data bytes.Buffer
+	if err := png.Encode(&data, image.NewRGBA(image.Rect(0, 0, 640, 480))); err != nil {
+		t.Fatalf("encoding test image: %v", err)
+	}
+
+	thumbnail, err := MakeThumbnail(data.Bytes(), 320)
+	if err != nil {
+		t.Fatalf("MakeThumbnail: %v", err)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+	if err != nil || config.Width != 320 || config.Height != 240 {
+		t.Fatalf("thumbnail = %dx%d (%v), want 320x240", config.Width, config.Height, err)
+	}
+}
@@ -0,0 +1,293 @@
+package utils
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strconv"
+)
+
+const (
+	PDFPageWidth  = 595.28
+	PDFPageHeight = 841.89
+
+	pdfMaxImagePixels = 16 << 20
+)
+
+var pdfHelveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556,
+	278, 278, 584, 584, 584, 556, 1015,
+	667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611,
+	278, 278, 278, 469, 556, 333,
+	556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500,
+	334, 260, 334, 584,
+}
+
+var pdfHelveticaBoldWidths = []int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556,
+	333, 333, 584, 584, 584, 611, 975,
+	722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611,
+	333, 278, 333, 584, 556, 333,
+	556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611, 611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500,
+	389, 280, 389, 584,
+}
+
+var pdfAccentBase = buildPDFAccentBase()
+
+var pdfWinAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+func buildPDFAccentBase() map[rune]rune {
+	groups := map[rune]string{
+		'A': "ÀÁÂÃÄÅ", 'C': "Ç", 'E': "ÈÉÊË", 'I': "ÌÍÎÏ", 'N': "Ñ", 'O': "ÒÓÔÕÖ", 'U': "ÙÚÛÜ", 'Y': "Ý",
+		'a': "àáâãäå", 'c': "ç", 'e': "èéêë", 'i': "ìíîï", 'n': "ñ", 'o': "òóôõö", 'u': "ùúûü", 'y': "ýÿ",
+	}
+	base := make(map[rune]rune)
+	for letter, accented := range groups {
+		for _, r := range accented {
+			base[r] = letter
+		}
+	}
+	return base
+}
+
+type pdfImage struct {
+	width      int
+	height     int
+	colorSpace string
+	filter     string
+	data       []byte
+}
+
+type PDFDocument struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+	images  []pdfImage
+}
+
+func NewPDFDocument() *PDFDocument {
+	return &PDFDocument{}
+}
+
+func (d *PDFDocument) AddPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+}
+
+func (d *PDFDocument) PageCount() int {
+	return len(d.pages)
+}
+
+func (d *PDFDocument) SetPage(index int) {
+	d.current = d.pages[index]
+}
+
+func PDFTextWidth(text string, size float64, bold bool) float64 {
+	widths := pdfHelveticaWidths
+	if bold {
+		widths = pdfHelveticaBoldWidths
+	}
+	total := 0
+	for _, r := range text {
+		if base, ok := pdfAccentBase[r]; ok {
+			r = base
+		}
+		if r >= 32 && r <= 126 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+func (d *PDFDocument) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.current, "BT /%s %s Tf %s %s Td (", font, pdfNumber(size), pdfNumber(x), pdfNumber(PDFPageHeight-y))
+	d.current.Write(pdfEncodeText(text))
+	d.current.WriteString(") Tj ET\n")
+}
+
+func (d *PDFDocument) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.current, "q %s w %s %s m %s %s l S Q\n", pdfNumber(width),
+		pdfNumber(x1), pdfNumber(PDFPageHeight-y1), pdfNumber(x2), pdfNumber(PDFPageHeight-y2))
+}
+
+func (d *PDFDocument) FillRect(x, y, width, height, gray float64) {
+	fmt.Fprintf(d.current, "q %s g %s %s %s %s re f Q\n", pdfNumber(gray),
+		pdfNumber(x), pdfNumber(PDFPageHeight-y-height), pdfNumber(width), pdfNumber(height))
+}
+
+func (d *PDFDocument) AddImage(data []byte) (int, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, fmt.Errorf("imagem inválida: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > pdfMaxImagePixels {
+		return 0, errors.New("imagem muito grande")
+	}
+
+	img := pdfImage{width: config.Width, height: config.Height}
+	if format == "jpeg" && (config.ColorModel == color.YCbCrModel || config.ColorModel == color.GrayModel) {
+		img.colorSpace = "DeviceRGB"
+		if config.ColorModel == color.GrayModel {
+			img.colorSpace = "DeviceGray"
+		}
+		img.filter = "DCTDecode"
+		img.data = data
+	} else {
+		decoded, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return 0, fmt.Errorf("imagem inválida: %w", err)
+		}
+		bounds := decoded.Bounds()
+		pixels := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				r, g, b, a := decoded.At(x, y).RGBA()
+				background := 0xffff - a
+				pixels = append(pixels, byte((r+background)>>8), byte((g+background)>>8), byte((b+background)>>8))
+			}
+		}
+		img.colorSpace = "DeviceRGB"
+		img.filter = "FlateDecode"
+		img.data = pdfDeflate(pixels)
+	}
+
+	d.images = append(d.images, img)
+	return len(d.images) - 1, nil
+}
+
+func (d *PDFDocument) ImageSize(handle int) (int, int) {
+	return d.images[handle].width, d.images[handle].height
+}
+
+func (d *PDFDocument) DrawImage(handle int, x, y, width, height float64) {
+	fmt.Fprintf(d.current, "q %s 0 0 %s %s %s cm /Im%d Do Q\n", pdfNumber(width), pdfNumber(height),
+		pdfNumber(x), pdfNumber(PDFPageHeight-y-height), handle+1)
+}
+
+func (d *PDFDocument) Write(w io.Writer) error {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body func()) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n", len(offsets))
+		body()
+		out.WriteString("\nendobj\n")
+	}
+	stream := func(header string, data []byte) {
+		fmt.Fprintf(&out, "<< %s /Length %d >>\nstream\n", header, len(data))
+		out.Write(data)
+		out.WriteString("\nendstream")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	firstImage := 5
+	firstPage := firstImage + len(d.images)
+
+	object(func() { out.WriteString("<< /Type /Catalog /Pages 2 0 R >>") })
+	object(func() {
+		out.WriteString("<< /Type /Pages /Kids [")
+		for i := range d.pages {
+			fmt.Fprintf(&out, " %d 0 R", firstPage+i*2)
+		}
+		fmt.Fprintf(&out, " ] /Count %d >>", len(d.pages))
+	})
+	object(func() {
+		out.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	})
+	object(func() {
+		out.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	})
+
+	for _, img := range d.images {
+		object(func() {
+			stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+				img.width, img.height, img.colorSpace, img.filter), img.data)
+		})
+	}
+
+	var resources bytes.Buffer
+	resources.WriteString("<< /Font << /F1 3 0 R /F2 4 0 R >>")
+	if len(d.images) > 0 {
+		resources.WriteString(" /XObject <<")
+		for i := range d.images {
+			fmt.Fprintf(&resources, " /Im%d %d 0 R", i+1, firstImage+i)
+		}
+		resources.WriteString(" >>")
+	}
+	resources.WriteString(" >>")
+
+	for i, page := range d.pages {
+		contents := firstPage + i*2 + 1
+		object(func() {
+			fmt.Fprintf(&out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+				pdfNumber(PDFPageWidth), pdfNumber(PDFPageHeight), resources.String(), contents)
+		})
+		object(func() { stream("/Filter /FlateDecode", pdfDeflate(page.Bytes())) })
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+func pdfEncodeText(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		var b byte
+		switch {
+		case r >= 32 && r <= 126:
+			b = byte(r)
+		case r >= 0xa0 && r <= 0xff:
+			b = byte(r)
+		default:
+			special, ok := pdfWinAnsi[r]
+			if !ok {
+				special = '?'
+			}
+			b = special
+		}
+		if b == '(' || b == ')' || b == '\\' {
+			encoded = append(encoded, '\\')
+		}
+		encoded = append(encoded, b)
+	}
+	return encoded
+}
+
+func pdfNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func pdfDeflate(data []byte) []byte {
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	writer.Write(data)
+	writer.Close()
+	return buffer.Bytes()
+}
@@ -1,18 +1,24 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/fazendapro/FazendaPro-api/cmd/app"
 	"github.com/fazendapro/FazendaPro-api/config"
+	"github.com/fazendapro/FazendaPro-api/internal/cache"
+	"github.com/fazendapro/FazendaPro-api/internal/jobs"
 	"github.com/fazendapro/FazendaPro-api/internal/migrations"
 	"github.com/fazendapro/FazendaPro-api/internal/repository"
 	"github.com/fazendapro/FazendaPro-api/internal/routes"
+	"github.com/fazendapro/FazendaPro-api/internal/service"
+	"github.com/fazendapro/FazendaPro-api/internal/storage"
 	"github.com/getsentry/sentry-go"
 )
 
@@ -22,6 +28,31 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "alerts" {
+		runAlerts()
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "rebatch" {
+		runRebatch()
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		runExport(os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "restore" {
+		runRestore(os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate-photos" {
+		runMigratePhotos(os.Args[2:])
+		return
+	}
+
 	var port int
 	flag.IntVar(&port, "port", 8080, "Porta do servidor")
 	flag.Parse()
@@ -66,6 +97,22 @@ func main() {
 	var dbInstance *repository.Database
 	if db != nil {
 		dbInstance = db
+
+		if cfg.Alerts.IntervalMinutes > 0 || cfg.RebatchIntervalMinutes > 0 {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			serviceFactory := service.NewServiceFactory(repository.NewRepositoryFactory(db, nil))
+			scheduler := jobs.NewScheduler(app.Logger)
+			if cfg.Alerts.IntervalMinutes > 0 {
+				alertService := serviceFactory.CreateAlertService(jobs.AlertChannels(cfg.Alerts))
+				scheduler.Register(jobs.NewAlertsJob(alertService, time.Duration(cfg.Alerts.IntervalMinutes)*time.Minute, app.Logger))
+			}
+			if cfg.RebatchIntervalMinutes > 0 {
+				scheduler.Register(jobs.NewRebatchJob(serviceFactory.CreateBatchService(), time.Duration(cfg.RebatchIntervalMinutes)*time.Minute, app.Logger))
+			}
+			scheduler.Start(ctx)
+		}
 	}
 	r := routes.SetupRoutes(app, dbInstance, cfg)
 	server := http.Server{
@@ -102,3 +149,209 @@ func runMigrations() {
 	}
 	log.Println("Migrações executadas com sucesso!")
 }
+
+func runAlerts() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Erro ao carregar configuração:", err)
+	}
+
+	db, err := repository.NewDatabase(cfg)
+	if err != nil {
+		log.Fatal("Erro ao conectar ao banco:", err)
+	}
+	defer db.Close()
+
+	serviceFactory := service.NewServiceFactory(repository.NewRepositoryFactory(db, nil))
+	alertService := serviceFactory.CreateAlertService(jobs.AlertChannels(cfg.Alerts))
+
+	log.Println("Gerando alertas...")
+	summary, err := alertService.GenerateAlerts(context.Background(), time.Now())
+	if err != nil {
+		log.Fatal("Erro ao gerar alertas:", err)
+	}
+	log.Printf("Alertas gerados: %d reproduções verificadas, %d com erro, %d notificações criadas, %d fazendas notificadas, %d falhas de envio",
+		summary.ReproductionsScanned, summary.ReproductionsFailed, summary.NotificationsCreated, summary.FarmsNotified, summary.DeliveryFailures)
+	if summary.ReproductionsFailed > 0 {
+		log.Fatalf("Falha ao gerar alertas de %d reproduções", summary.ReproductionsFailed)
+	}
+}
+
+func runRebatch() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Erro ao carregar configuração:", err)
+	}
+
+	db, err := repository.NewDatabase(cfg)
+	if err != nil {
+		log.Fatal("Erro ao conectar ao banco:", err)
+	}
+	defer db.Close()
+
+	serviceFactory := service.NewServiceFactory(repository.NewRepositoryFactory(db, nil))
+	batchService := serviceFactory.CreateBatchService()
+
+	log.Println("Recalculando lotes...")
+	summary, err := batchService.RebatchAllFarms(time.Now())
+	if err != nil {
+		log.Fatal("Erro ao recalcular lotes:", err)
+	}
+	log.Printf("Lotes recalculados: %d fazendas processadas, %d com erro, %d animais avaliados, %d animais movidos",
+		summary.FarmsProcessed, summary.FarmsFailed, summary.AnimalsEvaluated, summary.AnimalsMoved)
+	if summary.FarmsFailed > 0 {
+		log.Fatalf("Falha ao recalcular lotes de %d fazendas", summary.FarmsFailed)
+	}
+}
+
+func runExport(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	farmID := flags.Uint("farm", 0, "ID da fazenda")
+	format := flags.String("format", service.ExportFormatJSON, "Formato: json, csv ou xlsx")
+	entity := flags.String("entity", "", "Entidade para csv/xlsx: "+strings.Join(service.ExportEntities, ", "))
+	out := flags.String("out", "", "Arquivo de saída (padrão: stdout)")
+	flags.Parse(args)
+
+	if *farmID == 0 {
+		log.Fatal("Informe a fazenda com -farm")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Erro ao carregar configuração:", err)
+	}
+
+	db, err := repository.NewDatabase(cfg)
+	if err != nil {
+		log.Fatal("Erro ao conectar ao banco:", err)
+	}
+	defer db.Close()
+
+	blobStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatal("Erro ao configurar armazenamento de arquivos:", err)
+	}
+
+	serviceFactory := service.NewServiceFactory(repository.NewRepositoryFactory(db, nil))
+	exportService := serviceFactory.CreateExportService(blobStorage)
+	if err := exportService.ValidateExport(*format, *entity); err != nil {
+		log.Fatal("Erro ao exportar dados:", err)
+	}
+
+	output := os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatal("Erro ao criar arquivo de saída:", err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	log.Printf("Exportando dados da fazenda %d (%s)...", *farmID, *format)
+	if *format == service.ExportFormatJSON {
+		err = exportService.WriteArchive(context.Background(), uint(*farmID), output)
+	} else {
+		err = exportService.WriteEntity(context.Background(), uint(*farmID), *entity, *format, output)
+	}
+	if err != nil {
+		log.Fatal("Erro ao exportar dados:", err)
+	}
+	log.Println("Exportação concluída com sucesso!")
+}
+
+func runRestore(args []string) {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	in := flags.String("in", "", "Arquivo JSON exportado")
+	companyID := flags.Uint("company", 0, "ID da empresa da nova fazenda")
+	ownerID := flags.Uint("owner", 0, "ID do usuário proprietário da nova fazenda (opcional)")
+	dryRun := flags.Bool("dry-run", true, "Apenas valida o arquivo, sem gravar")
+	flags.Parse(args)
+
+	if *in == "" || *companyID == 0 {
+		log.Fatal("Informe o arquivo com -in e a empresa com -company")
+	}
+
+	file, err := os.Open(*in)
+	if err != nil {
+		log.Fatal("Erro ao abrir arquivo:", err)
+	}
+	defer file.Close()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Erro ao carregar configuração:", err)
+	}
+
+	db, err := repository.NewDatabase(cfg)
+	if err != nil {
+		log.Fatal("Erro ao conectar ao banco:", err)
+	}
+	defer db.Close()
+
+	blobStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatal("Erro ao configurar armazenamento de arquivos:", err)
+	}
+
+	serviceFactory := service.NewServiceFactory(repository.NewRepositoryFactory(db, nil))
+	restoreService := serviceFactory.CreateRestoreService(blobStorage)
+
+	log.Printf("Restaurando %s na empresa %d...", *in, *companyID)
+	result, err := restoreService.RestoreArchive(context.Background(), uint(*companyID), uint(*ownerID), file, *dryRun)
+	if err != nil {
+		log.Fatal("Erro ao restaurar fazenda:", err)
+	}
+
+	for _, entity := range service.ExportEntities {
+		log.Printf("%s: %d registros", entity, result.Counts[entity])
+	}
+	if len(result.Errors) > 0 {
+		for _, rowErr := range result.Errors {
+			log.Printf("%s linha %d (id %d): %s", rowErr.Entity, rowErr.Row, rowErr.ID, rowErr.Message)
+		}
+		log.Fatalf("Nenhum dado restaurado: %d registros com erro", len(result.Errors))
+	}
+	if result.DryRun {
+		log.Println("Arquivo validado, nada foi gravado (use -dry-run=false para restaurar)")
+		return
+	}
+	log.Printf("Fazenda %d restaurada como fazenda %d com sucesso!", result.SourceFarmID, result.FarmID)
+}
+
+func runMigratePhotos(args []string) {
+	flags := flag.NewFlagSet("migrate-photos", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", true, "Apenas conta as fotos migráveis, sem gravar")
+	flags.Parse(args)
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Erro ao carregar configuração:", err)
+	}
+
+	db, err := repository.NewDatabase(cfg)
+	if err != nil {
+		log.Fatal("Erro ao conectar ao banco:", err)
+	}
+	defer db.Close()
+
+	blobStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatal("Erro ao configurar armazenamento de arquivos:", err)
+	}
+
+	cacheClient := cache.NewMemcacheClient(fmt.Sprintf("%s:%s", cfg.MemcachedHost, cfg.MemcachedPort))
+	serviceFactory := service.NewServiceFactory(repository.NewRepositoryFactory(db, cacheClient))
+	photoService := serviceFactory.CreateAnimalPhotoService(blobStorage)
+
+	log.Printf("Migrando fotos de animais para o armazenamento %s...", blobStorage.Name())
+	summary, err := photoService.MigrateLegacyPhotos(context.Background(), *dryRun)
+	if err != nil {
+		log.Fatal("Erro ao migrar fotos:", err)
+	}
+	log.Printf("Fotos migradas: %d animais verificados, %d fotos migradas, %d falhas",
+		summary.Scanned, summary.Migrated, summary.Failed)
+	if *dryRun {
+		log.Println("Nada foi gravado (use -dry-run=false para migrar)")
+	}
+}
@@ -7,6 +7,7 @@ import { Sale } from '../../types/sale';
 import { MilkCollection } from '../../types/milk-collection';
 import { Reproduction } from '../../types/reproduction';
 import { generateAnimalHistoryPDF } from './pdfGenerator';
+import { loadApiImageDataUrl } from '../../hooks/use-api-image';
 
 interface AnimalHistoryExportProps {
   animal: Animal;
@@ -23,14 +24,15 @@ export const AnimalHistoryExport: React.FC<AnimalHistoryExportProps> = ({
 }) => {
   const { t } = useTranslation();
 
-  const generatePDF = () => {
+  const generatePDF = async () => {
     try {
+      const animalImage = await loadApiImageDataUrl(animal.photo).catch(() => undefined);
       generateAnimalHistoryPDF({
         animal,
         sales,
         milkCollections,
         reproductions,
-        animalImage: animal.photo,
+        animalImage,
         translations: {
           animalHistoryExport: {
             title: t('animalHistoryExport.title'),
@@ -0,0 +1,68 @@
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest'
+import { renderHook, waitFor } from '@testing-library/react'
+import { useApiImage, loadApiImageDataUrl } from '../use-api-image'
+import { api } from '../../config/api'
+
+vi.mock('../../config/api', () => ({
+  api: {
+    get: vi.fn(),
+  },
+}))
+
+describe('useApiImage', () => {
+  const originalCreateObjectURL = URL.createObjectURL
+  const originalRevokeObjectURL = URL.revokeObjectURL
+
+  beforeEach(() => {
+    vi.clearAllMocks()
+    URL.createObjectURL = vi.fn(() => 'blob:foto')
+    URL.revokeObjectURL = vi.fn()
+  })
+
+  afterEach(() => {
+    URL.createObjectURL = originalCreateObjectURL
+    URL.revokeObjectURL = originalRevokeObjectURL
+  })
+
+  it('deve buscar a foto da API com autenticação e retornar uma object URL', async () => {
+    vi.mocked(api.get).mockResolvedValue({ data: new Blob(['foto'], { type: 'image/jpeg' }) })
+
+    const { result, unmount } = renderHook(() => useApiImage('/api/v1/photos/animals/mimosa.jpg'))
+
+    await waitFor(() => expect(result.current).toBe('blob:foto'))
+    expect(api.get).toHaveBeenCalledWith('/photos/animals/mimosa.jpg', { responseType: 'blob' })
+
+    unmount()
+    expect(URL.revokeObjectURL).toHaveBeenCalledWith('blob:foto')
+  })
+
+  it('deve manter fotos antigas em base64 sem chamar a API', () => {
+    const { result } = renderHook(() => useApiImage('data:image/png;base64,abc'))
+
+    expect(result.current).toBe('data:image/png;base64,abc')
+    expect(api.get).not.toHaveBeenCalled()
+  })
+
+  it('deve retornar undefined quando a busca falha', async () => {
+    vi.mocked(api.get).mockRejectedValue(new Error('403'))
+
+    const { result } = renderHook(() => useApiImage('/api/v1/photos/animals/outra.jpg'))
+
+    await waitFor(() => expect(api.get).toHaveBeenCalled())
+    expect(result.current).toBeUndefined()
+  })
+})
+
+describe('loadApiImageDataUrl', () => {
+  it('deve converter a foto da API em data URL', async () => {
+    vi.mocked(api.get).mockResolvedValue({ data: new Blob(['foto'], { type: 'image/jpeg' }) })
+
+    const dataUrl = await loadApiImageDataUrl('/api/v1/photos/animals/mimosa.jpg')
+
+    expect(dataUrl).toMatch(/^data:image\/jpeg;base64,/)
+  })
+
+  it('deve retornar undefined sem foto', async () => {
+    expect(await loadApiImageDataUrl(undefined)).toBeUndefined()
+  })
+})
@@ -1,4 +1,5 @@
 export { useFarm } from './useFarm'
 export { useModal } from './useModal'
 export { useIsMobile } from './use-is-mobile'
-export { useResponsive } from './use-responsive' 
\ No newline at end of file
+export { useResponsive } from './use-responsive'
+export { useApiImage, loadApiImageDataUrl } from './use-api-image'
\ No newline at end of file
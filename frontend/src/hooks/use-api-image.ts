@@ -0,0 +1,63 @@
+import { useEffect, useState } from 'react'
+import { api } from '../config/api'
+
+const API_PREFIX = '/api/v1/'
+
+const isApiPath = (path?: string): path is string => !!path && path.startsWith(API_PREFIX)
+
+const fetchApiImage = async (path: string): Promise<Blob> => {
+  const response = await api.get<Blob>(path.slice(API_PREFIX.length - 1), { responseType: 'blob' })
+  return response.data
+}
+
+export const loadApiImageDataUrl = async (path?: string): Promise<string | undefined> => {
+  if (!isApiPath(path)) {
+    return path
+  }
+
+  const blob = await fetchApiImage(path)
+  return new Promise((resolve, reject) => {
+    const reader = new FileReader()
+    reader.onload = () => resolve(reader.result as string)
+    reader.onerror = () => reject(reader.error)
+    reader.readAsDataURL(blob)
+  })
+}
+
+export const useApiImage = (path?: string): string | undefined => {
+  const [source, setSource] = useState<string | undefined>(isApiPath(path) ? undefined : path)
+
+  useEffect(() => {
+    if (!isApiPath(path)) {
+      setSource(path)
+      return
+    }
+
+    let objectUrl: string | undefined
+    let cancelled = false
+    setSource(undefined)
+
+    fetchApiImage(path)
+      .then((blob) => {
+        if (cancelled) {
+          return
+        }
+        objectUrl = URL.createObjectURL(blob)
+        setSource(objectUrl)
+      })
+      .catch(() => {
+        if (!cancelled) {
+          setSource(undefined)
+        }
+      })
+
+    return () => {
+      cancelled = true
+      if (objectUrl) {
+        URL.revokeObjectURL(objectUrl)
+      }
+    }
+  }, [path])
+
+  return source
+}
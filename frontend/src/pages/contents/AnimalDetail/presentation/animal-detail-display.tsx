@@ -5,6 +5,7 @@ import { useTranslation } from 'react-i18next';
 import { useAnimalDetailContext } from '../hooks';
 import { SEX_OPTIONS } from '../types';
 import { AnimalHistoryExport } from '../../../../components/AnimalHistoryExport/AnimalHistoryExport';
+import { useApiImage } from '../../../../hooks/use-api-image';
 
 const { Title, Text } = Typography;
 
@@ -15,6 +16,7 @@ interface AnimalDetailDisplayProps {
 export const AnimalDetailDisplay: React.FC<AnimalDetailDisplayProps> = ({ onEdit }) => {
   const { t } = useTranslation();
   const { animal, loading } = useAnimalDetailContext();
+  const photoSource = useApiImage(animal?.photo);
 
   if (loading) {
     return (
@@ -253,7 +255,7 @@ export const AnimalDetailDisplay: React.FC<AnimalDetailDisplayProps> = ({ onEdit
           >
             {animal.photo ? (
               <Image
-                src={animal.photo}
+                src={photoSource}
                 alt={t('animalDetail.photoAlt', { name: animal.animal_name })}
                 style={{ 
                   width: '100%', 
@@ -110,6 +110,8 @@ export const CreateReproductionModal = ({
     }
   };
 
+  const isEditing = !!editingReproduction;
+
   const handleCancel = () => {
     form.resetFields();
     onCancel();
@@ -151,6 +153,7 @@ export const CreateReproductionModal = ({
         <Select
           placeholder={t('animalTable.reproduction.selectAnimalPlaceholder')}
           showSearch
+          disabled={isEditing}
           loading={animalsLoading}
           optionFilterProp="children"
           filterOption={(input, option) =>
@@ -170,7 +173,7 @@ export const CreateReproductionModal = ({
           label={t('animalTable.reproduction.currentPhase')}
           rules={[{ required: true, message: t('animalTable.reproduction.phaseRequired') }]}
         >
-          <Select>
+          <Select disabled={isEditing}>
             {Object.entries(ReproductionPhaseLabels).map(([value, label]) => (
               <Select.Option key={value} value={parseInt(value)}>
                 {label}
@@ -183,14 +186,14 @@ export const CreateReproductionModal = ({
           name="insemination_date"
           label={t('animalTable.reproduction.inseminationDate')}
         >
-          <DatePicker style={{ width: '100%' }} />
+          <DatePicker style={{ width: '100%' }} disabled={isEditing} />
         </Form.Item>
 
         <Form.Item
           name="insemination_type"
           label={t('animalTable.reproduction.inseminationType')}
         >
-          <Select placeholder={t('animalTable.reproduction.selectInseminationType')}>
+          <Select placeholder={t('animalTable.reproduction.selectInseminationType')} disabled={isEditing}>
             <Select.Option value="Natural">Natural</Select.Option>
             <Select.Option value="Artificial">Inseminação Artificial</Select.Option>
           </Select>
@@ -200,42 +203,42 @@ export const CreateReproductionModal = ({
           name="pregnancy_date"
           label={t('animalTable.reproduction.pregnancyDate')}
         >
-          <DatePicker style={{ width: '100%' }} />
+          <DatePicker style={{ width: '100%' }} disabled={isEditing} />
         </Form.Item>
 
         <Form.Item
           name="expected_birth_date"
           label={t('animalTable.reproduction.expectedBirthDate')}
         >
-          <DatePicker style={{ width: '100%' }} />
+          <DatePicker style={{ width: '100%' }} disabled={isEditing} />
         </Form.Item>
 
         <Form.Item
           name="actual_birth_date"
           label={t('animalTable.reproduction.actualBirthDate')}
         >
-          <DatePicker style={{ width: '100%' }} />
+          <DatePicker style={{ width: '100%' }} disabled={isEditing} />
         </Form.Item>
 
         <Form.Item
           name="lactation_start_date"
           label={t('animalTable.reproduction.lactationStartDate')}
         >
-          <DatePicker style={{ width: '100%' }} />
+          <DatePicker style={{ width: '100%' }} disabled={isEditing} />
         </Form.Item>
 
         <Form.Item
           name="lactation_end_date"
           label={t('animalTable.reproduction.lactationEndDate')}
         >
-          <DatePicker style={{ width: '100%' }} />
+          <DatePicker style={{ width: '100%' }} disabled={isEditing} />
         </Form.Item>
 
         <Form.Item
           name="dry_period_start_date"
           label={t('animalTable.reproduction.dryPeriodStartDate')}
         >
-          <DatePicker style={{ width: '100%' }} />
+          <DatePicker style={{ width: '100%' }} disabled={isEditing} />
         </Form.Item>
 
         <Form.Item
@@ -243,7 +246,7 @@ export const CreateReproductionModal = ({
           label={t('animalTable.reproduction.veterinaryConfirmation')}
           valuePropName="checked"
         >
-          <Switch />
+          <Switch disabled={isEditing} />
         </Form.Item>
 
         <Form.Item
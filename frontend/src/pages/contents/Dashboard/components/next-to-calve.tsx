@@ -3,6 +3,23 @@ import { Card, List, Avatar, Spin, Tooltip } from 'antd';
 import { InfoCircleOutlined } from '@ant-design/icons';
 import { useTranslation } from 'react-i18next';
 import { useNextToCalve } from '../hooks/useNextToCalve';
+import { useApiImage } from '../../../../hooks/use-api-image';
+
+const NextToCalvePhoto: React.FC<{ photo?: string }> = ({ photo }) => {
+  const photoSource = useApiImage(photo);
+
+  return (
+    <Avatar 
+      src={photoSource} 
+      shape="square" 
+      size={{ xs: 60, sm: 80, md: 100 }}
+      style={{ 
+        minWidth: '60px',
+        minHeight: '60px'
+      }}
+    />
+  );
+};
 
 const NextToCalve: React.FC = () => {
   const { t } = useTranslation();
@@ -80,15 +97,7 @@ const NextToCalve: React.FC = () => {
           >
             <List.Item.Meta
               avatar={
-                <Avatar 
-                  src={item.photo} 
-                  shape="square" 
-                  size={{ xs: 60, sm: 80, md: 100 }}
-                  style={{ 
-                    minWidth: '60px',
-                    minHeight: '60px'
-                  }}
-                />
+                <NextToCalvePhoto photo={item.photo} />
               }
               title={
                 <div style={{ 